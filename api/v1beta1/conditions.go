@@ -0,0 +1,37 @@
+package v1beta1
+
+// Condition types set on the APIRule status.
+const (
+	// ConditionReady indicates that the APIRule and all its subresources are reconciled.
+	ConditionReady = "Ready"
+	// ConditionValidated indicates that the APIRule passed the validation.
+	ConditionValidated = "Validated"
+	// ConditionVirtualServiceReady indicates that the VirtualService of the APIRule is reconciled.
+	ConditionVirtualServiceReady = "VirtualServiceReady"
+	// ConditionAccessRuleReady indicates that the Oathkeeper Rules of the APIRule are reconciled.
+	ConditionAccessRuleReady = "AccessRuleReady"
+	// ConditionAuthorizationPolicyReady indicates that the AuthorizationPolicies of the APIRule are reconciled.
+	ConditionAuthorizationPolicyReady = "AuthorizationPolicyReady"
+	// ConditionRequestAuthenticationReady indicates that the RequestAuthentications of the APIRule are reconciled.
+	ConditionRequestAuthenticationReady = "RequestAuthenticationReady"
+	// ConditionEnvoyFilterReady indicates that the EnvoyFilters of the APIRule are reconciled.
+	ConditionEnvoyFilterReady = "EnvoyFilterReady"
+)
+
+// Condition reasons set on the APIRule status.
+const (
+	// ReasonReconciled is used when the reconciliation succeeded.
+	ReasonReconciled = "Reconciled"
+	// ReasonReconciliationFailed is used when an error happened during the reconciliation.
+	ReasonReconciliationFailed = "ReconciliationFailed"
+	// ReasonReconciliationSkipped is used when the reconciliation of a subresource was skipped because of a previous error.
+	ReasonReconciliationSkipped = "ReconciliationSkipped"
+	// ReasonSubresourceFailed is used when an error happened during the reconciliation of a subresource.
+	ReasonSubresourceFailed = "SubresourceReconciliationFailed"
+	// ReasonValidationSucceeded is used when the APIRule passed the validation.
+	ReasonValidationSucceeded = "ValidationSucceeded"
+	// ReasonValidationFailed is used when the APIRule failed the validation.
+	ReasonValidationFailed = "ValidationFailed"
+	// ReasonValidationSkipped is used when the validation of the APIRule was not executed.
+	ReasonValidationSkipped = "ValidationSkipped"
+)
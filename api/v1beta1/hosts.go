@@ -0,0 +1,99 @@
+package v1beta1
+
+// GetHosts returns the hosts defined in the host and hosts fields. The host field comes first and hosts that are defined
+// more than once are returned only once.
+func (s *APIRuleSpec) GetHosts() []string {
+	var hosts []string
+	seen := make(map[string]bool)
+
+	add := func(host string) {
+		if !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+
+	if s.Host != nil {
+		add(*s.Host)
+	}
+
+	for _, host := range s.Hosts {
+		if host != nil {
+			add(string(*host))
+		}
+	}
+
+	return hosts
+}
+
+// MeshGateway is the reserved gateway name that exposes the service to the workloads in the service mesh instead of an
+// ingress gateway.
+const MeshGateway = "mesh"
+
+// IsMeshOnly returns true if the service is exposed only to the workloads in the service mesh.
+func (s *APIRuleSpec) IsMeshOnly() bool {
+	if s.Gateway == nil || *s.Gateway != MeshGateway {
+		return false
+	}
+	for _, gateway := range s.Gateways {
+		if gateway != MeshGateway {
+			return false
+		}
+	}
+	return true
+}
+
+// IsExposedToMesh returns true if the service is exposed to the workloads in the service mesh, either only or in
+// addition to ingress gateways.
+func (s *APIRuleSpec) IsExposedToMesh() bool {
+	for _, gateway := range s.GetGateways() {
+		if gateway == MeshGateway {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMeshHosts returns the hosts defined in the meshHosts field. Hosts that are defined more than once are returned only
+// once.
+func (s *APIRuleSpec) GetMeshHosts() []string {
+	var hosts []string
+	seen := make(map[string]bool)
+
+	for _, host := range s.MeshHosts {
+		if host != nil && !seen[string(*host)] {
+			seen[string(*host)] = true
+			hosts = append(hosts, string(*host))
+		}
+	}
+
+	return hosts
+}
+
+// GetGateways returns the gateways defined in the gateway and gateways fields. The mesh gateway is added if mesh hosts are
+// defined. Gateways that are defined more than once are returned only once.
+func (s *APIRuleSpec) GetGateways() []string {
+	var gateways []string
+	seen := make(map[string]bool)
+
+	add := func(gateway string) {
+		if !seen[gateway] {
+			seen[gateway] = true
+			gateways = append(gateways, gateway)
+		}
+	}
+
+	if s.Gateway != nil {
+		add(*s.Gateway)
+	}
+
+	for _, gateway := range s.Gateways {
+		add(gateway)
+	}
+
+	if len(s.GetMeshHosts()) > 0 {
+		add(MeshGateway)
+	}
+
+	return gateways
+}
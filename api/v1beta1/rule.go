@@ -3,19 +3,65 @@ package v1beta1
 import "encoding/json"
 
 func (r *Rule) GetJwtIstioAuthorizations() []*JwtAuthorization {
-	// For Istio JWT we can safely assume that there is only one access strategy
-	accessStrategy := r.AccessStrategies[0]
-
 	authorizations := &JwtConfig{
 		Authorizations: []*JwtAuthorization{},
 	}
-	if accessStrategy.Config != nil {
-		_ = json.Unmarshal(accessStrategy.Config.Raw, authorizations)
+
+	// For Istio JWT we can safely assume that there is only one jwt access strategy, but it can be combined with extAuth
+	for _, accessStrategy := range r.AccessStrategies {
+		if accessStrategy.Name == "jwt" && accessStrategy.Config != nil {
+			_ = json.Unmarshal(accessStrategy.Config.Raw, authorizations)
+			break
+		}
 	}
 
 	return authorizations.Authorizations
 }
 
+// GetJwtIstioAuthentications returns the authentications of the jwt access strategy of the rule
+func (r *Rule) GetJwtIstioAuthentications() []*JwtAuthentication {
+	authentications := &JwtConfig{
+		Authentications: []*JwtAuthentication{},
+	}
+
+	for _, accessStrategy := range r.AccessStrategies {
+		if accessStrategy.Name == "jwt" && accessStrategy.Config != nil {
+			_ = json.Unmarshal(accessStrategy.Config.Raw, authentications)
+			break
+		}
+	}
+
+	return authentications.Authentications
+}
+
+// GetExtAuthConfig returns the configuration of the extAuth access strategy of the rule
+func (r *Rule) GetExtAuthConfig() (ExtAuthConfig, error) {
+	var config ExtAuthConfig
+
+	for _, accessStrategy := range r.AccessStrategies {
+		if accessStrategy.Name == "extAuth" && accessStrategy.Config != nil {
+			err := json.Unmarshal(accessStrategy.Config.Raw, &config)
+			return config, err
+		}
+	}
+
+	return config, nil
+}
+
+// GetIntrospectionConfig returns the configuration of the oauth2_introspection access strategy of the rule
+func (r *Rule) GetIntrospectionConfig() (IntrospectionConfig, error) {
+	var config IntrospectionConfig
+
+	for _, accessStrategy := range r.AccessStrategies {
+		if accessStrategy.Name == "oauth2_introspection" && accessStrategy.Config != nil {
+			err := json.Unmarshal(accessStrategy.Config.Raw, &config)
+			return config, err
+		}
+	}
+
+	return config, nil
+}
+
 func (r *Rule) GetCookieMutator() (CookieMutatorConfig, error) {
 	var mutatorConfig CookieMutatorConfig
 
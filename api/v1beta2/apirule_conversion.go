@@ -0,0 +1,875 @@
+package v1beta2
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/kyma-project/api-gateway/api/v1beta1"
+)
+
+const (
+	// V1beta1SpecAnnotation stores the v1beta1 spec of an APIRule that can't be fully represented in v1beta2,
+	// e.g. because it uses Ory access strategies or mutators.
+	V1beta1SpecAnnotation = "gateway.kyma-project.io/v1beta1-spec"
+	// V1beta2SpecAnnotation stores the v1beta2 spec of an APIRule that can't be fully represented in v1beta1,
+	// e.g. because it uses the Exact or Prefix path type.
+	V1beta2SpecAnnotation = "gateway.kyma-project.io/v1beta2-spec"
+
+	allowHandler   = "allow"
+	jwtHandler     = "jwt"
+	extAuthHandler = "extAuth"
+)
+
+// ConvertTo converts this APIRule to the Hub version (v1beta1).
+func (in *APIRule) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1beta1.APIRule)
+
+	spec := withDefaults(in.Spec)
+	dstSpec, err := convertSpecToHub(spec)
+	if err != nil {
+		return err
+	}
+
+	annotations := copyAnnotations(in.Annotations)
+	if original, ok := annotations[V1beta1SpecAnnotation]; ok {
+		delete(annotations, V1beta1SpecAnnotation)
+		var originalSpec v1beta1.APIRuleSpec
+		if err := json.Unmarshal([]byte(original), &originalSpec); err == nil {
+			dstSpec = restoreHubSpec(spec, dstSpec, originalSpec)
+		}
+	}
+
+	delete(annotations, V1beta2SpecAnnotation)
+	if !specsEqual(convertSpecFromHub(dstSpec), spec) {
+		raw, err := json.Marshal(spec)
+		if err != nil {
+			return err
+		}
+		annotations[V1beta2SpecAnnotation] = string(raw)
+	}
+
+	in.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Annotations = nilIfEmpty(annotations)
+	dst.Spec = dstSpec
+	dst.Status = convertStatusToHub(in.Status)
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (in *APIRule) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1beta1.APIRule)
+
+	spec := convertSpecFromHub(src.Spec)
+
+	annotations := copyAnnotations(src.Annotations)
+	if stored, ok := annotations[V1beta2SpecAnnotation]; ok {
+		delete(annotations, V1beta2SpecAnnotation)
+		var storedSpec APIRuleSpec
+		if err := json.Unmarshal([]byte(stored), &storedSpec); err == nil {
+			// The stored spec is only used if the hub spec wasn't changed since it was stored.
+			if hubSpec, err := convertSpecToHub(storedSpec); err == nil && specsEqual(hubSpec, src.Spec) {
+				spec = storedSpec
+			}
+		}
+	}
+
+	delete(annotations, V1beta1SpecAnnotation)
+	hubSpec, err := convertSpecToHub(spec)
+	if err != nil {
+		return err
+	}
+	if !specsEqual(hubSpec, src.Spec) {
+		raw, err := json.Marshal(src.Spec)
+		if err != nil {
+			return err
+		}
+		annotations[V1beta1SpecAnnotation] = string(raw)
+	}
+
+	src.ObjectMeta.DeepCopyInto(&in.ObjectMeta)
+	in.Annotations = nilIfEmpty(annotations)
+	in.Spec = spec
+	in.Status = convertStatusFromHub(src.Status)
+
+	return nil
+}
+
+func convertSpecToHub(spec APIRuleSpec) (v1beta1.APIRuleSpec, error) {
+	dst := v1beta1.APIRuleSpec{
+		Service:  convertServiceToHub(spec.Service),
+		Services: convertWeightedServicesToHub(spec.Services),
+		Gateway:  copyString(spec.Gateway),
+		Gateways: copyStrings(spec.Gateways),
+		Timeout:  (*v1beta1.Timeout)(copyTimeout(spec.Timeout)),
+		Cors:     convertCorsToHub(spec.Cors),
+		Retries:  convertRetriesToHub(spec.Retries),
+	}
+
+	// The first host is converted to the host field to keep APIRules with a single host compatible with clients that
+	// don't know the hosts field.
+	for i, host := range spec.Hosts {
+		if host == nil {
+			continue
+		}
+		if i == 0 {
+			h := string(*host)
+			dst.Host = &h
+		} else {
+			h := v1beta1.Host(*host)
+			dst.Hosts = append(dst.Hosts, &h)
+		}
+	}
+
+	for _, host := range spec.MeshHosts {
+		if host != nil {
+			h := v1beta1.Host(*host)
+			dst.MeshHosts = append(dst.MeshHosts, &h)
+		}
+	}
+
+	for _, rule := range spec.Rules {
+		dstRule := v1beta1.Rule{
+			Path:           rule.Path,
+			PathType:       convertPathTypeToHub(rule.PathType),
+			Service:        convertServiceToHub(rule.Service),
+			Services:       convertWeightedServicesToHub(rule.Services),
+			Methods:        copyStrings(rule.Methods),
+			Headers:        convertStringMatchMapToHub(rule.Headers),
+			QueryParams:    convertStringMatchMapToHub(rule.QueryParams),
+			IPAllowList:    copyStrings(rule.IPAllowList),
+			IPDenyList:     copyStrings(rule.IPDenyList),
+			From:           convertRuleSourceToHub(rule.From),
+			Gateways:       copyStrings(rule.Gateways),
+			Timeout:        (*v1beta1.Timeout)(copyTimeout(rule.Timeout)),
+			Cors:           convertCorsToHub(rule.Cors),
+			Retries:        convertRetriesToHub(rule.Retries),
+			RateLimit:      convertRateLimitToHub(rule.RateLimit),
+			Rewrite:        convertRewriteToHub(rule.Rewrite),
+			Redirect:       convertRedirectToHub(rule.Redirect),
+			DirectResponse: convertDirectResponseToHub(rule.DirectResponse),
+		}
+
+		if rule.NoAuth != nil && *rule.NoAuth {
+			dstRule.AccessStrategies = append(dstRule.AccessStrategies, &v1beta1.Authenticator{Handler: &v1beta1.Handler{Name: allowHandler}})
+		}
+
+		if rule.Jwt != nil {
+			handler, err := newHandler(jwtHandler, rule.Jwt)
+			if err != nil {
+				return dst, err
+			}
+			dstRule.AccessStrategies = append(dstRule.AccessStrategies, &v1beta1.Authenticator{Handler: handler})
+		}
+
+		if rule.ExtAuth != nil {
+			handler, err := newHandler(extAuthHandler, rule.ExtAuth)
+			if err != nil {
+				return dst, err
+			}
+			dstRule.AccessStrategies = append(dstRule.AccessStrategies, &v1beta1.Authenticator{Handler: handler})
+		}
+
+		if rule.Request != nil {
+			if len(rule.Request.Headers) > 0 {
+				handler, err := newHandler(v1beta1.HeaderMutator, v1beta1.HeaderMutatorConfig{Headers: rule.Request.Headers})
+				if err != nil {
+					return dst, err
+				}
+				dstRule.Mutators = append(dstRule.Mutators, &v1beta1.Mutator{Handler: handler})
+			}
+
+			if len(rule.Request.Cookies) > 0 {
+				handler, err := newHandler(v1beta1.CookieMutator, v1beta1.CookieMutatorConfig{Cookies: rule.Request.Cookies})
+				if err != nil {
+					return dst, err
+				}
+				dstRule.Mutators = append(dstRule.Mutators, &v1beta1.Mutator{Handler: handler})
+			}
+		}
+
+		dst.Rules = append(dst.Rules, dstRule)
+	}
+
+	return dst, nil
+}
+
+func convertSpecFromHub(spec v1beta1.APIRuleSpec) APIRuleSpec {
+	dst := APIRuleSpec{
+		Service:  convertServiceFromHub(spec.Service),
+		Services: convertWeightedServicesFromHub(spec.Services),
+		Gateway:  copyString(spec.Gateway),
+		Gateways: copyStrings(spec.Gateways),
+		Timeout:  copyTimeout((*Timeout)(spec.Timeout)),
+		Cors:     convertCorsFromHub(spec.Cors),
+		Retries:  convertRetriesFromHub(spec.Retries),
+	}
+
+	if spec.Host != nil {
+		host := Host(*spec.Host)
+		dst.Hosts = append(dst.Hosts, &host)
+	}
+
+	for _, host := range spec.Hosts {
+		if host != nil {
+			h := Host(*host)
+			dst.Hosts = append(dst.Hosts, &h)
+		}
+	}
+
+	for _, host := range spec.MeshHosts {
+		if host != nil {
+			h := Host(*host)
+			dst.MeshHosts = append(dst.MeshHosts, &h)
+		}
+	}
+
+	for _, rule := range spec.Rules {
+		dstRule := Rule{
+			Path:           rule.Path,
+			PathType:       convertPathTypeFromHub(rule.PathType),
+			Service:        convertServiceFromHub(rule.Service),
+			Services:       convertWeightedServicesFromHub(rule.Services),
+			Methods:        copyStrings(rule.Methods),
+			Headers:        convertStringMatchMapFromHub(rule.Headers),
+			QueryParams:    convertStringMatchMapFromHub(rule.QueryParams),
+			IPAllowList:    copyStrings(rule.IPAllowList),
+			IPDenyList:     copyStrings(rule.IPDenyList),
+			From:           convertRuleSourceFromHub(rule.From),
+			Gateways:       copyStrings(rule.Gateways),
+			Timeout:        copyTimeout((*Timeout)(rule.Timeout)),
+			Cors:           convertCorsFromHub(rule.Cors),
+			Retries:        convertRetriesFromHub(rule.Retries),
+			RateLimit:      convertRateLimitFromHub(rule.RateLimit),
+			Rewrite:        convertRewriteFromHub(rule.Rewrite),
+			Redirect:       convertRedirectFromHub(rule.Redirect),
+			DirectResponse: convertDirectResponseFromHub(rule.DirectResponse),
+		}
+
+		for _, strategy := range rule.AccessStrategies {
+			if strategy == nil || strategy.Handler == nil {
+				continue
+			}
+
+			switch strategy.Name {
+			case allowHandler:
+				if strategy.Config == nil {
+					noAuth := true
+					dstRule.NoAuth = &noAuth
+				}
+			case jwtHandler:
+				var jwtConfig JwtConfig
+				if decodeConfig(strategy.Config, &jwtConfig) && (len(jwtConfig.Authentications) > 0 || len(jwtConfig.Authorizations) > 0) {
+					dstRule.Jwt = &jwtConfig
+				}
+			case extAuthHandler:
+				var extAuth ExtAuth
+				if decodeConfig(strategy.Config, &extAuth) {
+					dstRule.ExtAuth = &extAuth
+				}
+			}
+		}
+
+		for _, mutator := range rule.Mutators {
+			if mutator == nil || mutator.Handler == nil {
+				continue
+			}
+
+			switch mutator.Name {
+			case v1beta1.HeaderMutator:
+				var headerConfig v1beta1.HeaderMutatorConfig
+				if decodeConfig(mutator.Config, &headerConfig) && headerConfig.HasHeaders() {
+					if dstRule.Request == nil {
+						dstRule.Request = &Request{}
+					}
+					dstRule.Request.Headers = headerConfig.Headers
+				}
+			case v1beta1.CookieMutator:
+				var cookieConfig v1beta1.CookieMutatorConfig
+				if decodeConfig(mutator.Config, &cookieConfig) && cookieConfig.HasCookies() {
+					if dstRule.Request == nil {
+						dstRule.Request = &Request{}
+					}
+					dstRule.Request.Cookies = cookieConfig.Cookies
+				}
+			}
+		}
+
+		dst.Rules = append(dst.Rules, dstRule)
+	}
+
+	return dst
+}
+
+// restoreHubSpec restores the parts of the original v1beta1 spec that can't be represented in v1beta2. If the v1beta2 spec
+// wasn't changed, the original spec is returned as is. Otherwise, the access strategies and mutators that are not
+// available in v1beta2 are added to the rules matching the same requests as the original rules. Rules that were changed
+// are restored from the original rule with the same index, if the number of rules didn't change.
+func restoreHubSpec(spec APIRuleSpec, converted v1beta1.APIRuleSpec, original v1beta1.APIRuleSpec) v1beta1.APIRuleSpec {
+	if specsEqual(convertSpecFromHub(original), spec) {
+		return original
+	}
+
+	originalIndexes := make([]int, len(converted.Rules))
+	restored := make([]bool, len(original.Rules))
+	for i := range converted.Rules {
+		originalIndexes[i] = -1
+		for j, originalRule := range original.Rules {
+			if !restored[j] && matchSameRequests(originalRule, converted.Rules[i]) {
+				originalIndexes[i] = j
+				restored[j] = true
+				break
+			}
+		}
+	}
+	if len(converted.Rules) == len(original.Rules) {
+		for i := range converted.Rules {
+			if originalIndexes[i] < 0 && !restored[i] {
+				originalIndexes[i] = i
+				restored[i] = true
+			}
+		}
+	}
+
+	for i, j := range originalIndexes {
+		if j < 0 {
+			continue
+		}
+
+		for _, strategy := range original.Rules[j].AccessStrategies {
+			if !isRepresentableAccessStrategy(strategy) {
+				converted.Rules[i].AccessStrategies = append(converted.Rules[i].AccessStrategies, strategy)
+			}
+		}
+
+		for _, mutator := range original.Rules[j].Mutators {
+			if mutator != nil && mutator.Handler != nil && mutator.Name != v1beta1.HeaderMutator && mutator.Name != v1beta1.CookieMutator {
+				converted.Rules[i].Mutators = append(converted.Rules[i].Mutators, mutator)
+			}
+		}
+	}
+
+	return converted
+}
+
+// matchSameRequests returns true if the rules have the same path, methods and match conditions
+func matchSameRequests(a, b v1beta1.Rule) bool {
+	return a.Path == b.Path &&
+		convertPathTypeFromHub(a.PathType) == convertPathTypeFromHub(b.PathType) &&
+		specsEqual(a.Methods, b.Methods) &&
+		specsEqual(a.Headers, b.Headers) &&
+		specsEqual(a.QueryParams, b.QueryParams) &&
+		specsEqual(a.Gateways, b.Gateways)
+}
+
+// convertPathTypeToHub omits the Regex path type, since it's the default of v1beta1 APIRules.
+func convertPathTypeToHub(pathType PathType) v1beta1.PathType {
+	if pathType == PathTypeRegex {
+		return ""
+	}
+	return v1beta1.PathType(pathType)
+}
+
+func convertPathTypeFromHub(pathType v1beta1.PathType) PathType {
+	if pathType == "" {
+		return PathTypeRegex
+	}
+	return PathType(pathType)
+}
+
+func isRepresentableAccessStrategy(strategy *v1beta1.Authenticator) bool {
+	if strategy == nil || strategy.Handler == nil {
+		return false
+	}
+
+	rule := convertSpecFromHub(v1beta1.APIRuleSpec{Rules: []v1beta1.Rule{{AccessStrategies: []*v1beta1.Authenticator{strategy}}}}).Rules[0]
+	return rule.NoAuth != nil || rule.Jwt != nil || rule.ExtAuth != nil
+}
+
+func withDefaults(spec APIRuleSpec) APIRuleSpec {
+	dst := *spec.DeepCopy()
+	for i := range dst.Rules {
+		if dst.Rules[i].PathType == "" {
+			dst.Rules[i].PathType = PathTypeRegex
+		}
+	}
+
+	return dst
+}
+
+func newHandler(name string, config interface{}) (*v1beta1.Handler, error) {
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1beta1.Handler{Name: name, Config: &runtime.RawExtension{Raw: raw}}, nil
+}
+
+// decodeConfig decodes the handler config into the given target. It returns false if the config contains fields that
+// are unknown to the target, as they would be lost by the conversion.
+func decodeConfig(config *runtime.RawExtension, target interface{}) bool {
+	if config == nil || len(config.Raw) == 0 {
+		return false
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(config.Raw))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(target) == nil
+}
+
+// specsEqual compares the JSON representation of the given specs, so that differences in the formatting of raw handler
+// configs are ignored.
+func specsEqual(a, b interface{}) bool {
+	var aJson, bJson interface{}
+	if !toGenericJson(a, &aJson) || !toGenericJson(b, &bJson) {
+		return false
+	}
+
+	return reflect.DeepEqual(aJson, bJson)
+}
+
+func toGenericJson(in interface{}, out *interface{}) bool {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(raw, out) == nil
+}
+
+func convertServiceToHub(service *Service) *v1beta1.Service {
+	if service == nil {
+		return nil
+	}
+
+	return &v1beta1.Service{
+		Name:       copyString(service.Name),
+		Namespace:  copyString(service.Namespace),
+		Port:       copyUint32(service.Port),
+		IsExternal: copyBool(service.IsExternal),
+	}
+}
+
+func convertServiceFromHub(service *v1beta1.Service) *Service {
+	if service == nil {
+		return nil
+	}
+
+	return &Service{
+		Name:       copyString(service.Name),
+		Namespace:  copyString(service.Namespace),
+		Port:       copyUint32(service.Port),
+		IsExternal: copyBool(service.IsExternal),
+	}
+}
+
+func convertWeightedServicesToHub(services []*WeightedService) []*v1beta1.WeightedService {
+	if services == nil {
+		return nil
+	}
+
+	dst := make([]*v1beta1.WeightedService, 0, len(services))
+	for _, service := range services {
+		if service != nil {
+			dst = append(dst, &v1beta1.WeightedService{Service: *convertServiceToHub(&service.Service), Weight: service.Weight})
+		}
+	}
+
+	return dst
+}
+
+func convertWeightedServicesFromHub(services []*v1beta1.WeightedService) []*WeightedService {
+	if services == nil {
+		return nil
+	}
+
+	dst := make([]*WeightedService, 0, len(services))
+	for _, service := range services {
+		if service != nil {
+			dst = append(dst, &WeightedService{Service: *convertServiceFromHub(&service.Service), Weight: service.Weight})
+		}
+	}
+
+	return dst
+}
+
+func convertRuleSourceToHub(source *RuleSource) *v1beta1.RuleSource {
+	if source == nil {
+		return nil
+	}
+
+	return &v1beta1.RuleSource{
+		Principals: copyStrings(source.Principals),
+		Namespaces: copyStrings(source.Namespaces),
+	}
+}
+
+func convertRuleSourceFromHub(source *v1beta1.RuleSource) *RuleSource {
+	if source == nil {
+		return nil
+	}
+
+	return &RuleSource{
+		Principals: copyStrings(source.Principals),
+		Namespaces: copyStrings(source.Namespaces),
+	}
+}
+
+func convertCorsToHub(cors *CorsPolicy) *v1beta1.CorsPolicy {
+	if cors == nil {
+		return nil
+	}
+
+	dst := &v1beta1.CorsPolicy{
+		AllowMethods:     copyStrings(cors.AllowMethods),
+		AllowHeaders:     copyStrings(cors.AllowHeaders),
+		ExposeHeaders:    copyStrings(cors.ExposeHeaders),
+		AllowCredentials: copyBool(cors.AllowCredentials),
+		MaxAge:           copyUint64(cors.MaxAge),
+	}
+
+	for _, origin := range cors.AllowOrigins {
+		if origin != nil {
+			dst.AllowOrigins = append(dst.AllowOrigins, &v1beta1.StringMatch{Exact: origin.Exact, Prefix: origin.Prefix, Regex: origin.Regex})
+		}
+	}
+
+	return dst
+}
+
+func convertCorsFromHub(cors *v1beta1.CorsPolicy) *CorsPolicy {
+	if cors == nil {
+		return nil
+	}
+
+	dst := &CorsPolicy{
+		AllowMethods:     copyStrings(cors.AllowMethods),
+		AllowHeaders:     copyStrings(cors.AllowHeaders),
+		ExposeHeaders:    copyStrings(cors.ExposeHeaders),
+		AllowCredentials: copyBool(cors.AllowCredentials),
+		MaxAge:           copyUint64(cors.MaxAge),
+	}
+
+	for _, origin := range cors.AllowOrigins {
+		if origin != nil {
+			dst.AllowOrigins = append(dst.AllowOrigins, &StringMatch{Exact: origin.Exact, Prefix: origin.Prefix, Regex: origin.Regex})
+		}
+	}
+
+	return dst
+}
+
+func convertRetriesToHub(retries *Retries) *v1beta1.Retries {
+	if retries == nil {
+		return nil
+	}
+
+	return &v1beta1.Retries{
+		Attempts:      retries.Attempts,
+		PerTryTimeout: (*v1beta1.Timeout)(copyTimeout(retries.PerTryTimeout)),
+		RetryOn:       retries.RetryOn,
+	}
+}
+
+func convertRetriesFromHub(retries *v1beta1.Retries) *Retries {
+	if retries == nil {
+		return nil
+	}
+
+	return &Retries{
+		Attempts:      retries.Attempts,
+		PerTryTimeout: copyTimeout((*Timeout)(retries.PerTryTimeout)),
+		RetryOn:       retries.RetryOn,
+	}
+}
+
+func convertRateLimitToHub(rateLimit *RateLimit) *v1beta1.RateLimit {
+	if rateLimit == nil {
+		return nil
+	}
+
+	dst := &v1beta1.RateLimit{
+		Requests: rateLimit.Requests,
+		Unit:     v1beta1.RateLimitUnit(rateLimit.Unit),
+	}
+
+	for _, bucket := range rateLimit.Buckets {
+		if bucket == nil {
+			continue
+		}
+		dstBucket := &v1beta1.RateLimitBucket{StaticClientIP: bucket.StaticClientIP, Requests: bucket.Requests}
+		if bucket.Header != nil {
+			dstBucket.Header = &v1beta1.HeaderMatch{Name: bucket.Header.Name, Value: bucket.Header.Value}
+		}
+		dst.Buckets = append(dst.Buckets, dstBucket)
+	}
+
+	return dst
+}
+
+func convertRateLimitFromHub(rateLimit *v1beta1.RateLimit) *RateLimit {
+	if rateLimit == nil {
+		return nil
+	}
+
+	dst := &RateLimit{
+		Requests: rateLimit.Requests,
+		Unit:     RateLimitUnit(rateLimit.Unit),
+	}
+
+	for _, bucket := range rateLimit.Buckets {
+		if bucket == nil {
+			continue
+		}
+		dstBucket := &RateLimitBucket{StaticClientIP: bucket.StaticClientIP, Requests: bucket.Requests}
+		if bucket.Header != nil {
+			dstBucket.Header = &HeaderMatch{Name: bucket.Header.Name, Value: bucket.Header.Value}
+		}
+		dst.Buckets = append(dst.Buckets, dstBucket)
+	}
+
+	return dst
+}
+
+func convertStringMatchMapToHub(matches map[string]*StringMatch) map[string]*v1beta1.StringMatch {
+	if matches == nil {
+		return nil
+	}
+
+	dst := make(map[string]*v1beta1.StringMatch, len(matches))
+	for name, match := range matches {
+		if match == nil {
+			dst[name] = nil
+			continue
+		}
+		dst[name] = &v1beta1.StringMatch{Exact: match.Exact, Prefix: match.Prefix, Regex: match.Regex}
+	}
+
+	return dst
+}
+
+func convertStringMatchMapFromHub(matches map[string]*v1beta1.StringMatch) map[string]*StringMatch {
+	if matches == nil {
+		return nil
+	}
+
+	dst := make(map[string]*StringMatch, len(matches))
+	for name, match := range matches {
+		if match == nil {
+			dst[name] = nil
+			continue
+		}
+		dst[name] = &StringMatch{Exact: match.Exact, Prefix: match.Prefix, Regex: match.Regex}
+	}
+
+	return dst
+}
+
+func convertRewriteToHub(rewrite *Rewrite) *v1beta1.Rewrite {
+	if rewrite == nil {
+		return nil
+	}
+
+	return &v1beta1.Rewrite{
+		StripPrefix: rewrite.StripPrefix,
+		URI:         rewrite.URI,
+		Authority:   rewrite.Authority,
+	}
+}
+
+func convertRewriteFromHub(rewrite *v1beta1.Rewrite) *Rewrite {
+	if rewrite == nil {
+		return nil
+	}
+
+	return &Rewrite{
+		StripPrefix: rewrite.StripPrefix,
+		URI:         rewrite.URI,
+		Authority:   rewrite.Authority,
+	}
+}
+
+func convertRedirectToHub(redirect *Redirect) *v1beta1.Redirect {
+	if redirect == nil {
+		return nil
+	}
+
+	return &v1beta1.Redirect{
+		URI:       redirect.URI,
+		Authority: redirect.Authority,
+		Scheme:    redirect.Scheme,
+		Code:      redirect.Code,
+	}
+}
+
+func convertRedirectFromHub(redirect *v1beta1.Redirect) *Redirect {
+	if redirect == nil {
+		return nil
+	}
+
+	return &Redirect{
+		URI:       redirect.URI,
+		Authority: redirect.Authority,
+		Scheme:    redirect.Scheme,
+		Code:      redirect.Code,
+	}
+}
+
+func convertDirectResponseToHub(directResponse *DirectResponse) *v1beta1.DirectResponse {
+	if directResponse == nil {
+		return nil
+	}
+
+	return &v1beta1.DirectResponse{
+		Status: directResponse.Status,
+		Body:   directResponse.Body,
+	}
+}
+
+func convertDirectResponseFromHub(directResponse *v1beta1.DirectResponse) *DirectResponse {
+	if directResponse == nil {
+		return nil
+	}
+
+	return &DirectResponse{
+		Status: directResponse.Status,
+		Body:   directResponse.Body,
+	}
+}
+
+func convertStatusToHub(status APIRuleStatus) v1beta1.APIRuleStatus {
+	return v1beta1.APIRuleStatus{
+		LastProcessedTime:           status.LastProcessedTime.DeepCopy(),
+		ObservedGeneration:          status.ObservedGeneration,
+		APIRuleStatus:               convertResourceStatusToHub(status.APIRuleStatus),
+		VirtualServiceStatus:        convertResourceStatusToHub(status.VirtualServiceStatus),
+		AccessRuleStatus:            convertResourceStatusToHub(status.AccessRuleStatus),
+		RequestAuthenticationStatus: convertResourceStatusToHub(status.RequestAuthenticationStatus),
+		AuthorizationPolicyStatus:   convertResourceStatusToHub(status.AuthorizationPolicyStatus),
+		EnvoyFilterStatus:           convertResourceStatusToHub(status.EnvoyFilterStatus),
+		Hosts:                       copyStrings(status.Hosts),
+		Conditions:                  copyConditions(status.Conditions),
+	}
+}
+
+func convertStatusFromHub(status v1beta1.APIRuleStatus) APIRuleStatus {
+	return APIRuleStatus{
+		LastProcessedTime:           status.LastProcessedTime.DeepCopy(),
+		ObservedGeneration:          status.ObservedGeneration,
+		APIRuleStatus:               convertResourceStatusFromHub(status.APIRuleStatus),
+		VirtualServiceStatus:        convertResourceStatusFromHub(status.VirtualServiceStatus),
+		AccessRuleStatus:            convertResourceStatusFromHub(status.AccessRuleStatus),
+		RequestAuthenticationStatus: convertResourceStatusFromHub(status.RequestAuthenticationStatus),
+		AuthorizationPolicyStatus:   convertResourceStatusFromHub(status.AuthorizationPolicyStatus),
+		EnvoyFilterStatus:           convertResourceStatusFromHub(status.EnvoyFilterStatus),
+		Hosts:                       copyStrings(status.Hosts),
+		Conditions:                  copyConditions(status.Conditions),
+	}
+}
+
+func convertResourceStatusToHub(status *APIRuleResourceStatus) *v1beta1.APIRuleResourceStatus {
+	if status == nil {
+		return nil
+	}
+
+	return &v1beta1.APIRuleResourceStatus{
+		Code:        v1beta1.StatusCode(status.Code),
+		Description: status.Description,
+	}
+}
+
+func convertResourceStatusFromHub(status *v1beta1.APIRuleResourceStatus) *APIRuleResourceStatus {
+	if status == nil {
+		return nil
+	}
+
+	return &APIRuleResourceStatus{
+		Code:        StatusCode(status.Code),
+		Description: status.Description,
+	}
+}
+
+func copyConditions(conditions []metav1.Condition) []metav1.Condition {
+	if conditions == nil {
+		return nil
+	}
+
+	dst := make([]metav1.Condition, len(conditions))
+	for i := range conditions {
+		conditions[i].DeepCopyInto(&dst[i])
+	}
+
+	return dst
+}
+
+func copyAnnotations(annotations map[string]string) map[string]string {
+	dst := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		dst[k] = v
+	}
+
+	return dst
+}
+
+func nilIfEmpty(annotations map[string]string) map[string]string {
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	return annotations
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
+func copyBool(b *bool) *bool {
+	if b == nil {
+		return nil
+	}
+	c := *b
+	return &c
+}
+
+func copyUint32(u *uint32) *uint32 {
+	if u == nil {
+		return nil
+	}
+	c := *u
+	return &c
+}
+
+func copyUint64(u *uint64) *uint64 {
+	if u == nil {
+		return nil
+	}
+	c := *u
+	return &c
+}
+
+func copyTimeout(t *Timeout) *Timeout {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+
+	return append([]string{}, s...)
+}
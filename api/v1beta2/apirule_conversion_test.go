@@ -0,0 +1,797 @@
+package v1beta2_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/api/v1beta2"
+)
+
+var _ = Describe("APIRule conversion", func() {
+
+	host := "httpbin.kyma.local"
+	gateway := "kyma-system/kyma-gateway"
+	serviceName := "httpbin"
+	serviceNamespace := "default"
+	var servicePort uint32 = 8000
+	isExternal := true
+	var timeout v1beta1.Timeout = 30
+	var ruleTimeout v1beta1.Timeout = 60
+
+	jwtConfig := `{"authentications":[{"issuer":"https://example.com/","jwksUri":"https://example.com/.well-known/jwks.json","fromHeaders":[{"name":"x-jwt","prefix":"Bearer "}],"fromParams":["jwt"]}],"authorizations":[{"requiredScopes":["read"],"audiences":["httpbin"]}]}`
+
+	hubRule := func(path string, strategies []*v1beta1.Authenticator, mutators ...*v1beta1.Mutator) v1beta1.Rule {
+		return v1beta1.Rule{
+			Path:             path,
+			Methods:          []string{"GET", "POST"},
+			AccessStrategies: strategies,
+			Mutators:         mutators,
+		}
+	}
+
+	handler := func(name string, config string) *v1beta1.Handler {
+		h := &v1beta1.Handler{Name: name}
+		if config != "" {
+			h.Config = &runtime.RawExtension{Raw: []byte(config)}
+		}
+		return h
+	}
+
+	hubAPIRule := func(rules ...v1beta1.Rule) *v1beta1.APIRule {
+		return &v1beta1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test",
+				Namespace:   "default",
+				Generation:  2,
+				Labels:      map[string]string{"app": "httpbin"},
+				Annotations: map[string]string{"note": "value"},
+			},
+			Spec: v1beta1.APIRuleSpec{
+				Host:    &host,
+				Gateway: &gateway,
+				Service: &v1beta1.Service{
+					Name:       &serviceName,
+					Namespace:  &serviceNamespace,
+					Port:       &servicePort,
+					IsExternal: &isExternal,
+				},
+				Timeout: &timeout,
+				Rules:   rules,
+			},
+			Status: v1beta1.APIRuleStatus{
+				LastProcessedTime:  &metav1.Time{},
+				ObservedGeneration: 2,
+				APIRuleStatus:      &v1beta1.APIRuleResourceStatus{Code: v1beta1.StatusOK, Description: "ok"},
+				VirtualServiceStatus: &v1beta1.APIRuleResourceStatus{
+					Code: v1beta1.StatusOK,
+				},
+				AccessRuleStatus:            &v1beta1.APIRuleResourceStatus{Code: v1beta1.StatusSkipped},
+				RequestAuthenticationStatus: &v1beta1.APIRuleResourceStatus{Code: v1beta1.StatusError, Description: "error"},
+				AuthorizationPolicyStatus:   &v1beta1.APIRuleResourceStatus{Code: v1beta1.StatusOK},
+			},
+		}
+	}
+
+	roundTrip := func(hub *v1beta1.APIRule) (*v1beta2.APIRule, *v1beta1.APIRule) {
+		spoke := &v1beta2.APIRule{}
+		Expect(spoke.ConvertFrom(hub)).To(Succeed())
+
+		result := &v1beta1.APIRule{}
+		Expect(spoke.ConvertTo(result)).To(Succeed())
+
+		return spoke, result
+	}
+
+	Context("from v1beta1 to v1beta2", func() {
+
+		It("should convert spec, metadata and status fields", func() {
+			// given
+			rule := hubRule("/headers", []*v1beta1.Authenticator{{Handler: handler("allow", "")}})
+			rule.Service = &v1beta1.Service{Name: &serviceName, Port: &servicePort}
+			rule.Timeout = &ruleTimeout
+			hub := hubAPIRule(rule)
+
+			// when
+			spoke := &v1beta2.APIRule{}
+			err := spoke.ConvertFrom(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spoke.Name).To(Equal("test"))
+			Expect(spoke.Namespace).To(Equal("default"))
+			Expect(spoke.Generation).To(BeEquivalentTo(2))
+			Expect(spoke.Labels).To(Equal(map[string]string{"app": "httpbin"}))
+			Expect(spoke.Annotations).To(Equal(map[string]string{"note": "value"}))
+
+			Expect(spoke.Spec.Hosts).To(HaveLen(1))
+			Expect(string(*spoke.Spec.Hosts[0])).To(Equal(host))
+			Expect(*spoke.Spec.Gateway).To(Equal(gateway))
+			Expect(*spoke.Spec.Service.Name).To(Equal(serviceName))
+			Expect(*spoke.Spec.Service.Namespace).To(Equal(serviceNamespace))
+			Expect(*spoke.Spec.Service.Port).To(Equal(servicePort))
+			Expect(*spoke.Spec.Service.IsExternal).To(BeTrue())
+			Expect(*spoke.Spec.Timeout).To(BeEquivalentTo(30))
+
+			Expect(spoke.Spec.Rules).To(HaveLen(1))
+			Expect(spoke.Spec.Rules[0].Path).To(Equal("/headers"))
+			Expect(spoke.Spec.Rules[0].PathType).To(Equal(v1beta2.PathTypeRegex))
+			Expect(spoke.Spec.Rules[0].Methods).To(ConsistOf("GET", "POST"))
+			Expect(*spoke.Spec.Rules[0].Service.Name).To(Equal(serviceName))
+			Expect(*spoke.Spec.Rules[0].Service.Port).To(Equal(servicePort))
+			Expect(*spoke.Spec.Rules[0].Timeout).To(BeEquivalentTo(60))
+			Expect(*spoke.Spec.Rules[0].NoAuth).To(BeTrue())
+			Expect(spoke.Spec.Rules[0].Jwt).To(BeNil())
+			Expect(spoke.Spec.Rules[0].ExtAuth).To(BeNil())
+
+			Expect(spoke.Status.LastProcessedTime).NotTo(BeNil())
+			Expect(spoke.Status.ObservedGeneration).To(BeEquivalentTo(2))
+			Expect(spoke.Status.APIRuleStatus).To(Equal(&v1beta2.APIRuleResourceStatus{Code: v1beta2.StatusOK, Description: "ok"}))
+			Expect(spoke.Status.VirtualServiceStatus.Code).To(Equal(v1beta2.StatusOK))
+			Expect(spoke.Status.AccessRuleStatus.Code).To(Equal(v1beta2.StatusSkipped))
+			Expect(spoke.Status.RequestAuthenticationStatus).To(Equal(&v1beta2.APIRuleResourceStatus{Code: v1beta2.StatusError, Description: "error"}))
+			Expect(spoke.Status.AuthorizationPolicyStatus.Code).To(Equal(v1beta2.StatusOK))
+		})
+
+		It("should convert jwt access strategy to typed jwt config", func() {
+			// given
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("jwt", jwtConfig)}}))
+
+			// when
+			spoke := &v1beta2.APIRule{}
+			err := spoke.ConvertFrom(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spoke.Annotations).NotTo(HaveKey(v1beta2.V1beta1SpecAnnotation))
+
+			jwt := spoke.Spec.Rules[0].Jwt
+			Expect(jwt).NotTo(BeNil())
+			Expect(jwt.Authentications).To(HaveLen(1))
+			Expect(jwt.Authentications[0].Issuer).To(Equal("https://example.com/"))
+			Expect(jwt.Authentications[0].JwksUri).To(Equal("https://example.com/.well-known/jwks.json"))
+			Expect(jwt.Authentications[0].FromHeaders).To(Equal([]*v1beta2.JwtHeader{{Name: "x-jwt", Prefix: "Bearer "}}))
+			Expect(jwt.Authentications[0].FromParams).To(ConsistOf("jwt"))
+			Expect(jwt.Authorizations).To(HaveLen(1))
+			Expect(jwt.Authorizations[0].RequiredScopes).To(ConsistOf("read"))
+			Expect(jwt.Authorizations[0].Audiences).To(ConsistOf("httpbin"))
+			Expect(spoke.Spec.Rules[0].NoAuth).To(BeNil())
+		})
+
+		It("should convert extAuth access strategy to typed extAuth config", func() {
+			// given
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("extAuth", `{"provider":"oauth2-proxy"}`)}}))
+
+			// when
+			spoke := &v1beta2.APIRule{}
+			err := spoke.ConvertFrom(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spoke.Spec.Rules[0].ExtAuth).To(Equal(&v1beta2.ExtAuth{Provider: "oauth2-proxy"}))
+		})
+
+		It("should convert header and cookie mutators to request modifications", func() {
+			// given
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("jwt", jwtConfig)}},
+				&v1beta1.Mutator{Handler: handler("header", `{"headers":{"x-header":"value"}}`)},
+				&v1beta1.Mutator{Handler: handler("cookie", `{"cookies":{"cookie":"value"}}`)},
+			))
+
+			// when
+			spoke := &v1beta2.APIRule{}
+			err := spoke.ConvertFrom(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spoke.Spec.Rules[0].Request).NotTo(BeNil())
+			Expect(spoke.Spec.Rules[0].Request.Headers).To(Equal(map[string]string{"x-header": "value"}))
+			Expect(spoke.Spec.Rules[0].Request.Cookies).To(Equal(map[string]string{"cookie": "value"}))
+			Expect(spoke.Annotations).NotTo(HaveKey(v1beta2.V1beta1SpecAnnotation))
+		})
+
+		It("should store the original spec in an annotation when Ory access strategies are used", func() {
+			// given
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{
+				{Handler: handler("oauth2_introspection", `{"required_scope":["read"]}`)},
+			}, &v1beta1.Mutator{Handler: handler("id_token", "")}))
+
+			// when
+			spoke := &v1beta2.APIRule{}
+			err := spoke.ConvertFrom(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spoke.Annotations).To(HaveKey(v1beta2.V1beta1SpecAnnotation))
+			Expect(spoke.Annotations).To(HaveKeyWithValue("note", "value"))
+			Expect(spoke.Spec.Rules[0].NoAuth).To(BeNil())
+			Expect(spoke.Spec.Rules[0].Jwt).To(BeNil())
+			Expect(spoke.Spec.Rules[0].ExtAuth).To(BeNil())
+			Expect(spoke.Spec.Rules[0].Request).To(BeNil())
+		})
+
+		It("should not treat Ory jwt config as Istio jwt config", func() {
+			// given
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{
+				{Handler: handler("jwt", `{"trusted_issuers":["https://example.com/"],"jwks_urls":["https://example.com/.well-known/jwks.json"]}`)},
+			}))
+
+			// when
+			spoke := &v1beta2.APIRule{}
+			err := spoke.ConvertFrom(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spoke.Spec.Rules[0].Jwt).To(BeNil())
+			Expect(spoke.Annotations).To(HaveKey(v1beta2.V1beta1SpecAnnotation))
+		})
+
+		It("should not modify the annotations of the source object", func() {
+			// given
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("noop", "")}}))
+
+			// when
+			spoke := &v1beta2.APIRule{}
+			err := spoke.ConvertFrom(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spoke.Annotations).To(HaveKey(v1beta2.V1beta1SpecAnnotation))
+			Expect(hub.Annotations).To(Equal(map[string]string{"note": "value"}))
+		})
+	})
+
+	Context("round trip from v1beta1", func() {
+
+		DescribeTable("should not lose any data",
+			func(rules ...v1beta1.Rule) {
+				// given
+				hub := hubAPIRule(rules...)
+
+				// when
+				_, result := roundTrip(hub.DeepCopy())
+
+				// then
+				Expect(result.ObjectMeta).To(Equal(hub.ObjectMeta))
+				Expect(result.Spec).To(Equal(hub.Spec))
+				Expect(result.Status).To(Equal(hub.Status))
+			},
+			Entry("allow", hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("allow", "")}})),
+			Entry("istio jwt with mutators", hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("jwt", jwtConfig)}},
+				&v1beta1.Mutator{Handler: handler("header", `{"headers":{"x-header":"value"}}`)},
+				&v1beta1.Mutator{Handler: handler("cookie", `{"cookies":{"cookie":"value"}}`)},
+			)),
+			Entry("mutators in non-default order", hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("jwt", jwtConfig)}},
+				&v1beta1.Mutator{Handler: handler("cookie", `{"cookies":{"cookie":"value"}}`)},
+				&v1beta1.Mutator{Handler: handler("header", `{"headers":{"x-header":"value"}}`)},
+			)),
+			Entry("extAuth", hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("extAuth", `{"provider":"oauth2-proxy"}`)}})),
+			Entry("ory jwt", hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("jwt", `{"trusted_issuers":["https://example.com/"]}`)}})),
+			Entry("oauth2_introspection with id_token mutator", hubRule("/.*",
+				[]*v1beta1.Authenticator{{Handler: handler("oauth2_introspection", `{"required_scope":["read"]}`)}},
+				&v1beta1.Mutator{Handler: handler("id_token", `{"ttl":"60s"}`)},
+			)),
+			Entry("noop and allow", hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("noop", "")}, {Handler: handler("allow", "")}})),
+			Entry("multiple rules",
+				hubRule("/headers", []*v1beta1.Authenticator{{Handler: handler("noop", "")}}),
+				hubRule("/ip", []*v1beta1.Authenticator{{Handler: handler("jwt", jwtConfig)}}),
+			),
+		)
+
+		It("should convert additional hosts", func() {
+			// given
+			additionalHost := v1beta1.Host("httpbin.example.com")
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Spec.Hosts = []*v1beta1.Host{&additionalHost}
+			hub.Status.Hosts = []string{host, "httpbin.example.com"}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Annotations).NotTo(HaveKey(v1beta2.V1beta1SpecAnnotation))
+			Expect(spoke.Spec.Hosts).To(HaveLen(2))
+			Expect(string(*spoke.Spec.Hosts[0])).To(Equal(host))
+			Expect(string(*spoke.Spec.Hosts[1])).To(Equal("httpbin.example.com"))
+			Expect(spoke.Status.Hosts).To(ConsistOf(host, "httpbin.example.com"))
+			Expect(result.Spec).To(Equal(hub.Spec))
+			Expect(result.Status).To(Equal(hub.Status))
+		})
+
+		It("should convert mesh hosts", func() {
+			// given
+			meshHost := v1beta1.Host("httpbin.internal")
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Spec.MeshHosts = []*v1beta1.Host{&meshHost}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Spec.MeshHosts).To(HaveLen(1))
+			Expect(string(*spoke.Spec.MeshHosts[0])).To(Equal("httpbin.internal"))
+			Expect(result.Spec).To(Equal(hub.Spec))
+		})
+
+		It("should convert gateways", func() {
+			// given
+			rule := hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("allow", "")}})
+			rule.Gateways = []string{"partners/partner-gateway"}
+			hub := hubAPIRule(rule)
+			hub.Spec.Gateways = []string{"partners/partner-gateway"}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Spec.Gateways).To(Equal([]string{"partners/partner-gateway"}))
+			Expect(spoke.Spec.Rules[0].Gateways).To(Equal([]string{"partners/partner-gateway"}))
+			Expect(result.Spec).To(Equal(hub.Spec))
+		})
+
+		It("should convert weighted services", func() {
+			// given
+			canaryName := "httpbin-canary"
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Spec.Service = nil
+			hub.Spec.Services = []*v1beta1.WeightedService{
+				{Service: v1beta1.Service{Name: &serviceName, Port: &servicePort}, Weight: 90},
+				{Service: v1beta1.Service{Name: &canaryName, Namespace: &serviceNamespace, Port: &servicePort}, Weight: 10},
+			}
+			hub.Spec.Rules[0].Services = []*v1beta1.WeightedService{
+				{Service: v1beta1.Service{Name: &canaryName, Port: &servicePort}, Weight: 100},
+			}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Spec.Services).To(HaveLen(2))
+			Expect(*spoke.Spec.Services[1].Name).To(Equal(canaryName))
+			Expect(spoke.Spec.Services[1].Weight).To(BeEquivalentTo(10))
+			Expect(spoke.Spec.Rules[0].Services).To(HaveLen(1))
+			Expect(result.Spec).To(Equal(hub.Spec))
+		})
+
+		It("should convert CORS policies", func() {
+			// given
+			allowCredentials := true
+			var maxAge uint64 = 600
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Spec.Cors = &v1beta1.CorsPolicy{
+				AllowOrigins:     []*v1beta1.StringMatch{{Exact: "https://example.com"}, {Regex: "https://.*\\.example\\.com"}},
+				AllowMethods:     []string{"GET"},
+				AllowHeaders:     []string{"Authorization"},
+				ExposeHeaders:    []string{"X-Request-Id"},
+				AllowCredentials: &allowCredentials,
+				MaxAge:           &maxAge,
+			}
+			hub.Spec.Rules[0].Cors = &v1beta1.CorsPolicy{
+				AllowOrigins: []*v1beta1.StringMatch{{Prefix: "https://app."}},
+			}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Spec.Cors.AllowOrigins).To(HaveLen(2))
+			Expect(spoke.Spec.Cors.AllowOrigins[0].Exact).To(Equal("https://example.com"))
+			Expect(*spoke.Spec.Cors.AllowCredentials).To(BeTrue())
+			Expect(*spoke.Spec.Cors.MaxAge).To(BeEquivalentTo(600))
+			Expect(spoke.Spec.Rules[0].Cors.AllowOrigins[0].Prefix).To(Equal("https://app."))
+			Expect(result.Spec).To(Equal(hub.Spec))
+		})
+
+		It("should convert retry policies", func() {
+			// given
+			var perTryTimeout v1beta1.Timeout = 5
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Spec.Retries = &v1beta1.Retries{Attempts: 3, PerTryTimeout: &perTryTimeout, RetryOn: "5xx,connect-failure"}
+			hub.Spec.Rules[0].Retries = &v1beta1.Retries{Attempts: 0}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Spec.Retries.Attempts).To(BeEquivalentTo(3))
+			Expect(*spoke.Spec.Retries.PerTryTimeout).To(BeEquivalentTo(5))
+			Expect(spoke.Spec.Retries.RetryOn).To(Equal("5xx,connect-failure"))
+			Expect(spoke.Spec.Rules[0].Retries).NotTo(BeNil())
+			Expect(result.Spec).To(Equal(hub.Spec))
+		})
+
+		It("should convert rate limits", func() {
+			// given
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Spec.Rules[0].RateLimit = &v1beta1.RateLimit{
+				Requests: 10,
+				Unit:     v1beta1.RateLimitUnitMinute,
+				Buckets: []*v1beta1.RateLimitBucket{
+					{Header: &v1beta1.HeaderMatch{Name: "x-tier", Value: "premium"}, Requests: 100},
+					{StaticClientIP: "10.0.0.1", Requests: 1},
+				},
+			}
+			hub.Status.EnvoyFilterStatus = &v1beta1.APIRuleResourceStatus{Code: v1beta1.StatusOK}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Spec.Rules[0].RateLimit.Requests).To(BeEquivalentTo(10))
+			Expect(spoke.Spec.Rules[0].RateLimit.Unit).To(Equal(v1beta2.RateLimitUnitMinute))
+			Expect(spoke.Spec.Rules[0].RateLimit.Buckets).To(HaveLen(2))
+			Expect(spoke.Spec.Rules[0].RateLimit.Buckets[0].Header.Value).To(Equal("premium"))
+			Expect(spoke.Status.EnvoyFilterStatus.Code).To(Equal(v1beta2.StatusOK))
+			Expect(result.Spec).To(Equal(hub.Spec))
+			Expect(result.Status).To(Equal(hub.Status))
+		})
+
+		It("should convert rewrite", func() {
+			// given
+			hub := hubAPIRule(hubRule("/orders/v1/.*", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Spec.Rules[0].Rewrite = &v1beta1.Rewrite{StripPrefix: "/orders/v1", Authority: "orders.internal"}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Spec.Rules[0].Rewrite.StripPrefix).To(Equal("/orders/v1"))
+			Expect(spoke.Spec.Rules[0].Rewrite.Authority).To(Equal("orders.internal"))
+			Expect(result.Spec).To(Equal(hub.Spec))
+		})
+
+		It("should convert redirect and direct response", func() {
+			// given
+			hub := hubAPIRule(
+				hubRule("/old/.*", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}),
+				hubRule("/robots.txt", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}),
+			)
+			hub.Spec.Rules[0].Redirect = &v1beta1.Redirect{URI: "/new", Authority: "example.com", Scheme: "https", Code: 308}
+			hub.Spec.Rules[1].DirectResponse = &v1beta1.DirectResponse{Status: 200, Body: "User-agent: *"}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Spec.Rules[0].Redirect.Code).To(BeEquivalentTo(308))
+			Expect(spoke.Spec.Rules[1].DirectResponse.Body).To(Equal("User-agent: *"))
+			Expect(result.Spec).To(Equal(hub.Spec))
+		})
+
+		It("should convert header and query parameter matches", func() {
+			// given
+			hub := hubAPIRule(hubRule("/orders", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Spec.Rules[0].Headers = map[string]*v1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}
+			hub.Spec.Rules[0].QueryParams = map[string]*v1beta1.StringMatch{"beta": {Regex: "true|yes"}}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Spec.Rules[0].Headers["X-Api-Version"].Exact).To(Equal("2"))
+			Expect(spoke.Spec.Rules[0].QueryParams["beta"].Regex).To(Equal("true|yes"))
+			Expect(result.Spec).To(Equal(hub.Spec))
+		})
+
+		It("should convert IP allow and deny lists", func() {
+			// given
+			hub := hubAPIRule(hubRule("/admin", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Spec.Rules[0].IPAllowList = []string{"10.0.0.0/8", "192.168.0.1"}
+			hub.Spec.Rules[0].IPDenyList = []string{"10.1.0.0/16"}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Spec.Rules[0].IPAllowList).To(Equal([]string{"10.0.0.0/8", "192.168.0.1"}))
+			Expect(spoke.Spec.Rules[0].IPDenyList).To(Equal([]string{"10.1.0.0/16"}))
+			Expect(result.Spec).To(Equal(hub.Spec))
+		})
+
+		It("should convert workload sources", func() {
+			// given
+			hub := hubAPIRule(hubRule("/orders", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Spec.Rules[0].From = &v1beta1.RuleSource{
+				Principals: []string{"cluster.local/ns/orders/sa/checkout"},
+				Namespaces: []string{"payments"},
+			}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Spec.Rules[0].From.Principals).To(Equal([]string{"cluster.local/ns/orders/sa/checkout"}))
+			Expect(spoke.Spec.Rules[0].From.Namespaces).To(Equal([]string{"payments"}))
+			Expect(result.Spec).To(Equal(hub.Spec))
+		})
+
+		It("should convert status conditions", func() {
+			// given
+			hub := hubAPIRule(hubRule("/.*", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Status.Conditions = []metav1.Condition{{
+				Type:               v1beta1.ConditionReady,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: 2,
+				Reason:             v1beta1.ReasonValidationFailed,
+				Message:            "Validation error: Attribute \"spec.host\": Host was nil",
+			}}
+
+			// when
+			spoke, result := roundTrip(hub.DeepCopy())
+
+			// then
+			Expect(spoke.Status.Conditions).To(Equal(hub.Status.Conditions))
+			Expect(result.Status).To(Equal(hub.Status))
+		})
+
+		It("should keep Ory access strategies when the spec is changed in v1beta2", func() {
+			// given
+			hub := hubAPIRule(
+				hubRule("/headers", []*v1beta1.Authenticator{{Handler: handler("oauth2_introspection", `{"required_scope":["read"]}`)}},
+					&v1beta1.Mutator{Handler: handler("id_token", "")}),
+				hubRule("/ip", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}),
+			)
+			spoke := &v1beta2.APIRule{}
+			Expect(spoke.ConvertFrom(hub)).To(Succeed())
+
+			var newTimeout v1beta2.Timeout = 120
+			spoke.Spec.Timeout = &newTimeout
+			spoke.Spec.Rules[0].Methods = []string{"GET"}
+
+			// when
+			result := &v1beta1.APIRule{}
+			err := spoke.ConvertTo(result)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Annotations).NotTo(HaveKey(v1beta2.V1beta1SpecAnnotation))
+			Expect(*result.Spec.Timeout).To(BeEquivalentTo(120))
+			Expect(result.Spec.Rules).To(HaveLen(2))
+			Expect(result.Spec.Rules[0].Methods).To(ConsistOf("GET"))
+			Expect(result.Spec.Rules[0].AccessStrategies).To(HaveLen(1))
+			Expect(result.Spec.Rules[0].AccessStrategies[0].Name).To(Equal("oauth2_introspection"))
+			Expect(result.Spec.Rules[0].Mutators).To(HaveLen(1))
+			Expect(result.Spec.Rules[0].Mutators[0].Name).To(Equal("id_token"))
+			Expect(result.Spec.Rules[1].AccessStrategies).To(HaveLen(1))
+			Expect(result.Spec.Rules[1].AccessStrategies[0].Name).To(Equal("allow"))
+		})
+
+		It("should keep the Ory access strategies and mutators of rules with the same path when the spec is changed in v1beta2", func() {
+			// given
+			getItems := hubRule("/items", []*v1beta1.Authenticator{{Handler: handler("noop", "")}}, &v1beta1.Mutator{Handler: handler("id_token", "")})
+			getItems.Methods = []string{"GET"}
+			postItems := hubRule("/items", []*v1beta1.Authenticator{{Handler: handler("oauth2_introspection", `{"required_scope":["write"]}`)}})
+			postItems.Methods = []string{"POST"}
+			hub := hubAPIRule(getItems, postItems)
+
+			spoke, result := roundTrip(hub)
+			Expect(result.Spec).To(Equal(hub.Spec))
+
+			var newTimeout v1beta2.Timeout = 120
+			spoke.Spec.Timeout = &newTimeout
+			spoke.Spec.Rules[0], spoke.Spec.Rules[1] = spoke.Spec.Rules[1], spoke.Spec.Rules[0]
+
+			// when
+			result = &v1beta1.APIRule{}
+			err := spoke.ConvertTo(result)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Spec.Rules).To(HaveLen(2))
+			Expect(result.Spec.Rules[0].Methods).To(ConsistOf("POST"))
+			Expect(result.Spec.Rules[0].AccessStrategies).To(HaveLen(1))
+			Expect(result.Spec.Rules[0].AccessStrategies[0].Name).To(Equal("oauth2_introspection"))
+			Expect(result.Spec.Rules[0].Mutators).To(BeEmpty())
+			Expect(result.Spec.Rules[1].Methods).To(ConsistOf("GET"))
+			Expect(result.Spec.Rules[1].AccessStrategies).To(HaveLen(1))
+			Expect(result.Spec.Rules[1].AccessStrategies[0].Name).To(Equal("noop"))
+			Expect(result.Spec.Rules[1].Mutators).To(HaveLen(1))
+			Expect(result.Spec.Rules[1].Mutators[0].Name).To(Equal("id_token"))
+		})
+	})
+
+	Context("from v1beta2 to v1beta1", func() {
+
+		v1beta2Host := func(h string) *v1beta2.Host {
+			host := v1beta2.Host(h)
+			return &host
+		}
+
+		spokeAPIRule := func(rules ...v1beta2.Rule) *v1beta2.APIRule {
+			var spokeTimeout v1beta2.Timeout = 30
+			return &v1beta2.APIRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+				},
+				Spec: v1beta2.APIRuleSpec{
+					Hosts:   []*v1beta2.Host{v1beta2Host(host)},
+					Gateway: &gateway,
+					Service: &v1beta2.Service{
+						Name:       &serviceName,
+						Namespace:  &serviceNamespace,
+						Port:       &servicePort,
+						IsExternal: &isExternal,
+					},
+					Timeout: &spokeTimeout,
+					Rules:   rules,
+				},
+				Status: v1beta2.APIRuleStatus{
+					ObservedGeneration: 1,
+					APIRuleStatus:      &v1beta2.APIRuleResourceStatus{Code: v1beta2.StatusOK, Description: "ok"},
+				},
+			}
+		}
+
+		noAuth := true
+
+		It("should convert spec and status fields", func() {
+			// given
+			var spokeRuleTimeout v1beta2.Timeout = 60
+			spoke := spokeAPIRule(v1beta2.Rule{
+				Path:     "/.*",
+				PathType: v1beta2.PathTypeRegex,
+				Methods:  []string{"GET"},
+				Service:  &v1beta2.Service{Name: &serviceName, Port: &servicePort},
+				Timeout:  &spokeRuleTimeout,
+				NoAuth:   &noAuth,
+			})
+
+			// when
+			hub := &v1beta1.APIRule{}
+			err := spoke.ConvertTo(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hub.Name).To(Equal("test"))
+			Expect(hub.Annotations).To(BeEmpty())
+			Expect(*hub.Spec.Host).To(Equal(host))
+			Expect(*hub.Spec.Gateway).To(Equal(gateway))
+			Expect(*hub.Spec.Service.Name).To(Equal(serviceName))
+			Expect(*hub.Spec.Service.Namespace).To(Equal(serviceNamespace))
+			Expect(*hub.Spec.Service.Port).To(Equal(servicePort))
+			Expect(*hub.Spec.Service.IsExternal).To(BeTrue())
+			Expect(*hub.Spec.Timeout).To(BeEquivalentTo(30))
+			Expect(hub.Spec.Rules).To(HaveLen(1))
+			Expect(hub.Spec.Rules[0].Path).To(Equal("/.*"))
+			Expect(hub.Spec.Rules[0].Methods).To(ConsistOf("GET"))
+			Expect(*hub.Spec.Rules[0].Service.Name).To(Equal(serviceName))
+			Expect(*hub.Spec.Rules[0].Timeout).To(BeEquivalentTo(60))
+			Expect(hub.Spec.Rules[0].AccessStrategies).To(HaveLen(1))
+			Expect(hub.Spec.Rules[0].AccessStrategies[0].Name).To(Equal("allow"))
+			Expect(hub.Spec.Rules[0].AccessStrategies[0].Config).To(BeNil())
+			Expect(hub.Status.ObservedGeneration).To(BeEquivalentTo(1))
+			Expect(hub.Status.APIRuleStatus).To(Equal(&v1beta1.APIRuleResourceStatus{Code: v1beta1.StatusOK, Description: "ok"}))
+		})
+
+		It("should convert jwt, extAuth and request to access strategies and mutators", func() {
+			// given
+			forwardOriginalToken := false
+			spoke := spokeAPIRule(v1beta2.Rule{
+				Path:    "/.*",
+				Methods: []string{"GET"},
+				Jwt: &v1beta2.JwtConfig{
+					Authentications: []*v1beta2.JwtAuthentication{{
+						Issuer:               "https://example.com/",
+						JwksUri:              "https://example.com/.well-known/jwks.json",
+						OutputClaimToHeaders: []*v1beta2.JwtClaimToHeader{{Header: "x-user", Claim: "sub"}},
+						ForwardOriginalToken: &forwardOriginalToken,
+					}},
+					Authorizations: []*v1beta2.JwtAuthorization{{
+						RequiredScopes: []string{"read"},
+						Claims:         []*v1beta2.JwtClaim{{Key: "realm_access.roles", Values: []string{"admin"}, NotValues: []string{"guest"}}},
+					}},
+				},
+				ExtAuth: &v1beta2.ExtAuth{Provider: "oauth2-proxy"},
+				Request: &v1beta2.Request{
+					Headers: map[string]string{"x-header": "value"},
+					Cookies: map[string]string{"cookie": "value"},
+				},
+			})
+
+			// when
+			hub := &v1beta1.APIRule{}
+			err := spoke.ConvertTo(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			strategies := hub.Spec.Rules[0].AccessStrategies
+			Expect(strategies).To(HaveLen(2))
+			Expect(strategies[0].Name).To(Equal("jwt"))
+			Expect(strategies[0].Config.Raw).To(MatchJSON(`{"authentications":[{"issuer":"https://example.com/","jwksUri":"https://example.com/.well-known/jwks.json","outputClaimToHeaders":[{"header":"x-user","claim":"sub"}],"forwardOriginalToken":false}],"authorizations":[{"requiredScopes":["read"],"claims":[{"key":"realm_access.roles","values":["admin"],"notValues":["guest"]}]}]}`))
+			Expect(strategies[1].Name).To(Equal("extAuth"))
+			Expect(strategies[1].Config.Raw).To(MatchJSON(`{"provider":"oauth2-proxy"}`))
+
+			mutators := hub.Spec.Rules[0].Mutators
+			Expect(mutators).To(HaveLen(2))
+			Expect(mutators[0].Name).To(Equal("header"))
+			Expect(mutators[0].Config.Raw).To(MatchJSON(`{"headers":{"x-header":"value"}}`))
+			Expect(mutators[1].Name).To(Equal("cookie"))
+			Expect(mutators[1].Config.Raw).To(MatchJSON(`{"cookies":{"cookie":"value"}}`))
+		})
+
+		DescribeTable("should convert the path type",
+			func(pathType v1beta2.PathType, path string, expected v1beta1.PathType) {
+				// given
+				spoke := spokeAPIRule(v1beta2.Rule{Path: path, PathType: pathType, Methods: []string{"GET"}, NoAuth: &noAuth})
+
+				// when
+				hub := &v1beta1.APIRule{}
+				err := spoke.ConvertTo(hub)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hub.Spec.Rules[0].Path).To(Equal(path))
+				Expect(hub.Spec.Rules[0].PathType).To(Equal(expected))
+			},
+			Entry("regex", v1beta2.PathTypeRegex, "/anything/.*", v1beta1.PathType("")),
+			Entry("default", v1beta2.PathType(""), "/anything/.*", v1beta1.PathType("")),
+			Entry("exact", v1beta2.PathTypeExact, "/anything/v1.0", v1beta1.PathTypeExact),
+			Entry("prefix", v1beta2.PathTypePrefix, "/anything", v1beta1.PathTypePrefix),
+			Entry("templated", v1beta2.PathTypeExact, "/users/{id}", v1beta1.PathTypeExact),
+		)
+
+		It("should convert the path type from hub", func() {
+			// given
+			hub := hubAPIRule(hubRule("/users/{id}", []*v1beta1.Authenticator{{Handler: handler("allow", "")}}))
+			hub.Spec.Rules[0].PathType = v1beta1.PathTypePrefix
+
+			// when
+			spoke := &v1beta2.APIRule{}
+			err := spoke.ConvertFrom(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spoke.Spec.Rules[0].Path).To(Equal("/users/{id}"))
+			Expect(spoke.Spec.Rules[0].PathType).To(Equal(v1beta2.PathTypePrefix))
+		})
+
+		It("should keep additional hosts and path types when converted back", func() {
+			// given
+			spoke := spokeAPIRule(v1beta2.Rule{Path: "/anything", PathType: v1beta2.PathTypePrefix, Methods: []string{"GET"}, NoAuth: &noAuth})
+			spoke.Spec.Hosts = append(spoke.Spec.Hosts, v1beta2Host("httpbin.example.com"))
+
+			// when
+			hub := &v1beta1.APIRule{}
+			Expect(spoke.ConvertTo(hub)).To(Succeed())
+			result := &v1beta2.APIRule{}
+			err := result.ConvertFrom(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hub.Annotations).NotTo(HaveKey(v1beta2.V1beta2SpecAnnotation))
+			Expect(hub.Spec.Rules[0].PathType).To(Equal(v1beta1.PathTypePrefix))
+			Expect(*hub.Spec.Host).To(Equal(host))
+			Expect(hub.Spec.Hosts).To(HaveLen(1))
+			Expect(string(*hub.Spec.Hosts[0])).To(Equal("httpbin.example.com"))
+			Expect(result.Annotations).To(BeEmpty())
+			Expect(result.Spec).To(Equal(spoke.Spec))
+		})
+
+		It("should ignore stored v1beta2 spec when the v1beta1 spec was changed", func() {
+			// given
+			spoke := spokeAPIRule(v1beta2.Rule{Path: "/anything", PathType: v1beta2.PathTypePrefix, Methods: []string{"GET"}, NoAuth: &noAuth})
+			hub := &v1beta1.APIRule{}
+			Expect(spoke.ConvertTo(hub)).To(Succeed())
+			raw, err := json.Marshal(spoke.Spec)
+			Expect(err).NotTo(HaveOccurred())
+			hub.Annotations = map[string]string{v1beta2.V1beta2SpecAnnotation: string(raw)}
+
+			hub.Spec.Rules[0].Path = "/headers"
+
+			// when
+			result := &v1beta2.APIRule{}
+			err = result.ConvertFrom(hub)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Annotations).To(BeEmpty())
+			Expect(result.Spec.Rules[0].Path).To(Equal("/headers"))
+			Expect(result.Spec.Rules[0].PathType).To(Equal(v1beta2.PathTypePrefix))
+		})
+	})
+})
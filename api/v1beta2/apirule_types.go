@@ -0,0 +1,526 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Status code describing APIRule.
+type StatusCode string
+
+const (
+	//StatusOK .
+	StatusOK StatusCode = "OK"
+	//StatusSkipped .
+	StatusSkipped StatusCode = "SKIPPED"
+	//StatusError .
+	StatusError StatusCode = "ERROR"
+)
+
+// Defines the desired state of ApiRule.
+type APIRuleSpec struct {
+	// Specifies the URLs of the exposed service.
+	// +kubebuilder:validation:MinItems=1
+	Hosts []*Host `json:"hosts"`
+	// Describes the service to expose.
+	// +optional
+	Service *Service `json:"service,omitempty"`
+	// Specifies the services the traffic is split between, for example, for canary releases. Can't be used together with **service**.
+	// +optional
+	Services []*WeightedService `json:"services,omitempty"`
+	// Specifies the Istio Gateway to be used. Use `mesh` to expose the service only to the workloads in the service mesh.
+	// +kubebuilder:validation:Pattern=`^[0-9a-z-_]+(\/[0-9a-z-_]+|(\.[0-9a-z-_]+)*)$`
+	Gateway *string `json:"gateway"`
+	// Specifies internal hosts under which the service is additionally exposed to the workloads in the service mesh. Can't be used together with the `mesh` gateway.
+	// +optional
+	MeshHosts []*Host `json:"meshHosts,omitempty"`
+	// Specifies additional Istio Gateways the service is exposed through. The same rules as for **gateway** apply to every
+	// gateway.
+	// +optional
+	Gateways []string `json:"gateways,omitempty"`
+	// Represents the array of rules to be applied.
+	// +kubebuilder:validation:MinItems=1
+	Rules []Rule `json:"rules"`
+	// +optional
+	Timeout *Timeout `json:"timeout,omitempty"`
+	// Overrides the global CORS policy for all rules.
+	// +optional
+	Cors *CorsPolicy `json:"cors,omitempty"`
+	// Specifies the retry policy for all rules.
+	// +optional
+	Retries *Retries `json:"retries,omitempty"`
+}
+
+// Host is the URL of the exposed service.
+// +kubebuilder:validation:MinLength=3
+// +kubebuilder:validation:MaxLength=256
+// +kubebuilder:validation:Pattern=^([a-zA-Z0-9][a-zA-Z0-9-_]*\.)*[a-zA-Z0-9]*[a-zA-Z0-9-_]*[[a-zA-Z0-9]+$
+type Host string
+
+// Describes the observed state of ApiRule.
+type APIRuleStatus struct {
+	LastProcessedTime    *metav1.Time           `json:"lastProcessedTime,omitempty"`
+	ObservedGeneration   int64                  `json:"observedGeneration,omitempty"`
+	APIRuleStatus        *APIRuleResourceStatus `json:"APIRuleStatus,omitempty"`
+	VirtualServiceStatus *APIRuleResourceStatus `json:"virtualServiceStatus,omitempty"`
+	// +optional
+	AccessRuleStatus *APIRuleResourceStatus `json:"accessRuleStatus,omitempty"`
+	// +optional
+	RequestAuthenticationStatus *APIRuleResourceStatus `json:"requestAuthenticationStatus,omitempty"`
+	// +optional
+	AuthorizationPolicyStatus *APIRuleResourceStatus `json:"authorizationPolicyStatus,omitempty"`
+	// +optional
+	EnvoyFilterStatus *APIRuleResourceStatus `json:"envoyFilterStatus,omitempty"`
+	// Lists the hosts exposed by the APIRule, including the default domain name if applied.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+	// Represents the observations of the APIRule's current state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// APIRule is the Schema for ApiRule APIs.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.APIRuleStatus.code"
+// +kubebuilder:printcolumn:name="Hosts",type="string",JSONPath=".spec.hosts"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+type APIRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIRuleSpec   `json:"spec,omitempty"`
+	Status APIRuleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// APIRuleList contains a list of ApiRule
+type APIRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIRule `json:"items"`
+}
+
+// Service .
+type Service struct {
+	// Specifies the name of the exposed service.
+	Name *string `json:"name"`
+	// Specifies the Namespace of the exposed service. If not defined, it defaults to the APIRule Namespace.
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// Specifies the communication port of the exposed service.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *uint32 `json:"port"`
+	// Specifies if the service is internal (in cluster) or external.
+	// +optional
+	IsExternal *bool `json:"external,omitempty"`
+}
+
+// PathType defines how the path of a rule is matched against the request path.
+// +kubebuilder:validation:Enum=Exact;Prefix;Regex
+type PathType string
+
+const (
+	// PathTypeExact matches the request path exactly.
+	PathTypeExact PathType = "Exact"
+	// PathTypePrefix matches every request path starting with the given path.
+	PathTypePrefix PathType = "Prefix"
+	// PathTypeRegex matches the request path against the given regular expression.
+	PathTypeRegex PathType = "Regex"
+)
+
+// RuleSource specifies the identities of the mesh workloads allowed to access a rule. A request must match one of the
+// principals, if defined, and one of the namespaces, if defined.
+type RuleSource struct {
+	// Specifies the principals of the workloads in the format <trust domain>/ns/<namespace>/sa/<service account>, for
+	// example cluster.local/ns/orders/sa/checkout. The trust domain or the service account can be *.
+	// +optional
+	Principals []string `json:"principals,omitempty"`
+	// Specifies the namespaces of the workloads.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// WeightedService describes a service that receives a share of the traffic.
+type WeightedService struct {
+	Service `json:",inline"`
+	// Specifies the percentage of the traffic routed to the service. The weights of all services must sum up to 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight uint32 `json:"weight"`
+}
+
+// Rule .
+type Rule struct {
+	// Specifies the path of the exposed service.
+	// +kubebuilder:validation:Pattern=^([0-9a-zA-Z./*()?!\\_-]+)
+	Path string `json:"path"`
+	// Specifies how the path is matched. Exact and Prefix paths can contain templated segments, for example /users/{id},
+	// which match a single path segment. Defaults to Regex.
+	// +kubebuilder:default=Regex
+	// +optional
+	PathType PathType `json:"pathType,omitempty"`
+	// Describes the service to expose. Overwrites the **spec** level service if defined.
+	// +optional
+	Service *Service `json:"service,omitempty"`
+	// Specifies the services the traffic is split between. Overwrites the **spec** level services if defined. Can't be used together with **service**.
+	// +optional
+	Services []*WeightedService `json:"services,omitempty"`
+	// Represents the list of allowed HTTP request methods available for the **spec.rules.path**.
+	// +kubebuilder:validation:MinItems=1
+	Methods []string `json:"methods"`
+	// Specifies the headers the requests must match to be handled by the rule. The key is the name of the header.
+	// +optional
+	Headers map[string]*StringMatch `json:"headers,omitempty"`
+	// Specifies the query parameters the requests must match to be handled by the rule. The key is the name of the query parameter.
+	// +optional
+	QueryParams map[string]*StringMatch `json:"queryParams,omitempty"`
+	// Disables authentication and authorization for the rule.
+	// +optional
+	NoAuth *bool `json:"noAuth,omitempty"`
+	// Specifies the Istio JWT access strategy.
+	// +optional
+	Jwt *JwtConfig `json:"jwt,omitempty"`
+	// Specifies the external authorizer that authorizes requests to the rule.
+	// +optional
+	ExtAuth *ExtAuth `json:"extAuth,omitempty"`
+	// Specifies modifications applied to the request before it is forwarded to the service.
+	// +optional
+	Request *Request `json:"request,omitempty"`
+	// Specifies the CIDR ranges or IP addresses of the clients allowed to access the rule's path. Requests from other clients are rejected.
+	// +optional
+	IPAllowList []string `json:"ipAllowList,omitempty"`
+	// Specifies the CIDR ranges or IP addresses of the clients that are denied access to the rule's path.
+	// +optional
+	IPDenyList []string `json:"ipDenyList,omitempty"`
+	// Specifies the mesh workloads allowed to access the rule's path. Requests from other workloads, including the ingress
+	// gateway, are rejected.
+	// +optional
+	From *RuleSource `json:"from,omitempty"`
+	// Restricts the rule to the requests received through the given gateways of the APIRule. By default, the rule applies
+	// to all gateways.
+	// +optional
+	Gateways []string `json:"gateways,omitempty"`
+	// +optional
+	Timeout *Timeout `json:"timeout,omitempty"`
+	// Overrides the **spec** level and global CORS policy for the rule.
+	// +optional
+	Cors *CorsPolicy `json:"cors,omitempty"`
+	// Overrides the **spec** level retry policy for the rule.
+	// +optional
+	Retries *Retries `json:"retries,omitempty"`
+	// Specifies the local rate limit of the requests to the rule's path. The limit is enforced by the sidecar of every workload the rule routes to.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// Specifies the rewrite of the URI and the authority of the requests forwarded to the exposed service.
+	// +optional
+	Rewrite *Rewrite `json:"rewrite,omitempty"`
+	// Specifies the redirect returned for the requests to the rule's path instead of forwarding them to a service.
+	// +optional
+	Redirect *Redirect `json:"redirect,omitempty"`
+	// Specifies the fixed response returned for the requests to the rule's path instead of forwarding them to a service.
+	// +optional
+	DirectResponse *DirectResponse `json:"directResponse,omitempty"`
+}
+
+// Describes the status of APIRule.
+type APIRuleResourceStatus struct {
+	Code        StatusCode `json:"code,omitempty"`
+	Description string     `json:"desc,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&APIRule{}, &APIRuleList{})
+}
+
+// JwtConfig configures the Istio JWT access strategy.
+type JwtConfig struct {
+	// +optional
+	Authentications []*JwtAuthentication `json:"authentications,omitempty"`
+	// +optional
+	Authorizations []*JwtAuthorization `json:"authorizations,omitempty"`
+	// Specifies that requests without a JWT are allowed. A JWT in the request must still be valid.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// JwtAuthorization contains an array of required scopes
+type JwtAuthorization struct {
+	// +optional
+	RequiredScopes []string `json:"requiredScopes,omitempty"`
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+	// +optional
+	Claims []*JwtClaim `json:"claims,omitempty"`
+	// Specifies the names of the claims containing the required scopes. Nested claims are separated by dots. Defaults to
+	// the scope claim keys of the api-gateway ConfigMap.
+	// +optional
+	ScopeClaimKeys []string `json:"scopeClaimKeys,omitempty"`
+	// Specifies whether the JWT must have all or any of the required scopes. Defaults to all.
+	// +optional
+	RequiredScopesMatch MatchMode `json:"requiredScopesMatch,omitempty"`
+	// Specifies whether the JWT must have all or any of the audiences. Defaults to all.
+	// +optional
+	AudiencesMatch MatchMode `json:"audiencesMatch,omitempty"`
+}
+
+// MatchMode defines whether all or any of the values must match
+// +kubebuilder:validation:Enum=all;any
+type MatchMode string
+
+const (
+	MatchModeAll MatchMode = "all"
+	MatchModeAny MatchMode = "any"
+)
+
+// JwtClaim is a condition on a claim of the JWT
+type JwtClaim struct {
+	// Specifies the name of the claim. Nested claims are separated by dots, for example realm_access.roles.
+	Key string `json:"key"`
+	// Specifies the values of which the claim must contain at least one.
+	// +optional
+	Values []string `json:"values,omitempty"`
+	// Specifies the values that the claim must not contain.
+	// +optional
+	NotValues []string `json:"notValues,omitempty"`
+}
+
+// JwtAuthentication Config for Jwt Istio authentication
+type JwtAuthentication struct {
+	Issuer string `json:"issuer"`
+	// Specifies the URL of the JWKS. Exactly one of jwksUri, jwks or jwksFrom must be defined.
+	// +optional
+	JwksUri string `json:"jwksUri,omitempty"`
+	// Specifies the JWKS inline.
+	// +optional
+	Jwks string `json:"jwks,omitempty"`
+	// Specifies the key of a Secret or ConfigMap in the namespace of the APIRule holding the JWKS.
+	// +optional
+	JwksFrom *JwksSource `json:"jwksFrom,omitempty"`
+	// +optional
+	FromHeaders []*JwtHeader `json:"fromHeaders,omitempty"`
+	// +optional
+	FromParams []string `json:"fromParams,omitempty"`
+	// Specifies the claims copied to headers of the request forwarded to the upstream.
+	// +optional
+	OutputClaimToHeaders []*JwtClaimToHeader `json:"outputClaimToHeaders,omitempty"`
+	// Specifies whether the original token is forwarded to the upstream. Defaults to true.
+	// +optional
+	ForwardOriginalToken *bool `json:"forwardOriginalToken,omitempty"`
+	// Specifies the audiences of which the token must have at least one. If empty, tokens for any audience are accepted.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// JwtHeader for specifying from header for the Jwt token
+type JwtHeader struct {
+	Name string `json:"name"`
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// JwksSource references the key of a Secret or ConfigMap holding the JWKS. Exactly one of the references must be defined.
+type JwksSource struct {
+	// +optional
+	SecretKeyRef *JwksKeySelector `json:"secretKeyRef,omitempty"`
+	// +optional
+	ConfigMapKeyRef *JwksKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// JwksKeySelector selects a key of a Secret or ConfigMap
+type JwksKeySelector struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// JwtClaimToHeader for specifying a claim of the Jwt token copied to a request header
+type JwtClaimToHeader struct {
+	// Specifies the name of the header.
+	Header string `json:"header"`
+	// Specifies the name of the claim. Only claims of type string, int or bool are supported.
+	Claim string `json:"claim"`
+}
+
+// ExtAuth references an external authorizer configured as an extension provider in the Istio mesh config.
+type ExtAuth struct {
+	// Specifies the name of the extension provider.
+	Provider string `json:"provider"`
+}
+
+// Request describes modifications applied to the request before it is forwarded to the service.
+type Request struct {
+	// Specifies the cookies set on the request.
+	// +optional
+	Cookies map[string]string `json:"cookies,omitempty"`
+	// Specifies the headers set on the request.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// CorsPolicy configures Cross-Origin Resource Sharing for the exposed service.
+// Fields that are not set fall back to the less specific configuration, that is, **spec.rules.cors**, then **spec.cors**, then the global defaults.
+type CorsPolicy struct {
+	// Specifies the origins allowed to make cross-origin requests.
+	// +optional
+	AllowOrigins []*StringMatch `json:"allowOrigins,omitempty"`
+	// Specifies the HTTP methods allowed in cross-origin requests.
+	// +optional
+	AllowMethods []string `json:"allowMethods,omitempty"`
+	// Specifies the HTTP headers allowed in cross-origin requests.
+	// +optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+	// Specifies the HTTP headers that browsers are allowed to access.
+	// +optional
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+	// Specifies whether the caller is allowed to send credentials with cross-origin requests.
+	// +optional
+	AllowCredentials *bool `json:"allowCredentials,omitempty"`
+	// Specifies how long, in seconds, the results of a preflight request can be cached.
+	// +optional
+	MaxAge *uint64 `json:"maxAge,omitempty"`
+}
+
+// StringMatch describes how to match a string. Exactly one of the fields must be set.
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
+type StringMatch struct {
+	// Matches the exact string.
+	// +optional
+	Exact string `json:"exact,omitempty"`
+	// Matches the string prefix.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Matches the string against an RE2 style regular expression.
+	// +optional
+	Regex string `json:"regex,omitempty"`
+}
+
+// Retries describes the retry policy of HTTP requests forwarded to the exposed service.
+type Retries struct {
+	// Specifies the number of retries for a request, not counting the initial call. Set to 0 to disable retries.
+	// +kubebuilder:validation:Minimum=0
+	Attempts int32 `json:"attempts"`
+	// Specifies the timeout per attempt in seconds. The initial call and all retries, which is attempts + 1 calls, multiplied by the timeout per attempt can't exceed the timeout of the rule.
+	// +optional
+	PerTryTimeout *Timeout `json:"perTryTimeout,omitempty"`
+	// Specifies the conditions under which a retry takes place, as a comma-separated list, for example, `5xx,connect-failure`. See the [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/router_filter#x-envoy-retry-on) for the supported values.
+	// +optional
+	RetryOn string `json:"retryOn,omitempty"`
+}
+
+// RateLimit describes the local rate limit of HTTP requests. Every replica of the exposed workload counts the requests separately.
+type RateLimit struct {
+	// Specifies the number of requests allowed per unit.
+	// +kubebuilder:validation:Minimum=1
+	Requests uint32 `json:"requests"`
+	// Specifies the unit of time in which the requests are counted.
+	Unit RateLimitUnit `json:"unit"`
+	// Specifies the buckets with a separate limit for the requests with a given header value or from a given client IP.
+	// +optional
+	Buckets []*RateLimitBucket `json:"buckets,omitempty"`
+}
+
+// RateLimitUnit is the unit of time in which the requests are counted.
+// +kubebuilder:validation:Enum=second;minute;hour
+type RateLimitUnit string
+
+const (
+	RateLimitUnitSecond RateLimitUnit = "second"
+	RateLimitUnitMinute RateLimitUnit = "minute"
+	RateLimitUnitHour   RateLimitUnit = "hour"
+)
+
+// RateLimitBucket describes a separate limit for a subset of the requests. Exactly one of **header** and **staticClientIP** must be defined.
+type RateLimitBucket struct {
+	// Specifies the header the requests counted in the bucket must have.
+	// +optional
+	Header *HeaderMatch `json:"header,omitempty"`
+	// Specifies a single client IP whose requests are counted in the bucket instead of the limit of the rule. The IP is set
+	// by the ingress gateway, so it can't be used if the service is exposed to the mesh.
+	// +optional
+	StaticClientIP string `json:"staticClientIP,omitempty"`
+	// Specifies the number of requests allowed per unit in the bucket.
+	// +kubebuilder:validation:Minimum=1
+	Requests uint32 `json:"requests"`
+}
+
+// HeaderMatch describes an HTTP header with the given value.
+type HeaderMatch struct {
+	// Specifies the name of the header.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Specifies the exact value of the header.
+	Value string `json:"value"`
+}
+
+// Rewrite describes how the URI and the authority of a request are rewritten before the request is forwarded to the exposed service.
+// Only one of **stripPrefix** and **uri** can be defined.
+type Rewrite struct {
+	// Specifies the path prefix removed from the URI, for example, `/orders/v1`. A request to `/orders/v1/items` is forwarded as `/items`.
+	// +kubebuilder:validation:Pattern=^/.*$
+	// +optional
+	StripPrefix string `json:"stripPrefix,omitempty"`
+	// Specifies the URI that replaces the whole path of the request.
+	// +kubebuilder:validation:Pattern=^/.*$
+	// +optional
+	URI string `json:"uri,omitempty"`
+	// Specifies the value that replaces the Host/Authority header of the request.
+	// +optional
+	Authority string `json:"authority,omitempty"`
+}
+
+// Redirect describes the HTTP redirect returned for a request. The parts of the redirect location that aren't defined are
+// taken from the request.
+type Redirect struct {
+	// Specifies the path that replaces the path of the request in the redirect location.
+	// +kubebuilder:validation:Pattern=^/.*$
+	// +optional
+	URI string `json:"uri,omitempty"`
+	// Specifies the host that replaces the host of the request in the redirect location.
+	// +optional
+	Authority string `json:"authority,omitempty"`
+	// Specifies the scheme of the redirect location.
+	// +kubebuilder:validation:Enum=http;https
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+	// Specifies the HTTP status code of the redirect. The default is 301.
+	// +kubebuilder:validation:Enum=301;302;303;307;308
+	// +optional
+	Code uint32 `json:"code,omitempty"`
+}
+
+// DirectResponse describes the fixed HTTP response returned for a request.
+type DirectResponse struct {
+	// Specifies the HTTP status code of the response.
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=599
+	Status uint32 `json:"status"`
+	// Specifies the body of the response.
+	// +optional
+	Body string `json:"body,omitempty"`
+}
+
+// Timeout for HTTP requests in seconds. The timeout can be configured up to 3900 seconds (65 minutes).
+// +kubebuilder:validation:Minimum=1
+// +kubebuilder:validation:Maximum=3900
+type Timeout uint16 // We use unit16 instead of a time.Duration because there is a bug with duration that requires additional validation of the format. Issue: checking https://github.com/kubernetes/apiextensions-apiserver/issues/56
@@ -37,6 +37,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -86,6 +87,7 @@ func (p isApiGatewayConfigMapPredicate) Generic(e event.GenericEvent) bool {
 //+kubebuilder:rbac:groups=gateway.kyma-project.io,resources=apirules/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=gateway.kyma-project.io,resources=apirules/finalizers,verbs=update
 //+kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.istio.io,resources=envoyfilters,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=oathkeeper.ory.sh,resources=rules,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=security.istio.io,resources=authorizationpolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=security.istio.io,resources=requestauthentications,verbs=get;list;watch;create;update;patch;delete
@@ -93,6 +95,7 @@ func (p isApiGatewayConfigMapPredicate) Generic(e event.GenericEvent) bool {
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.Log.Info("Starting reconciliation", "namespacedName", req.NamespacedName.String())
@@ -194,7 +197,10 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 func (r *APIRuleReconciler) getReconciliation() processing.ReconciliationCommand {
 	if r.Config.JWTHandler == helpers.JWT_HANDLER_ISTIO {
-		return istio.NewIstioReconciliation(r.ReconciliationConfig, &r.Log)
+		config := r.ReconciliationConfig
+		config.ScopeClaimKeys = r.Config.ScopeClaimKeys
+		config.GatewayPrincipals = r.Config.GatewayPrincipals
+		return istio.NewIstioReconciliation(config, &r.Log)
 	}
 	return ory.NewOryReconciliation(r.ReconciliationConfig, &r.Log)
 
@@ -206,9 +212,50 @@ func (r *APIRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// We need to filter for generation changes, because we had an issue that on Azure clusters the APIRules were constantly reconciled.
 		For(&gatewayv1beta1.APIRule{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(&corev1.ConfigMap{}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(&isApiGatewayConfigMapPredicate{Log: r.Log})).
+		Watches(&corev1.ConfigMap{}, r.enqueueApiRulesReferencingJwks(func(source *gatewayv1beta1.JwksSource) *gatewayv1beta1.JwksKeySelector {
+			return source.ConfigMapKeyRef
+		})).
+		// Only the metadata of the Secrets is watched, so that the content of all Secrets in the cluster is not cached
+		WatchesMetadata(&corev1.Secret{}, r.enqueueApiRulesReferencingJwks(func(source *gatewayv1beta1.JwksSource) *gatewayv1beta1.JwksKeySelector {
+			return source.SecretKeyRef
+		})).
 		Complete(r)
 }
 
+// enqueueApiRulesReferencingJwks returns a handler enqueueing the APIRules in the namespace of the object that reference it
+// as the source of a JWKS, so that the RequestAuthentications are updated when the JWKS changes
+func (r *APIRuleReconciler) enqueueApiRulesReferencingJwks(getKeySelector func(*gatewayv1beta1.JwksSource) *gatewayv1beta1.JwksKeySelector) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var apiRules gatewayv1beta1.APIRuleList
+		if err := r.Client.List(ctx, &apiRules, client.InNamespace(obj.GetNamespace())); err != nil {
+			r.Log.Error(err, "Could not list APIRules referencing the JWKS", "namespace", obj.GetNamespace(), "name", obj.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, apiRule := range apiRules.Items {
+			if referencesJwks(apiRule, getKeySelector, obj.GetName()) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: apiRule.Namespace, Name: apiRule.Name}})
+			}
+		}
+		return requests
+	})
+}
+
+func referencesJwks(apiRule gatewayv1beta1.APIRule, getKeySelector func(*gatewayv1beta1.JwksSource) *gatewayv1beta1.JwksKeySelector, name string) bool {
+	for _, rule := range apiRule.Spec.Rules {
+		for _, authentication := range rule.GetJwtIstioAuthentications() {
+			if authentication.JwksFrom == nil {
+				continue
+			}
+			if keySelector := getKeySelector(authentication.JwksFrom); keySelector != nil && keySelector.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Updates api status. If there was an error during update, returns the error so that entire reconcile loop is retried. If there is no error, returns a "reconcile success" value.
 func (r *APIRuleReconciler) updateStatusOrRetry(ctx context.Context, api *gatewayv1beta1.APIRule, status processing.ReconciliationStatus) (ctrl.Result, error) {
 	_, updateStatusErr := r.updateStatus(ctx, api, status)
@@ -260,6 +307,9 @@ func (r *APIRuleReconciler) updateStatus(ctx context.Context, api *gatewayv1beta
 	api.Status.AccessRuleStatus = status.AccessRuleStatus
 	api.Status.RequestAuthenticationStatus = status.RequestAuthenticationStatus
 	api.Status.AuthorizationPolicyStatus = status.AuthorizationPolicyStatus
+	api.Status.EnvoyFilterStatus = status.EnvoyFilterStatus
+	api.Status.Hosts = helpers.GetAPIRuleHosts(api.Spec, r.DefaultDomainName)
+	status.SetConditions(&api.Status.Conditions, api.Generation)
 
 	r.Log.Info("Updating ApiRule status", "status", api.Status)
 	err := r.Client.Status().Update(ctx, api)
@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Tests needs to be executed serially because of the shared state of the JWT Handler in the API Controller.
@@ -347,5 +348,105 @@ var _ = Describe("APIRule timeout", Serial, func() {
 				})
 			})
 		})
+
+		Context("with retries", func() {
+
+			var timeout gatewayv1beta1.Timeout = 40
+			var perTryTimeout gatewayv1beta1.Timeout = 10
+
+			testRetriesOnRuleLevel := func(jwtHandler *gatewayv1beta1.Handler) {
+				rule := testRule("/img", []string{"GET"}, nil, jwtHandler)
+				rule.Retries = &gatewayv1beta1.Retries{Attempts: 3, PerTryTimeout: &perTryTimeout, RetryOn: "5xx"}
+				apiRuleName := generateTestName(testNameBase, testIDLength)
+				serviceName := testServiceNameBase
+				serviceHost := fmt.Sprintf("httpbin-%s.kyma.local", apiRuleName)
+
+				apiRule := testApiRule(apiRuleName, testNamespace, serviceName, testNamespace, serviceHost, testServicePort, []gatewayv1beta1.Rule{rule})
+				apiRule.Spec.Timeout = &timeout
+				apiRule.Spec.Retries = &gatewayv1beta1.Retries{Attempts: 1}
+
+				svc := testService(serviceName, testNamespace, testServicePort)
+
+				// when
+				Expect(c.Create(context.TODO(), svc)).Should(Succeed())
+				Expect(c.Create(context.TODO(), apiRule)).Should(Succeed())
+				defer func() {
+					apiRuleTeardown(apiRule)
+					serviceTeardown(svc)
+				}()
+
+				expectApiRuleStatus(apiRuleName, gatewayv1beta1.StatusOK)
+
+				matchingLabels := matchingLabelsFunc(apiRuleName, testNamespace)
+
+				By("Verifying created virtual service")
+				vsList := networkingv1beta1.VirtualServiceList{}
+				Eventually(func(g Gomega) {
+					g.Expect(c.List(context.TODO(), &vsList, matchingLabels)).Should(Succeed())
+					g.Expect(vsList.Items).To(HaveLen(1))
+
+					vs := vsList.Items[0]
+					g.Expect(vs.Spec.Http[0].Timeout.AsDuration()).To(Equal(40 * time.Second))
+					g.Expect(vs.Spec.Http[0].Retries.Attempts).To(BeEquivalentTo(3))
+					g.Expect(vs.Spec.Http[0].Retries.PerTryTimeout.AsDuration()).To(Equal(10 * time.Second))
+					g.Expect(vs.Spec.Http[0].Retries.RetryOn).To(Equal("5xx"))
+				}, eventuallyTimeout).Should(Succeed())
+			}
+			testRetriesExceedingTimeout := func(jwtHandler *gatewayv1beta1.Handler) {
+				rule := testRule("/img", []string{"GET"}, nil, jwtHandler)
+				rule.Timeout = &timeout
+				rule.Retries = &gatewayv1beta1.Retries{Attempts: 5, PerTryTimeout: &perTryTimeout}
+				apiRuleName := generateTestName(testNameBase, testIDLength)
+				serviceName := testServiceNameBase
+				serviceHost := fmt.Sprintf("httpbin-%s.kyma.local", apiRuleName)
+
+				apiRule := testApiRule(apiRuleName, testNamespace, serviceName, testNamespace, serviceHost, testServicePort, []gatewayv1beta1.Rule{rule})
+
+				svc := testService(serviceName, testNamespace, testServicePort)
+
+				// when
+				Expect(c.Create(context.TODO(), svc)).Should(Succeed())
+				Expect(c.Create(context.TODO(), apiRule)).Should(Succeed())
+				defer func() {
+					apiRuleTeardown(apiRule)
+					serviceTeardown(svc)
+				}()
+
+				// then
+				expectApiRuleStatus(apiRuleName, gatewayv1beta1.StatusError)
+
+				Eventually(func(g Gomega) {
+					created := gatewayv1beta1.APIRule{}
+					g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(apiRule), &created)).Should(Succeed())
+					g.Expect(created.Status.APIRuleStatus.Description).To(ContainSubstring("The retry attempts take up to 50s, which exceeds the timeout of 40s"))
+				}, eventuallyTimeout).Should(Succeed())
+			}
+
+			Context("with Ory JWT handler", func() {
+				It("should create a virtual service with retries of the rule", func() {
+					updateJwtHandlerTo(helpers.JWT_HANDLER_ORY)
+					jwtHandler := testOryJWTHandler(testIssuer, defaultScopes)
+					testRetriesOnRuleLevel(jwtHandler)
+				})
+				It("should set Status to Error when retries exceed the timeout", func() {
+					updateJwtHandlerTo(helpers.JWT_HANDLER_ORY)
+					jwtHandler := testOryJWTHandler(testIssuer, defaultScopes)
+					testRetriesExceedingTimeout(jwtHandler)
+				})
+			})
+
+			Context("with Istio JWT handler", func() {
+				It("should create a virtual service with retries of the rule", func() {
+					updateJwtHandlerTo(helpers.JWT_HANDLER_ISTIO)
+					jwtHandler := testIstioJWTHandler(testIssuer, testJwksUri)
+					testRetriesOnRuleLevel(jwtHandler)
+				})
+				It("should set Status to Error when retries exceed the timeout", func() {
+					updateJwtHandlerTo(helpers.JWT_HANDLER_ISTIO)
+					jwtHandler := testIstioJWTHandler(testIssuer, testJwksUri)
+					testRetriesExceedingTimeout(jwtHandler)
+				})
+			})
+		})
 	})
 })
@@ -36,6 +36,7 @@ type ApiRuleReconcilerConfiguration struct {
 	AdditionalLabels                                     map[string]string
 	ReconciliationPeriod                                 uint
 	ErrorReconciliationPeriod                            uint
+	IntrospectionProvider                                string
 }
 
 func NewApiRuleReconciler(mgr manager.Manager, config ApiRuleReconcilerConfiguration) (*APIRuleReconciler, error) {
@@ -63,11 +64,12 @@ func NewApiRuleReconciler(mgr manager.Manager, config ApiRuleReconcilerConfigura
 				AllowMethods: getList(config.CorsAllowMethods),
 				AllowOrigins: getStringMatch(config.CorsAllowOrigins),
 			},
-			AdditionalLabels:  config.AdditionalLabels,
-			DefaultDomainName: config.DomainName,
-			ServiceBlockList:  serviceBlockList,
-			DomainAllowList:   getList(config.AllowListedDomains),
-			HostBlockList:     hostBlockList,
+			AdditionalLabels:      config.AdditionalLabels,
+			DefaultDomainName:     config.DomainName,
+			ServiceBlockList:      serviceBlockList,
+			DomainAllowList:       getList(config.AllowListedDomains),
+			HostBlockList:         hostBlockList,
+			IntrospectionProvider: config.IntrospectionProvider,
 		},
 		Scheme:                 mgr.GetScheme(),
 		Config:                 &helpers.Config{},
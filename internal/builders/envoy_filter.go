@@ -0,0 +1,248 @@
+package builders
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"istio.io/api/networking/v1alpha3"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+)
+
+const (
+	localRateLimitFilterName = "envoy.filters.http.local_ratelimit"
+	localRateLimitTypeUrl    = "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit"
+	localRateLimitStatPrefix = "http_local_rate_limiter"
+	typedStructTypeUrl       = "type.googleapis.com/udpa.type.v1.TypedStruct"
+	httpConnectionManager    = "envoy.filters.network.http_connection_manager"
+)
+
+// NewEnvoyFilterBuilder returns a builder for istio.io/client-go/pkg/apis/networking/v1alpha3/EnvoyFilter type
+func NewEnvoyFilterBuilder() *EnvoyFilterBuilder {
+	return &EnvoyFilterBuilder{
+		value: &networkingv1alpha3.EnvoyFilter{},
+	}
+}
+
+type EnvoyFilterBuilder struct {
+	value *networkingv1alpha3.EnvoyFilter
+}
+
+func (ef *EnvoyFilterBuilder) Get() *networkingv1alpha3.EnvoyFilter {
+	return ef.value
+}
+
+func (ef *EnvoyFilterBuilder) WithName(val string) *EnvoyFilterBuilder {
+	ef.value.Name = val
+	return ef
+}
+
+func (ef *EnvoyFilterBuilder) WithGenerateName(val string) *EnvoyFilterBuilder {
+	ef.value.Name = ""
+	ef.value.GenerateName = val
+	return ef
+}
+
+func (ef *EnvoyFilterBuilder) WithNamespace(val string) *EnvoyFilterBuilder {
+	ef.value.Namespace = val
+	return ef
+}
+
+func (ef *EnvoyFilterBuilder) WithLabel(key, val string) *EnvoyFilterBuilder {
+	if ef.value.Labels == nil {
+		ef.value.Labels = make(map[string]string)
+	}
+	ef.value.Labels[key] = val
+	return ef
+}
+
+func (ef *EnvoyFilterBuilder) WithSpec(val *v1alpha3.EnvoyFilter) *EnvoyFilterBuilder {
+	ef.value.Spec = *val.DeepCopy()
+	return ef
+}
+
+// NewEnvoyFilterSpecBuilder returns a builder for istio.io/api/networking/v1alpha3/EnvoyFilter type
+func NewEnvoyFilterSpecBuilder() *EnvoyFilterSpecBuilder {
+	return &EnvoyFilterSpecBuilder{
+		value: &v1alpha3.EnvoyFilter{},
+	}
+}
+
+type EnvoyFilterSpecBuilder struct {
+	value *v1alpha3.EnvoyFilter
+}
+
+func (efs *EnvoyFilterSpecBuilder) Get() *v1alpha3.EnvoyFilter {
+	return efs.value
+}
+
+func (efs *EnvoyFilterSpecBuilder) WithWorkloadSelector(labels map[string]string) *EnvoyFilterSpecBuilder {
+	efs.value.WorkloadSelector = &v1alpha3.WorkloadSelector{Labels: make(map[string]string)}
+	for k, v := range labels {
+		efs.value.WorkloadSelector.Labels[k] = v
+	}
+	return efs
+}
+
+func (efs *EnvoyFilterSpecBuilder) WithConfigPatch(val *v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch) *EnvoyFilterSpecBuilder {
+	efs.value.ConfigPatches = append(efs.value.ConfigPatches, val)
+	return efs
+}
+
+// NewLocalRateLimitFilterPatch returns the patch that adds the Envoy local rate limit filter to the inbound HTTP filter chain of the sidecar.
+// The filter doesn't limit requests until a route defines its local rate limit configuration.
+func NewLocalRateLimitFilterPatch() (*v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch, error) {
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"name": localRateLimitFilterName,
+		"typed_config": map[string]interface{}{
+			"@type":    typedStructTypeUrl,
+			"type_url": localRateLimitTypeUrl,
+			"value": map[string]interface{}{
+				"stat_prefix": localRateLimitStatPrefix,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch{
+		ApplyTo: v1alpha3.EnvoyFilter_HTTP_FILTER,
+		Match: &v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch{
+			Context: v1alpha3.EnvoyFilter_SIDECAR_INBOUND,
+			ObjectTypes: &v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch_Listener{
+				Listener: &v1alpha3.EnvoyFilter_ListenerMatch{
+					FilterChain: &v1alpha3.EnvoyFilter_ListenerMatch_FilterChainMatch{
+						Filter: &v1alpha3.EnvoyFilter_ListenerMatch_FilterMatch{
+							Name: httpConnectionManager,
+						},
+					},
+				},
+			},
+		},
+		Patch: &v1alpha3.EnvoyFilter_Patch{
+			Operation: v1alpha3.EnvoyFilter_Patch_INSERT_BEFORE,
+			Value:     value,
+		},
+	}, nil
+}
+
+// HeaderMatcher is the Envoy configuration matching a request header
+type HeaderMatcher map[string]interface{}
+
+// ExactHeaderMatcher returns a HeaderMatcher matching the header with exactly the given value
+func ExactHeaderMatcher(name, value string) HeaderMatcher {
+	return HeaderMatcher{"name": name, "string_match": map[string]interface{}{"exact": value}}
+}
+
+// PrefixHeaderMatcher returns a HeaderMatcher matching the header with a value starting with the given prefix
+func PrefixHeaderMatcher(name, prefix string) HeaderMatcher {
+	return HeaderMatcher{"name": name, "string_match": map[string]interface{}{"prefix": prefix}}
+}
+
+// RegexHeaderMatcher returns a HeaderMatcher matching the header with a value matching the given regular expression
+func RegexHeaderMatcher(name, regex string) HeaderMatcher {
+	return HeaderMatcher{"name": name, "string_match": map[string]interface{}{"safe_regex": map[string]interface{}{"regex": regex}}}
+}
+
+// NewLocalRateLimitRoutePatchBuilder returns a builder for the patch that configures the local rate limits of the inbound routes of the sidecar
+func NewLocalRateLimitRoutePatchBuilder() *LocalRateLimitRoutePatchBuilder {
+	return &LocalRateLimitRoutePatchBuilder{}
+}
+
+type LocalRateLimitRoutePatchBuilder struct {
+	rateLimits  []interface{}
+	descriptors []interface{}
+}
+
+// WithDescriptor limits the requests matching all given headers to the given number of requests per fill interval.
+// The name must be unique in the patch.
+func (b *LocalRateLimitRoutePatchBuilder) WithDescriptor(name string, headers []HeaderMatcher, requests uint32, fillInterval time.Duration) *LocalRateLimitRoutePatchBuilder {
+	var matchers []interface{}
+	for _, h := range headers {
+		matchers = append(matchers, map[string]interface{}(h))
+	}
+
+	b.rateLimits = append(b.rateLimits, map[string]interface{}{
+		"actions": []interface{}{
+			map[string]interface{}{
+				"header_value_match": map[string]interface{}{
+					"descriptor_value": name,
+					"headers":          matchers,
+				},
+			},
+		},
+	})
+	b.descriptors = append(b.descriptors, map[string]interface{}{
+		"entries": []interface{}{
+			map[string]interface{}{"key": "header_match", "value": name},
+		},
+		"token_bucket": tokenBucket(requests, fillInterval),
+	})
+	return b
+}
+
+func (b *LocalRateLimitRoutePatchBuilder) Get() (*v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch, error) {
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"route": map[string]interface{}{
+			"rate_limits": b.rateLimits,
+		},
+		"typed_per_filter_config": map[string]interface{}{
+			localRateLimitFilterName: map[string]interface{}{
+				"@type":    typedStructTypeUrl,
+				"type_url": localRateLimitTypeUrl,
+				"value": map[string]interface{}{
+					"stat_prefix": localRateLimitStatPrefix,
+					// Requests that don't match any descriptor are not limited. The fill interval of every descriptor must be
+					// a multiple of the fill interval of this token bucket.
+					"token_bucket":    tokenBucket(math.MaxUint32, time.Second),
+					"filter_enabled":  runtimeFractionalPercent("local_rate_limit_enabled"),
+					"filter_enforced": runtimeFractionalPercent("local_rate_limit_enforced"),
+					"descriptors":     b.descriptors,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch{
+		ApplyTo: v1alpha3.EnvoyFilter_HTTP_ROUTE,
+		Match: &v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch{
+			Context: v1alpha3.EnvoyFilter_SIDECAR_INBOUND,
+			ObjectTypes: &v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch_RouteConfiguration{
+				RouteConfiguration: &v1alpha3.EnvoyFilter_RouteConfigurationMatch{
+					Vhost: &v1alpha3.EnvoyFilter_RouteConfigurationMatch_VirtualHostMatch{
+						Route: &v1alpha3.EnvoyFilter_RouteConfigurationMatch_RouteMatch{
+							Action: v1alpha3.EnvoyFilter_RouteConfigurationMatch_RouteMatch_ANY,
+						},
+					},
+				},
+			},
+		},
+		Patch: &v1alpha3.EnvoyFilter_Patch{
+			Operation: v1alpha3.EnvoyFilter_Patch_MERGE,
+			Value:     value,
+		},
+	}, nil
+}
+
+func tokenBucket(tokens uint32, fillInterval time.Duration) map[string]interface{} {
+	return map[string]interface{}{
+		"max_tokens":      tokens,
+		"tokens_per_fill": tokens,
+		"fill_interval":   fmt.Sprintf("%ds", int64(fillInterval.Seconds())),
+	}
+}
+
+func runtimeFractionalPercent(runtimeKey string) map[string]interface{} {
+	return map[string]interface{}{
+		"runtime_key": runtimeKey,
+		"default_value": map[string]interface{}{
+			"numerator":   100,
+			"denominator": "HUNDRED",
+		},
+	}
+}
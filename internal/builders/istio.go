@@ -11,7 +11,8 @@ import (
 )
 
 const (
-	istioIngressGatewayPrincipal      string = "cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"
+	// IstioIngressGatewayPrincipal is the principal of the default Istio Ingress Gateway
+	IstioIngressGatewayPrincipal      string = "cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"
 	oathkeeperMaesterAccountPrincipal string = "cluster.local/ns/kyma-system/sa/oathkeeper-maester-account"
 )
 
@@ -89,6 +90,19 @@ func (aps *AuthorizationPolicySpecBuilder) WithSelector(val *apiv1beta1.Workload
 	return aps
 }
 
+func (aps *AuthorizationPolicySpecBuilder) WithAction(val v1beta1.AuthorizationPolicy_Action) *AuthorizationPolicySpecBuilder {
+	aps.value.Action = val
+	return aps
+}
+
+// WithProvider sets the extension provider performing the authorization of an AuthorizationPolicy with the CUSTOM action
+func (aps *AuthorizationPolicySpecBuilder) WithProvider(name string) *AuthorizationPolicySpecBuilder {
+	aps.value.ActionDetail = &v1beta1.AuthorizationPolicy_Provider{
+		Provider: &v1beta1.AuthorizationPolicy_ExtensionProvider{Name: name},
+	}
+	return aps
+}
+
 func (aps *AuthorizationPolicySpecBuilder) WithRule(val *v1beta1.Rule) *AuthorizationPolicySpecBuilder {
 	aps.value.Rules = append(aps.value.Rules, val)
 	return aps
@@ -163,17 +177,59 @@ func (rf *FromBuilder) WithForcedJWTAuthorization(accessStrategies []*gatewayv1b
 }
 
 func (rf *FromBuilder) WithIngressGatewaySource() *FromBuilder {
-	source := v1beta1.Source{Principals: []string{istioIngressGatewayPrincipal}}
+	source := v1beta1.Source{Principals: []string{IstioIngressGatewayPrincipal}}
 	rf.value.Source = &source
 	return rf
 }
 
+// WithGatewaySource sets the principals of the gateways the requests are received through. The principals are combined
+// with the JWT request principals of the source, if set.
+func (rf *FromBuilder) WithGatewaySource(principals []string) *FromBuilder {
+	if rf.value.Source == nil {
+		rf.value.Source = &v1beta1.Source{}
+	}
+	rf.value.Source.Principals = principals
+	return rf
+}
+
+// WithoutPrincipals sets the principals of the workloads the requests must not come from, for example, the ingress gateways
+// excluded from the mesh workloads.
+func (rf *FromBuilder) WithoutPrincipals(notPrincipals []string) *FromBuilder {
+	if rf.value.Source == nil {
+		rf.value.Source = &v1beta1.Source{}
+	}
+	rf.value.Source.NotPrincipals = notPrincipals
+	return rf
+}
+
 func (rf *FromBuilder) WithOathkeeperProxySource() *FromBuilder {
 	source := v1beta1.Source{Principals: []string{oathkeeperMaesterAccountPrincipal}}
 	rf.value.Source = &source
 	return rf
 }
 
+// WithIpBlocks restricts the source to the given client IP blocks. The IP blocks are combined with the principals of the
+// source, so the request must fulfill both. Must be called after the principals are set.
+func (rf *FromBuilder) WithIpBlocks(allowList []string, denyList []string) *FromBuilder {
+	if rf.value.Source == nil {
+		rf.value.Source = &v1beta1.Source{}
+	}
+	rf.value.Source.RemoteIpBlocks = allowList
+	rf.value.Source.NotRemoteIpBlocks = denyList
+	return rf
+}
+
+// WithWorkloadSource sets the principals and namespaces of the workloads allowed to send the requests. The principals
+// replace the ingress gateway or Oathkeeper principal.
+func (rf *FromBuilder) WithWorkloadSource(principals []string, namespaces []string) *FromBuilder {
+	if rf.value.Source == nil {
+		rf.value.Source = &v1beta1.Source{}
+	}
+	rf.value.Source.Principals = principals
+	rf.value.Source.Namespaces = namespaces
+	return rf
+}
+
 // NewToBuilder returns builder for istio.io/apis/security/v1beta1/Rule_To type
 func NewToBuilder() *ToBuilder {
 	return &ToBuilder{
@@ -244,6 +300,11 @@ func (rc *ConditionBuilder) WithValues(values []string) *ConditionBuilder {
 	return rc
 }
 
+func (rc *ConditionBuilder) WithNotValues(notValues []string) *ConditionBuilder {
+	rc.value.NotValues = notValues
+	return rc
+}
+
 // NewRequestAuthenticationBuilder returns a builder for istio.io/client-go/pkg/apis/security/v1beta1/RequestAuthentication type
 func NewRequestAuthenticationBuilder() *RequestAuthenticationBuilder {
 	return &RequestAuthenticationBuilder{
@@ -350,10 +411,14 @@ func (jr *JwtRuleBuilder) From(val []*gatewayv1beta1.Authenticator) *JwtRuleBuil
 			jwtRule := v1beta1.JWTRule{
 				Issuer:  authentication.Issuer,
 				JwksUri: authentication.JwksUri,
+				Jwks:    authentication.Jwks,
 				// We decided to change the default behavior of Istio to provide the same behavior as ORY
 				// so there's no breaking change
 				ForwardOriginalToken: true,
 			}
+			if authentication.ForwardOriginalToken != nil {
+				jwtRule.ForwardOriginalToken = *authentication.ForwardOriginalToken
+			}
 			for _, fromHeader := range authentication.FromHeaders {
 				jwtRule.FromHeaders = append(jwtRule.FromHeaders, &v1beta1.JWTHeader{
 					Name:   fromHeader.Name,
@@ -363,6 +428,15 @@ func (jr *JwtRuleBuilder) From(val []*gatewayv1beta1.Authenticator) *JwtRuleBuil
 			if authentication.FromParams != nil {
 				jwtRule.FromParams = authentication.FromParams
 			}
+			if authentication.Audiences != nil {
+				jwtRule.Audiences = authentication.Audiences
+			}
+			for _, claimToHeader := range authentication.OutputClaimToHeaders {
+				jwtRule.OutputClaimToHeaders = append(jwtRule.OutputClaimToHeaders, &v1beta1.ClaimToHeader{
+					Header: claimToHeader.Header,
+					Claim:  claimToHeader.Claim,
+				})
+			}
 			*jr.value = append(*jr.value, &jwtRule)
 		}
 	}
@@ -399,11 +473,21 @@ type Authentications struct {
 type Authentication struct {
 	Issuer      string       `json:"issuer"`
 	JwksUri     string       `json:"jwksUri"`
+	Jwks        string       `json:"jwks"`
 	FromHeaders []*JwtHeader `json:"fromHeaders"`
 	FromParams  []string     `json:"fromParams"`
+	// OutputClaimToHeaders and ForwardOriginalToken mirror the fields of gatewayv1beta1.JwtAuthentication
+	OutputClaimToHeaders []*ClaimToHeader `json:"outputClaimToHeaders"`
+	ForwardOriginalToken *bool            `json:"forwardOriginalToken"`
+	Audiences            []string         `json:"audiences"`
 }
 
 type JwtHeader struct {
 	Name   string `json:"name"`
 	Prefix string `json:"prefix"`
 }
+
+type ClaimToHeader struct {
+	Header string `json:"header"`
+	Claim  string `json:"claim"`
+}
@@ -4,6 +4,7 @@ import (
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"istio.io/api/security/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -59,6 +60,63 @@ var _ = Describe("Builder for", func() {
 		})
 	})
 
+	Describe("AuthorizationPolicy with CUSTOM action", func() {
+		It("should build an AuthorizationPolicy delegating to the extension provider", func() {
+			spec := NewAuthorizationPolicySpecBuilder().
+				WithAction(v1beta1.AuthorizationPolicy_CUSTOM).
+				WithProvider("opa").
+				WithRule(NewRuleBuilder().
+					WithTo(NewToBuilder().
+						WithOperation(NewOperationBuilder().
+							WithPath(path).
+							WithMethods(methods).Get()).Get()).Get()).
+				Get()
+
+			Expect(spec.Action).To(Equal(v1beta1.AuthorizationPolicy_CUSTOM))
+			Expect(spec.GetProvider().Name).To(Equal("opa"))
+			Expect(spec.Rules[0].To[0].Operation.Paths).To(ConsistOf(path))
+			Expect(spec.Rules[0].From).To(BeEmpty())
+		})
+	})
+
+	Describe("From", func() {
+		It("should combine the source principals with the IP blocks", func() {
+			from := NewFromBuilder().
+				WithIngressGatewaySource().
+				WithIpBlocks([]string{"10.0.0.0/8"}, []string{"10.1.0.0/16", "10.2.0.1"}).
+				Get()
+
+			Expect(from.Source.Principals).To(ConsistOf(IstioIngressGatewayPrincipal))
+			Expect(from.Source.RemoteIpBlocks).To(ConsistOf("10.0.0.0/8"))
+			Expect(from.Source.NotRemoteIpBlocks).To(ConsistOf("10.1.0.0/16", "10.2.0.1"))
+		})
+
+		It("should replace the source principals with the workload source", func() {
+			from := NewFromBuilder().
+				WithIngressGatewaySource().
+				WithWorkloadSource(nil, []string{"orders"}).
+				Get()
+
+			Expect(from.Source.Principals).To(BeEmpty())
+			Expect(from.Source.Namespaces).To(ConsistOf("orders"))
+		})
+
+		It("should combine the gateway principals with the JWT request principals", func() {
+			jwt := &gatewayv1beta1.Authenticator{Handler: &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: &runtime.RawExtension{Raw: []byte(`{"authentications": [{"issuer": "testIssuer", "jwksUri": "testJwksUri"}]}`)},
+			}}
+
+			from := NewFromBuilder().
+				WithForcedJWTAuthorization([]*gatewayv1beta1.Authenticator{jwt}).
+				WithGatewaySource([]string{"cluster.local/ns/partners/sa/partner-gateway"}).
+				Get()
+
+			Expect(from.Source.RequestPrincipals).To(ConsistOf("testIssuer/*"))
+			Expect(from.Source.Principals).To(ConsistOf("cluster.local/ns/partners/sa/partner-gateway"))
+		})
+	})
+
 	Describe("RequestAuthentication", func() {
 		name := "testName"
 		namespace := "testNs"
@@ -162,5 +220,44 @@ var _ = Describe("Builder for", func() {
 			Expect(ap.Spec.JwtRules[0].FromHeaders).To(BeEmpty())
 			Expect(ap.Spec.JwtRules[0].ForwardOriginalToken).To(BeTrue())
 		})
+
+		It("should build an RequestAuthentication with outputClaimToHeaders and forwardOriginalToken", func() {
+			testRaw := runtime.RawExtension{Raw: []byte(`{"authentications": [{"issuer": "testIssuer", "jwksUri": "testJwksUri", "outputClaimToHeaders": [{"header": "x-user", "claim": "sub"}, {"header": "x-email", "claim": "email"}], "forwardOriginalToken": false}]}`)}
+			testHandler := gatewayv1beta1.Handler{Config: &testRaw}
+			testAuthenticator := gatewayv1beta1.Authenticator{Handler: &testHandler}
+			testAccessStrategies := []*gatewayv1beta1.Authenticator{&testAuthenticator}
+
+			ap := NewRequestAuthenticationBuilder().WithGenerateName(name).WithNamespace(namespace).
+				WithSpec(NewRequestAuthenticationSpecBuilder().
+					WithSelector(NewSelectorBuilder().WithMatchLabels(testMatchLabelsKey, testMatchLabelsValue).Get()).
+					WithJwtRules(*NewJwtRuleBuilder().From(testAccessStrategies).Get()).
+					Get()).
+				Get()
+
+			Expect(ap.Spec.JwtRules).To(HaveLen(1))
+			Expect(ap.Spec.JwtRules[0].OutputClaimToHeaders).To(HaveLen(2))
+			Expect(ap.Spec.JwtRules[0].OutputClaimToHeaders[0].Header).To(Equal("x-user"))
+			Expect(ap.Spec.JwtRules[0].OutputClaimToHeaders[0].Claim).To(Equal("sub"))
+			Expect(ap.Spec.JwtRules[0].OutputClaimToHeaders[1].Header).To(Equal("x-email"))
+			Expect(ap.Spec.JwtRules[0].OutputClaimToHeaders[1].Claim).To(Equal("email"))
+			Expect(ap.Spec.JwtRules[0].ForwardOriginalToken).To(BeFalse())
+		})
+
+		It("should build an RequestAuthentication with audiences", func() {
+			testRaw := runtime.RawExtension{Raw: []byte(`{"authentications": [{"issuer": "testIssuer", "jwksUri": "testJwksUri", "audiences": ["audience1", "audience2"]}]}`)}
+			testHandler := gatewayv1beta1.Handler{Config: &testRaw}
+			testAuthenticator := gatewayv1beta1.Authenticator{Handler: &testHandler}
+			testAccessStrategies := []*gatewayv1beta1.Authenticator{&testAuthenticator}
+
+			ap := NewRequestAuthenticationBuilder().WithGenerateName(name).WithNamespace(namespace).
+				WithSpec(NewRequestAuthenticationSpecBuilder().
+					WithSelector(NewSelectorBuilder().WithMatchLabels(testMatchLabelsKey, testMatchLabelsValue).Get()).
+					WithJwtRules(*NewJwtRuleBuilder().From(testAccessStrategies).Get()).
+					Get()).
+				Get()
+
+			Expect(ap.Spec.JwtRules).To(HaveLen(1))
+			Expect(ap.Spec.JwtRules[0].Audiences).To(Equal([]string{"audience1", "audience2"}))
+		})
 	})
 })
@@ -1,7 +1,13 @@
 package builders
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"istio.io/api/networking/v1beta1"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	"time"
@@ -131,6 +137,26 @@ func (hr *httpRoute) Timeout(value time.Duration) *httpRoute {
 	return hr
 }
 
+func (hr *httpRoute) Retries(rp *retryPolicy) *httpRoute {
+	hr.value.Retries = rp.Get()
+	return hr
+}
+
+func (hr *httpRoute) Rewrite(rw *rewrite) *httpRoute {
+	hr.value.Rewrite = rw.Get()
+	return hr
+}
+
+func (hr *httpRoute) Redirect(r *redirect) *httpRoute {
+	hr.value.Redirect = r.Get()
+	return hr
+}
+
+func (hr *httpRoute) DirectResponse(dr *directResponse) *httpRoute {
+	hr.value.DirectResponse = dr.Get()
+	return hr
+}
+
 // MatchRequest returns builder for istio.io/api/networking/v1beta1/HTTPMatchRequest type
 func MatchRequest() *matchRequest {
 	return &matchRequest{
@@ -151,11 +177,50 @@ func (mr *matchRequest) Uri() *stringMatch {
 	return &stringMatch{mr.value.Uri, func() *matchRequest { return mr }}
 }
 
+// Header adds a match on the header with the given name. Istio only accepts lowercase header names.
+func (mr *matchRequest) Header(name string) *stringMatch {
+	if mr.value.Headers == nil {
+		mr.value.Headers = make(map[string]*v1beta1.StringMatch)
+	}
+	name = strings.ToLower(name)
+	mr.value.Headers[name] = &v1beta1.StringMatch{}
+	return &stringMatch{mr.value.Headers[name], func() *matchRequest { return mr }}
+}
+
+func (mr *matchRequest) QueryParam(name string) *stringMatch {
+	if mr.value.QueryParams == nil {
+		mr.value.QueryParams = make(map[string]*v1beta1.StringMatch)
+	}
+	mr.value.QueryParams[name] = &v1beta1.StringMatch{}
+	return &stringMatch{mr.value.QueryParams[name], func() *matchRequest { return mr }}
+}
+
+// Method adds a match on the request method. Several methods are matched with a regex.
+func (mr *matchRequest) Method(methods ...string) *matchRequest {
+	mr.value.Method = &v1beta1.StringMatch{}
+	st := &stringMatch{mr.value.Method, func() *matchRequest { return mr }}
+	if len(methods) == 1 {
+		return st.Exact(methods[0])
+	}
+	return st.Regex(strings.Join(methods, "|"))
+}
+
+// Gateways restricts the match to the requests received through the given gateways of the VirtualService
+func (mr *matchRequest) Gateways(gateways ...string) *matchRequest {
+	mr.value.Gateways = append(mr.value.Gateways, gateways...)
+	return mr
+}
+
 type stringMatch struct {
 	value  *v1beta1.StringMatch
 	parent func() *matchRequest
 }
 
+func (st *stringMatch) Exact(val string) *matchRequest {
+	st.value.MatchType = &v1beta1.StringMatch_Exact{Exact: val}
+	return st.parent()
+}
+
 func (st *stringMatch) Regex(val string) *matchRequest {
 	st.value.MatchType = &v1beta1.StringMatch_Regex{Regex: val}
 	return st.parent()
@@ -166,6 +231,20 @@ func (st *stringMatch) Prefix(val string) *matchRequest {
 	return st.parent()
 }
 
+// From sets the match type of the given APIRule StringMatch
+func (st *stringMatch) From(val *gatewayv1beta1.StringMatch) *matchRequest {
+	switch {
+	case val == nil:
+		return st.parent()
+	case val.Exact != "":
+		return st.Exact(val.Exact)
+	case val.Prefix != "":
+		return st.Prefix(val.Prefix)
+	default:
+		return st.Regex(val.Regex)
+	}
+}
+
 // RouteDestination returns builder for istio.io/api/networking/v1beta1/HTTPRouteDestination type
 func RouteDestination() *routeDestination {
 	return &routeDestination{&v1beta1.HTTPRouteDestination{
@@ -194,6 +273,11 @@ func (rd *routeDestination) Port(val uint32) *routeDestination {
 	return rd
 }
 
+func (rd *routeDestination) Weight(val int32) *routeDestination {
+	rd.value.Weight = val
+	return rd
+}
+
 // CorsPolicy returns builder for istio.io/api/networking/v1beta1/CorsPolicy type
 func CorsPolicy() *corsPolicy {
 	return &corsPolicy{
@@ -236,6 +320,165 @@ func (cp *corsPolicy) AllowOrigins(val ...*v1beta1.StringMatch) *corsPolicy {
 	return cp
 }
 
+func (cp *corsPolicy) ExposeHeaders(val ...string) *corsPolicy {
+	if len(val) == 0 {
+		cp.value.ExposeHeaders = nil
+	} else {
+		cp.value.ExposeHeaders = append(cp.value.ExposeHeaders, val...)
+	}
+	return cp
+}
+
+func (cp *corsPolicy) AllowCredentials(val *bool) *corsPolicy {
+	if val == nil {
+		cp.value.AllowCredentials = nil
+	} else {
+		cp.value.AllowCredentials = wrapperspb.Bool(*val)
+	}
+	return cp
+}
+
+func (cp *corsPolicy) MaxAge(val *time.Duration) *corsPolicy {
+	if val == nil {
+		cp.value.MaxAge = nil
+	} else {
+		cp.value.MaxAge = durationpb.New(*val)
+	}
+	return cp
+}
+
+// RetryPolicy returns builder for istio.io/api/networking/v1beta1/HTTPRetry type
+func RetryPolicy() *retryPolicy {
+	return &retryPolicy{
+		value: &v1beta1.HTTPRetry{},
+	}
+}
+
+type retryPolicy struct {
+	value *v1beta1.HTTPRetry
+}
+
+func (rp *retryPolicy) Get() *v1beta1.HTTPRetry {
+	return rp.value
+}
+
+func (rp *retryPolicy) Attempts(val int32) *retryPolicy {
+	rp.value.Attempts = val
+	return rp
+}
+
+func (rp *retryPolicy) PerTryTimeout(val time.Duration) *retryPolicy {
+	rp.value.PerTryTimeout = durationpb.New(val)
+	return rp
+}
+
+func (rp *retryPolicy) RetryOn(val string) *retryPolicy {
+	rp.value.RetryOn = val
+	return rp
+}
+
+// Rewrite returns builder for istio.io/api/networking/v1beta1/HTTPRewrite type
+func Rewrite() *rewrite {
+	return &rewrite{
+		value: &v1beta1.HTTPRewrite{},
+	}
+}
+
+type rewrite struct {
+	value *v1beta1.HTTPRewrite
+}
+
+func (rw *rewrite) Get() *v1beta1.HTTPRewrite {
+	return rw.value
+}
+
+func (rw *rewrite) Uri(val string) *rewrite {
+	rw.value.Uri = val
+	return rw
+}
+
+func (rw *rewrite) UriRegexRewrite(match, val string) *rewrite {
+	rw.value.UriRegexRewrite = &v1beta1.RegexRewrite{Match: match, Rewrite: val}
+	return rw
+}
+
+// StripPrefix removes the given prefix from the path of the URI. The path consisting of the prefix only is rewritten to "/".
+func (rw *rewrite) StripPrefix(prefix string) *rewrite {
+	if prefix == "" {
+		return rw
+	}
+	return rw.UriRegexRewrite(fmt.Sprintf("^%s(/|$)", regexp.QuoteMeta(strings.TrimSuffix(prefix, "/"))), "/")
+}
+
+func (rw *rewrite) Authority(val string) *rewrite {
+	rw.value.Authority = val
+	return rw
+}
+
+// Redirect returns builder for istio.io/api/networking/v1beta1/HTTPRedirect type
+func Redirect() *redirect {
+	return &redirect{
+		value: &v1beta1.HTTPRedirect{},
+	}
+}
+
+type redirect struct {
+	value *v1beta1.HTTPRedirect
+}
+
+func (r *redirect) Get() *v1beta1.HTTPRedirect {
+	return r.value
+}
+
+func (r *redirect) Uri(val string) *redirect {
+	r.value.Uri = val
+	return r
+}
+
+func (r *redirect) Authority(val string) *redirect {
+	r.value.Authority = val
+	return r
+}
+
+func (r *redirect) Scheme(val string) *redirect {
+	r.value.Scheme = val
+	return r
+}
+
+func (r *redirect) Code(val uint32) *redirect {
+	r.value.RedirectCode = val
+	return r
+}
+
+// DirectResponse returns builder for istio.io/api/networking/v1beta1/HTTPDirectResponse type
+func DirectResponse() *directResponse {
+	return &directResponse{
+		value: &v1beta1.HTTPDirectResponse{},
+	}
+}
+
+type directResponse struct {
+	value *v1beta1.HTTPDirectResponse
+}
+
+func (dr *directResponse) Get() *v1beta1.HTTPDirectResponse {
+	return dr.value
+}
+
+func (dr *directResponse) Status(val uint32) *directResponse {
+	dr.value.Status = val
+	return dr
+}
+
+func (dr *directResponse) Body(val string) *directResponse {
+	if val == "" {
+		dr.value.Body = nil
+		return dr
+	}
+	dr.value.Body = &v1beta1.HTTPBody{Specifier: &v1beta1.HTTPBody_String_{String_: val}}
+	return dr
+}
+
 // NewHttpRouteHeadersBuilder returns builder for istio.io/api/networking/v1beta1/Headers type
 func NewHttpRouteHeadersBuilder() HttpRouteHeadersBuilder {
 	return HttpRouteHeadersBuilder{
@@ -274,3 +517,9 @@ func (h HttpRouteHeadersBuilder) SetRequestHeaders(headers map[string]string) Ht
 
 	return h
 }
+
+// RemoveRequestHeaders removes the request headers
+func (h HttpRouteHeadersBuilder) RemoveRequestHeaders(headers ...string) HttpRouteHeadersBuilder {
+	h.value.Request.Remove = append(h.value.Request.Remove, headers...)
+	return h
+}
@@ -1,6 +1,7 @@
 package builders
 
 import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -105,4 +106,66 @@ var _ = Describe("Builder for", func() {
 			Expect(result.Http[1].Route[0].Weight).To(Equal(int32(100)))
 		})
 	})
+
+	Describe("MatchRequest", func() {
+		It("should match headers and query parameters", func() {
+			matchRequest := MatchRequest().Uri().Regex("/orders")
+			matchRequest.Header("X-Api-Version").From(&gatewayv1beta1.StringMatch{Prefix: "2"})
+			matchRequest.QueryParam("beta").Exact("true")
+			result := matchRequest.Get()
+
+			Expect(result.Uri.GetRegex()).To(Equal("/orders"))
+			Expect(result.Headers["x-api-version"].GetPrefix()).To(Equal("2"))
+			Expect(result.QueryParams["beta"].GetExact()).To(Equal("true"))
+		})
+
+		It("should match a single method exactly and several methods with a regex", func() {
+			Expect(MatchRequest().Method("GET").Get().Method.GetExact()).To(Equal("GET"))
+			Expect(MatchRequest().Method("POST", "PUT").Get().Method.GetRegex()).To(Equal("POST|PUT"))
+		})
+	})
+
+	Describe("Rewrite", func() {
+		It("should strip the prefix from the path", func() {
+			result := Rewrite().StripPrefix("/orders/v1/").Authority("orders.internal").Get()
+
+			Expect(result.UriRegexRewrite.Match).To(Equal(`^/orders/v1(/|$)`))
+			Expect(result.UriRegexRewrite.Rewrite).To(Equal("/"))
+			Expect(result.Uri).To(BeEmpty())
+			Expect(result.Authority).To(Equal("orders.internal"))
+		})
+
+		It("should not set a regex rewrite for an empty prefix", func() {
+			result := Rewrite().StripPrefix("").Uri("/items").Get()
+
+			Expect(result.UriRegexRewrite).To(BeNil())
+			Expect(result.Uri).To(Equal("/items"))
+		})
+	})
+
+	Describe("Redirect", func() {
+		It("should build the redirect", func() {
+			result := Redirect().Uri("/new").Authority("example.com").Scheme("https").Code(308).Get()
+
+			Expect(result.Uri).To(Equal("/new"))
+			Expect(result.Authority).To(Equal("example.com"))
+			Expect(result.Scheme).To(Equal("https"))
+			Expect(result.RedirectCode).To(BeEquivalentTo(308))
+		})
+	})
+
+	Describe("DirectResponse", func() {
+		It("should build the direct response with a string body", func() {
+			result := DirectResponse().Status(200).Body("User-agent: *").Get()
+
+			Expect(result.Status).To(BeEquivalentTo(200))
+			Expect(result.Body.GetString_()).To(Equal("User-agent: *"))
+		})
+
+		It("should not set the body when it is empty", func() {
+			result := DirectResponse().Status(410).Body("").Get()
+
+			Expect(result.Body).To(BeNil())
+		})
+	})
 })
@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	"regexp"
+	"strings"
+)
+
+const claimKeyPrefix = "request.auth.claims"
+
+// claimPathRegex matches a claim name or a path of nested claims separated by dots. Brackets are not allowed, since the
+// names are put in brackets in the AuthorizationPolicy condition key.
+var claimPathRegex = regexp.MustCompile(`^[^.\[\]\s]+(\.[^.\[\]\s]+)*$`)
+
+// IsValidClaimPath returns true if the value is a claim name or a path of nested claims separated by dots
+func IsValidClaimPath(claimPath string) bool {
+	return claimPathRegex.MatchString(claimPath)
+}
+
+// GetClaimConditionKey returns the AuthorizationPolicy condition key of the claim, for example
+// request.auth.claims[realm_access][roles] for the nested claim realm_access.roles
+func GetClaimConditionKey(claimPath string) string {
+	var key strings.Builder
+	key.WriteString(claimKeyPrefix)
+	for _, name := range strings.Split(claimPath, ".") {
+		key.WriteString("[" + name + "]")
+	}
+	return key.String()
+}
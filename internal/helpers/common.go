@@ -3,6 +3,9 @@ package helpers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	apiv1beta1 "istio.io/api/type/v1beta1"
@@ -11,6 +14,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// DefaultHttpTimeout is the timeout of HTTP requests if neither the rule nor the APIRule spec defines one.
+const DefaultHttpTimeout = time.Second * 180
+
 func FindServiceNamespace(api *gatewayv1beta1.APIRule, rule *gatewayv1beta1.Rule) string {
 	// Fallback direction for the upstream service namespace: Rule.Service > Spec.Service > APIRule
 	if rule != nil && rule.Service != nil && rule.Service.Namespace != nil {
@@ -22,6 +28,77 @@ func FindServiceNamespace(api *gatewayv1beta1.APIRule, rule *gatewayv1beta1.Rule
 	return api.Namespace
 }
 
+// GetRuleMatchKey returns a key that is equal for rules matching the same requests, which is the case if they have the same
+// path regex, the same header and query parameter match conditions and the same gateways. The methods of the rules are not
+// part of the key.
+func GetRuleMatchKey(rule gatewayv1beta1.Rule) string {
+	pathRegex := GetPathRegex(rule)
+	conditions := append(stringMatchConditions("header", rule.Headers), stringMatchConditions("query", rule.QueryParams)...)
+	for _, gateway := range rule.Gateways {
+		conditions = append(conditions, "gateway:"+gateway)
+	}
+	if len(conditions) == 0 {
+		return pathRegex
+	}
+
+	sort.Strings(conditions)
+	return fmt.Sprintf("%s[%s]", pathRegex, strings.Join(conditions, ","))
+}
+
+func stringMatchConditions(kind string, matches map[string]*gatewayv1beta1.StringMatch) []string {
+	var conditions []string
+	for name, match := range matches {
+		if match == nil {
+			continue
+		}
+		// Header names are case-insensitive
+		if kind == "header" {
+			name = strings.ToLower(name)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s:%s=exact:%s,prefix:%s,regex:%s", kind, name, match.Exact, match.Prefix, match.Regex))
+	}
+	return conditions
+}
+
+// GetRuleServices returns the services the traffic of the rule is routed to, with the namespace of every service set.
+// Fallback direction for the services: Rule.Services > Rule.Service > Spec.Services > Spec.Service
+// A single service receives the whole traffic.
+func GetRuleServices(api *gatewayv1beta1.APIRule, rule *gatewayv1beta1.Rule) []*gatewayv1beta1.WeightedService {
+	switch {
+	case rule != nil && len(rule.Services) > 0:
+		return withNamespace(rule.Services, api.Namespace)
+	case rule != nil && rule.Service != nil:
+		return []*gatewayv1beta1.WeightedService{singleService(rule.Service, FindServiceNamespace(api, rule))}
+	case len(api.Spec.Services) > 0:
+		return withNamespace(api.Spec.Services, api.Namespace)
+	case api.Spec.Service != nil:
+		return []*gatewayv1beta1.WeightedService{singleService(api.Spec.Service, FindServiceNamespace(api, rule))}
+	default:
+		return nil
+	}
+}
+
+func withNamespace(services []*gatewayv1beta1.WeightedService, defaultNamespace string) []*gatewayv1beta1.WeightedService {
+	var result []*gatewayv1beta1.WeightedService
+	for _, service := range services {
+		if service == nil {
+			continue
+		}
+		s := service.DeepCopy()
+		if s.Namespace == nil {
+			s.Namespace = &defaultNamespace
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+func singleService(service *gatewayv1beta1.Service, namespace string) *gatewayv1beta1.WeightedService {
+	s := service.DeepCopy()
+	s.Namespace = &namespace
+	return &gatewayv1beta1.WeightedService{Service: *s, Weight: 100}
+}
+
 func GetLabelSelectorFromService(ctx context.Context, client client.Client, service *gatewayv1beta1.Service, api *gatewayv1beta1.APIRule, rule *gatewayv1beta1.Rule) (*apiv1beta1.WorkloadSelector, error) {
 	workloadSelector := apiv1beta1.WorkloadSelector{}
 	if service == nil || service.Name == nil {
@@ -53,3 +130,18 @@ func GetLabelSelectorFromService(ctx context.Context, client client.Client, serv
 	}
 	return &workloadSelector, nil
 }
+
+// GetWorkloadSelectorKey returns a key identifying the workloads selected by the labels in the namespace
+func GetWorkloadSelectorKey(namespace string, matchLabels map[string]string) string {
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	var labels []string
+	for k, v := range matchLabels {
+		labels = append(labels, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(labels)
+
+	return fmt.Sprintf("%s:%s", namespace, strings.Join(labels, ","))
+}
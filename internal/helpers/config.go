@@ -30,14 +30,23 @@ var ReadConfigMapHandle = ReadConfigMap
 
 type Config struct {
 	JWTHandler string `yaml:"jwtHandler"`
+	// ScopeClaimKeys are the names of the JWT claims containing the scopes required by the Istio jwt access strategy
+	ScopeClaimKeys []string `yaml:"scopeClaimKeys,omitempty"`
+	// GatewayPrincipals are the principals of the ingress gateways by gateway in the format namespace/name. Requests
+	// through gateways without a principal are expected from the default Istio Ingress Gateway.
+	GatewayPrincipals map[string]string `yaml:"gatewayPrincipals,omitempty"`
 }
 
 func (c *Config) Reset() {
 	c.JWTHandler = ""
+	c.ScopeClaimKeys = nil
+	c.GatewayPrincipals = nil
 }
 
 func (c *Config) ResetToDefault() {
 	c.JWTHandler = JWT_HANDLER_ORY
+	c.ScopeClaimKeys = nil
+	c.GatewayPrincipals = nil
 }
 
 func (c *Config) ReadFromConfigMap(ctx context.Context, client client.Client) error {
@@ -45,6 +54,9 @@ func (c *Config) ReadFromConfigMap(ctx context.Context, client client.Client) er
 	if err != nil {
 		return err
 	}
+	// Optional fields removed from the ConfigMap are not overwritten by the unmarshalling
+	c.ScopeClaimKeys = nil
+	c.GatewayPrincipals = nil
 	err = yaml.Unmarshal(cmData, c)
 	if err != nil {
 		return err
@@ -3,6 +3,9 @@ package helpers
 import (
 	"fmt"
 	"strings"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"golang.org/x/exp/slices"
 )
 
 func GetHostWithDomain(host, defaultDomainName string) string {
@@ -23,3 +26,29 @@ func GetHostWithDefaultDomain(host, defaultDomainName string) string {
 func GetHostLocalDomain(host string, namespace string) string {
 	return fmt.Sprintf("%s.%s.svc.cluster.local", host, namespace)
 }
+
+// GetHostsWithDomain returns the given hosts with the default domain name appended to every host that doesn't include a domain.
+func GetHostsWithDomain(hosts []string, defaultDomainName string) []string {
+	var result []string
+	for _, host := range hosts {
+		result = append(result, GetHostWithDomain(host, defaultDomainName))
+	}
+	return result
+}
+
+// GetAPIRuleHosts returns the hosts the APIRule exposes the service under. The default domain name is appended to the hosts
+// exposed through an ingress gateway, while the hosts exposed only to the workloads in the service mesh are internal
+// aliases that are returned unchanged.
+func GetAPIRuleHosts(spec gatewayv1beta1.APIRuleSpec, defaultDomainName string) []string {
+	if spec.IsMeshOnly() {
+		return spec.GetHosts()
+	}
+
+	hosts := GetHostsWithDomain(spec.GetHosts(), defaultDomainName)
+	for _, meshHost := range spec.GetMeshHosts() {
+		if !slices.Contains(hosts, meshHost) {
+			hosts = append(hosts, meshHost)
+		}
+	}
+	return hosts
+}
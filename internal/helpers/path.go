@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"regexp"
+	"strings"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+)
+
+// pathTemplateRegex matches a templated path segment, for example {id}
+var pathTemplateRegex = regexp.MustCompile(`^\{[a-zA-Z0-9_-]+\}$`)
+
+// IsPathTemplate returns true if the path segment is a template matching a single segment of the request path
+func IsPathTemplate(segment string) bool {
+	return pathTemplateRegex.MatchString(segment)
+}
+
+// HasPathTemplate returns true if the path contains at least one templated segment
+func HasPathTemplate(path string) bool {
+	for _, segment := range strings.Split(path, "/") {
+		if IsPathTemplate(segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPathRegex returns the regular expression matching the request paths of the rule. For the Regex path type, which is
+// the default, this is the path of the rule.
+func GetPathRegex(rule gatewayv1beta1.Rule) string {
+	switch rule.PathType {
+	case gatewayv1beta1.PathTypeExact:
+		return pathTemplateToRegex(rule.Path)
+	case gatewayv1beta1.PathTypePrefix:
+		// A templated segment can't be matched partially, so the prefix of a templated path has to end at a segment boundary
+		if HasPathTemplate(rule.Path) {
+			return pathTemplateToRegex(strings.TrimSuffix(rule.Path, "/")) + "(/.*)?"
+		}
+		return regexp.QuoteMeta(rule.Path) + ".*"
+	default:
+		return rule.Path
+	}
+}
+
+func pathTemplateToRegex(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if IsPathTemplate(segment) {
+			segments[i] = "[^/]+"
+		} else {
+			segments[i] = regexp.QuoteMeta(segment)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// GetAuthorizationPolicyPaths returns the AuthorizationPolicy paths matching the same request paths as the rule. It returns
+// false if the path is a regular expression that can't be expressed with the exact, prefix and template matches supported
+// by AuthorizationPolicies.
+func GetAuthorizationPolicyPaths(rule gatewayv1beta1.Rule) ([]string, bool) {
+	switch rule.PathType {
+	case gatewayv1beta1.PathTypeExact:
+		path, ok := pathTemplateToAuthorizationPolicyPath(rule.Path)
+		return []string{path}, ok
+	case gatewayv1beta1.PathTypePrefix:
+		if HasPathTemplate(rule.Path) {
+			path, ok := pathTemplateToAuthorizationPolicyPath(strings.TrimSuffix(rule.Path, "/"))
+			return []string{path, path + "/{**}"}, ok
+		}
+		return []string{rule.Path + "*"}, isAuthorizationPolicyLiteral(rule.Path)
+	}
+
+	// The VirtualService matches "/*" as prefix "/" and "/.*" is the regex matching all paths
+	if rule.Path == "/*" || rule.Path == "/.*" {
+		return []string{"/*"}, true
+	}
+
+	if literal, ok := regexLiteral(rule.Path); ok {
+		return []string{literal}, isAuthorizationPolicyLiteral(literal)
+	}
+
+	if prefix, ok := regexLiteral(strings.TrimSuffix(rule.Path, ".*")); ok && strings.HasSuffix(rule.Path, ".*") {
+		return []string{prefix + "*"}, isAuthorizationPolicyLiteral(prefix)
+	}
+
+	return nil, false
+}
+
+func pathTemplateToAuthorizationPolicyPath(path string) (string, bool) {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if IsPathTemplate(segment) {
+			segments[i] = "{*}"
+		} else if !isAuthorizationPolicyLiteral(segment) {
+			return "", false
+		}
+	}
+	return strings.Join(segments, "/"), true
+}
+
+// regexLiteral returns the string matched by the regular expression if it matches exactly one string
+func regexLiteral(expr string) (string, bool) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return "", false
+	}
+	literal, complete := re.LiteralPrefix()
+	return literal, complete
+}
+
+// isAuthorizationPolicyLiteral returns false if the value contains characters with a special meaning in AuthorizationPolicy paths
+func isAuthorizationPolicyLiteral(value string) bool {
+	return !strings.ContainsAny(value, "*{}")
+}
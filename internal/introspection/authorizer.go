@@ -0,0 +1,267 @@
+package introspection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// RuleHeader is the header set by the VirtualService on requests to rules with native token introspection. It
+	// references the rule as <namespace>/<name>/<index of the rule>, so that the authorizer can look up the introspection
+	// configuration of the rule whose route matched the request.
+	RuleHeader = "x-api-gateway-introspection"
+
+	// ClientCertHeader is the header the Istio sidecar of the authorizer sets to the identity of the mTLS peer, which is
+	// the sidecar of the workload the request is authorized for.
+	ClientCertHeader = "x-forwarded-client-cert"
+
+	ClientIdKey     = "client_id"
+	ClientSecretKey = "client_secret"
+
+	defaultTimeout = 5 * time.Second
+)
+
+// Authorizer implements the HTTP protocol of the Envoy external authorization filter. It introspects the bearer token of
+// the request at the introspection endpoint configured for the matching rule of the APIRule and allows the request, if the
+// token is active and has the required scopes.
+type Authorizer struct {
+	// Client is used to read the APIRules
+	Client client.Reader
+	// SecretReader is used to read the client credentials. It should not be a cached client, so that the Secrets of the
+	// whole cluster are not watched.
+	SecretReader client.Reader
+	HttpClient   *http.Client
+	Log          logr.Logger
+}
+
+// NewAuthorizer returns an Authorizer with a HTTP client using the default timeout
+func NewAuthorizer(apiRuleReader client.Reader, secretReader client.Reader, log logr.Logger) *Authorizer {
+	return &Authorizer{
+		Client:       apiRuleReader,
+		SecretReader: secretReader,
+		HttpClient:   &http.Client{Timeout: defaultTimeout},
+		Log:          log,
+	}
+}
+
+type introspectionResponse struct {
+	Active bool   `json:"active"`
+	Scope  string `json:"scope"`
+}
+
+func (a *Authorizer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	rule, namespace, err := a.findRule(ctx, req)
+	if err != nil {
+		a.Log.Info("Denying request without matching introspection rule", "reason", err.Error())
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	token, ok := bearerToken(req)
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	config, err := rule.GetIntrospectionConfig()
+	if err != nil {
+		a.Log.Error(err, "Could not read introspection config")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response, err := a.introspect(ctx, config, namespace, token)
+	if err != nil {
+		a.Log.Error(err, "Token introspection failed", "url", config.IntrospectionUrl)
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	if !response.Active {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !hasScopes(response.Scope, config.RequiredScopes) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// findRule returns the rule referenced by the RuleHeader, which must introspect tokens natively and match the method of the
+// request. Because the RuleHeader can be set by any caller of the workload, the APIRule must be in the namespace of the
+// workload the request is authorized for, and the rule must route only to services in that namespace.
+func (a *Authorizer) findRule(ctx context.Context, req *http.Request) (gatewayv1beta1.Rule, string, error) {
+	parts := strings.Split(req.Header.Get(RuleHeader), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return gatewayv1beta1.Rule{}, "", fmt.Errorf("header %s is missing or invalid", RuleHeader)
+	}
+	namespace, name := parts[0], parts[1]
+	index, err := strconv.Atoi(parts[2])
+	if err != nil || index < 0 {
+		return gatewayv1beta1.Rule{}, "", fmt.Errorf("header %s has an invalid rule index", RuleHeader)
+	}
+
+	destinationNamespace, err := peerNamespace(req)
+	if err != nil {
+		return gatewayv1beta1.Rule{}, "", err
+	}
+	if namespace != destinationNamespace {
+		return gatewayv1beta1.Rule{}, "", fmt.Errorf("APIRule %s/%s is not in the namespace %s of the destination workload", namespace, name, destinationNamespace)
+	}
+
+	var apiRule gatewayv1beta1.APIRule
+	if err := a.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &apiRule); err != nil {
+		return gatewayv1beta1.Rule{}, "", err
+	}
+
+	if index < len(apiRule.Spec.Rules) {
+		rule := apiRule.Spec.Rules[index]
+		if processing.IsIntrospectionSecured(rule) && matchesMethod(rule, req.Method) && routesToNamespace(&apiRule, &rule, destinationNamespace) {
+			return rule, namespace, nil
+		}
+	}
+
+	return gatewayv1beta1.Rule{}, "", fmt.Errorf("rule %d of APIRule %s/%s is no introspection rule for %s requests", index, namespace, name, req.Method)
+}
+
+// peerNamespace returns the namespace of the SPIFFE identity in the last element of the ClientCertHeader, which is the
+// element appended by the sidecar of the authorizer for the mTLS peer.
+func peerNamespace(req *http.Request) (string, error) {
+	elements := splitQuoted(req.Header.Get(ClientCertHeader), ',')
+	if len(elements) == 0 {
+		return "", fmt.Errorf("header %s is missing", ClientCertHeader)
+	}
+
+	for _, pair := range splitQuoted(elements[len(elements)-1], ';') {
+		key, value, _ := strings.Cut(pair, "=")
+		if !strings.EqualFold(strings.TrimSpace(key), "URI") {
+			continue
+		}
+		// spiffe://<trust domain>/ns/<namespace>/sa/<service account>
+		segments := strings.Split(strings.Trim(strings.TrimSpace(value), `"`), "/")
+		if len(segments) == 7 && segments[0] == "spiffe:" && segments[3] == "ns" && segments[4] != "" && segments[5] == "sa" {
+			return segments[4], nil
+		}
+	}
+
+	return "", fmt.Errorf("header %s has no SPIFFE identity of the peer", ClientCertHeader)
+}
+
+// splitQuoted splits s at the separator, ignoring separators in quoted values
+func splitQuoted(s string, separator rune) []string {
+	var parts []string
+	var part strings.Builder
+	quoted := false
+	for _, c := range s {
+		switch {
+		case c == '"':
+			quoted = !quoted
+			part.WriteRune(c)
+		case c == separator && !quoted:
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteRune(c)
+		}
+	}
+	if part.Len() > 0 {
+		parts = append(parts, part.String())
+	}
+	return parts
+}
+
+func routesToNamespace(api *gatewayv1beta1.APIRule, rule *gatewayv1beta1.Rule, namespace string) bool {
+	for _, service := range helpers.GetRuleServices(api, rule) {
+		if service.Namespace == nil || *service.Namespace != namespace {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesMethod(rule gatewayv1beta1.Rule, method string) bool {
+	if len(rule.Methods) == 0 {
+		return true
+	}
+	for _, m := range rule.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func bearerToken(req *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// introspect sends the token to the introspection endpoint as defined in RFC 7662, authenticating with the client
+// credentials of the Secret if one is configured
+func (a *Authorizer) introspect(ctx context.Context, config gatewayv1beta1.IntrospectionConfig, namespace string, token string) (introspectionResponse, error) {
+	var response introspectionResponse
+
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.IntrospectionUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return response, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if config.ClientCredentialsSecret != "" {
+		var secret corev1.Secret
+		if err := a.SecretReader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: config.ClientCredentialsSecret}, &secret); err != nil {
+			return response, err
+		}
+		req.SetBasicAuth(url.QueryEscape(string(secret.Data[ClientIdKey])), url.QueryEscape(string(secret.Data[ClientSecretKey])))
+	}
+
+	res, err := a.HttpClient.Do(req)
+	if err != nil {
+		return response, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return response, fmt.Errorf("introspection endpoint returned status %d", res.StatusCode)
+	}
+
+	err = json.NewDecoder(res.Body).Decode(&response)
+	return response, err
+}
+
+func hasScopes(scope string, requiredScopes []string) bool {
+	scopes := make(map[string]bool)
+	for _, s := range strings.Fields(scope) {
+		scopes[s] = true
+	}
+	for _, required := range requiredScopes {
+		if !scopes[required] {
+			return false
+		}
+	}
+	return true
+}
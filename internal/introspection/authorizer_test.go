@@ -0,0 +1,216 @@
+package introspection_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/go-logr/logr"
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/introspection"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	activeToken   = "active-token"
+	inactiveToken = "inactive-token"
+	clientId      = "api-gateway"
+	clientSecret  = "secret"
+
+	defaultPeer = `By=spiffe://cluster.local/ns/kyma-system/sa/api-gateway;Hash=abc;Subject="";URI=spiffe://cluster.local/ns/default/sa/orders`
+	otherPeer   = `By=spiffe://cluster.local/ns/kyma-system/sa/api-gateway;Hash=abc;Subject="";URI=spiffe://cluster.local/ns/other/sa/attacker`
+)
+
+// newMockIntrospectionServer returns a server implementing the token introspection endpoint of RFC 7662. The active
+// token has the scopes read and write, and the client must authenticate with the client credentials.
+func newMockIntrospectionServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if id, secret, ok := req.BasicAuth(); !ok || id != clientId || secret != clientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if req.Method != http.MethodPost || req.ParseForm() != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		response := map[string]interface{}{"active": false}
+		if req.PostForm.Get("token") == activeToken {
+			response = map[string]interface{}{"active": true, "scope": "read write"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func getIntrospectionRule(path string, methods []string, config string) gatewayv1beta1.Rule {
+	return gatewayv1beta1.Rule{
+		Path:    path,
+		Methods: methods,
+		AccessStrategies: []*gatewayv1beta1.Authenticator{
+			{
+				Handler: &gatewayv1beta1.Handler{
+					Name:   "oauth2_introspection",
+					Config: &runtime.RawExtension{Raw: []byte(config)},
+				},
+			},
+		},
+	}
+}
+
+func getVersionedIntrospectionRule(path string, methods []string, version string, config string) gatewayv1beta1.Rule {
+	rule := getIntrospectionRule(path, methods, config)
+	rule.Headers = map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: version}}
+	return rule
+}
+
+func getFakeClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	Expect(gatewayv1beta1.AddToScheme(scheme)).To(Succeed())
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+var _ = Describe("Authorizer", func() {
+	var introspectionServer *httptest.Server
+	var authorizer *introspection.Authorizer
+
+	BeforeEach(func() {
+		introspectionServer = newMockIntrospectionServer()
+
+		apiRule := &gatewayv1beta1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "rule", Namespace: "default"},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Rules: []gatewayv1beta1.Rule{
+					getIntrospectionRule("/orders/.*", []string{"GET"},
+						`{"introspectionUrl": "`+introspectionServer.URL+`", "requiredScopes": ["read"], "clientCredentialsSecret": "credentials"}`),
+					getIntrospectionRule("/orders/.*", []string{"DELETE"},
+						`{"introspectionUrl": "`+introspectionServer.URL+`", "requiredScopes": ["admin"], "clientCredentialsSecret": "credentials"}`),
+					getIntrospectionRule("/public", []string{"GET"}, `{"introspectionUrl": "`+introspectionServer.URL+`"}`),
+					getIntrospectionRule("/oathkeeper", []string{"GET"}, `{"required_scope": ["read"]}`),
+					getVersionedIntrospectionRule("/orders/.*", []string{"GET"}, "2",
+						`{"introspectionUrl": "`+introspectionServer.URL+`", "requiredScopes": ["admin"], "clientCredentialsSecret": "credentials"}`),
+				},
+			},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "default"},
+			Data: map[string][]byte{
+				introspection.ClientIdKey:     []byte(clientId),
+				introspection.ClientSecretKey: []byte(clientSecret),
+			},
+		}
+
+		c := getFakeClient(apiRule, secret)
+		authorizer = introspection.NewAuthorizer(c, c, logr.Discard())
+	})
+
+	AfterEach(func() {
+		introspectionServer.Close()
+	})
+
+	checkFrom := func(peer string, method string, path string, rule string, token string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(""))
+		if peer != "" {
+			req.Header.Set(introspection.ClientCertHeader, peer)
+		}
+		if rule != "" {
+			req.Header.Set(introspection.RuleHeader, rule)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		recorder := httptest.NewRecorder()
+		authorizer.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	check := func(method string, path string, rule string, token string) int {
+		return checkFrom(defaultPeer, method, path, rule, token)
+	}
+
+	It("should allow the request if the token is active and has the required scopes", func() {
+		Expect(check(http.MethodGet, "/orders/1", "default/rule/0", activeToken)).To(Equal(http.StatusOK))
+	})
+
+	It("should deny the request if the token is not active", func() {
+		Expect(check(http.MethodGet, "/orders/1", "default/rule/0", inactiveToken)).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should deny the request if there is no bearer token", func() {
+		Expect(check(http.MethodGet, "/orders/1", "default/rule/0", "")).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should deny the request if the token doesn't have the required scopes of the rule matching the method", func() {
+		Expect(check(http.MethodDelete, "/orders/1", "default/rule/1", activeToken)).To(Equal(http.StatusForbidden))
+	})
+
+	It("should deny the request if the introspection endpoint rejects the client", func() {
+		Expect(check(http.MethodGet, "/public", "default/rule/2", activeToken)).To(Equal(http.StatusBadGateway))
+	})
+
+	It("should deny the request if no rule introspecting the tokens natively matches", func() {
+		Expect(check(http.MethodGet, "/oathkeeper", "default/rule/3", activeToken)).To(Equal(http.StatusForbidden))
+		Expect(check(http.MethodPost, "/orders/1", "default/rule/0", activeToken)).To(Equal(http.StatusForbidden))
+		Expect(check(http.MethodGet, "/orders/1", "default/rule/5", activeToken)).To(Equal(http.StatusForbidden))
+	})
+
+	It("should deny the request if the rule index is missing or invalid", func() {
+		Expect(check(http.MethodGet, "/orders/1", "default/rule", activeToken)).To(Equal(http.StatusForbidden))
+		Expect(check(http.MethodGet, "/orders/1", "default/rule/first", activeToken)).To(Equal(http.StatusForbidden))
+		Expect(check(http.MethodGet, "/orders/1", "default/rule/-1", activeToken)).To(Equal(http.StatusForbidden))
+	})
+
+	It("should use the rule referenced by the index if several rules match the path and method", func() {
+		Expect(check(http.MethodGet, "/orders/1", "default/rule/0", activeToken)).To(Equal(http.StatusOK))
+		Expect(check(http.MethodGet, "/orders/1", "default/rule/4", activeToken)).To(Equal(http.StatusForbidden))
+	})
+
+	It("should deny the request if the APIRule doesn't exist or isn't referenced", func() {
+		Expect(check(http.MethodGet, "/orders/1", "default/other/0", activeToken)).To(Equal(http.StatusForbidden))
+		Expect(check(http.MethodGet, "/orders/1", "", activeToken)).To(Equal(http.StatusForbidden))
+	})
+
+	It("should deny the request if the APIRule is not in the namespace of the destination workload", func() {
+		Expect(checkFrom(otherPeer, http.MethodGet, "/orders/1", "default/rule/0", activeToken)).To(Equal(http.StatusForbidden))
+	})
+
+	It("should use the identity of the peer appended last to the client certificate header", func() {
+		spoofed := defaultPeer + "," + otherPeer
+		Expect(checkFrom(spoofed, http.MethodGet, "/orders/1", "default/rule/0", activeToken)).To(Equal(http.StatusForbidden))
+		Expect(checkFrom(otherPeer+","+defaultPeer, http.MethodGet, "/orders/1", "default/rule/0", activeToken)).To(Equal(http.StatusOK))
+	})
+
+	It("should deny the request if the identity of the destination workload is unknown", func() {
+		Expect(checkFrom("", http.MethodGet, "/orders/1", "default/rule/0", activeToken)).To(Equal(http.StatusForbidden))
+		Expect(checkFrom("Hash=abc", http.MethodGet, "/orders/1", "default/rule/0", activeToken)).To(Equal(http.StatusForbidden))
+	})
+
+	It("should deny the request if the rule routes to a service in another namespace", func() {
+		serviceName := "orders"
+		otherNamespace := "other"
+		apiRule := &gatewayv1beta1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "rule", Namespace: "default"},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: &gatewayv1beta1.Service{Name: &serviceName, Namespace: &otherNamespace},
+				Rules: []gatewayv1beta1.Rule{
+					getIntrospectionRule("/orders/.*", []string{"GET"}, `{"introspectionUrl": "`+introspectionServer.URL+`"}`),
+				},
+			},
+		}
+		c := getFakeClient(apiRule)
+		authorizer = introspection.NewAuthorizer(c, c, logr.Discard())
+
+		Expect(check(http.MethodGet, "/orders/1", "default/rule/0", activeToken)).To(Equal(http.StatusForbidden))
+	})
+})
@@ -0,0 +1,47 @@
+package introspection
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// Server serves the Authorizer as a Runnable of the controller manager
+type Server struct {
+	Addr       string
+	Authorizer http.Handler
+}
+
+// Start runs the server until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Authorizer,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection returns false, since every replica of the controller manager has to authorize requests
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
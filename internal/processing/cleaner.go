@@ -4,6 +4,7 @@ import (
 	"context"
 
 	rulev1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 
@@ -41,6 +42,19 @@ func DeleteAPIRuleSubresources(k8sClient client.Client, ctx context.Context, api
 		}
 	}
 
+	var efList networkingv1alpha3.EnvoyFilterList
+	err = k8sClient.List(ctx, &efList, client.MatchingLabels(labels))
+	if err != nil {
+		return err
+	}
+	for _, ef := range efList.Items {
+		log.Log.Info("Removing subresource", "EnvoyFilter", ef.Name)
+		err := k8sClient.Delete(ctx, ef)
+		if err != nil {
+			return err
+		}
+	}
+
 	var vsList networkingv1beta1.VirtualServiceList
 	err = k8sClient.List(ctx, &vsList, client.MatchingLabels(labels))
 	if err != nil {
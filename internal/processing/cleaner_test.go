@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	rulev1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 
@@ -84,7 +85,15 @@ var _ = Describe("APIRule subresources deletion", func() {
 			ObjectMeta: notApiRuleObjectMeta,
 		}
 
-		client := testUtils.GetFakeClient(&apiRuleVS, &otherVS, &apiRuleRule, &otherRule, &apiRuleAP, &otherAP, &apiRuleRA, &otherRA)
+		apiRuleEF := networkingv1alpha3.EnvoyFilter{
+			ObjectMeta: apiRuleObjectMeta,
+		}
+
+		otherEF := networkingv1alpha3.EnvoyFilter{
+			ObjectMeta: notApiRuleObjectMeta,
+		}
+
+		client := testUtils.GetFakeClient(&apiRuleVS, &otherVS, &apiRuleRule, &otherRule, &apiRuleAP, &otherAP, &apiRuleRA, &otherRA, &apiRuleEF, &otherEF)
 
 		// when
 		err := processing.DeleteAPIRuleSubresources(client, context.TODO(), *apiRule)
@@ -118,5 +127,12 @@ var _ = Describe("APIRule subresources deletion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(raList.Items).To(HaveLen(1))
 		Expect(raList.Items[0].Name).To(Equal("test-other-apirule"))
+
+		efList := networkingv1alpha3.EnvoyFilterList{}
+		err = client.List(context.TODO(), &efList)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(efList.Items).To(HaveLen(1))
+		Expect(efList.Items[0].Name).To(Equal("test-other-apirule"))
 	})
 })
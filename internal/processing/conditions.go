@@ -0,0 +1,130 @@
+package processing
+
+import (
+	"fmt"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetConditions sets the conditions describing the reconciliation status on the given list of conditions.
+// Conditions of subresources that are not used by the handler (status is nil) are removed.
+func (status ReconciliationStatus) SetConditions(conditions *[]metav1.Condition, generation int64) {
+	meta.SetStatusCondition(conditions, status.validatedCondition(generation))
+	meta.SetStatusCondition(conditions, status.readyCondition(generation))
+
+	subresources := []struct {
+		conditionType string
+		status        *gatewayv1beta1.APIRuleResourceStatus
+	}{
+		{gatewayv1beta1.ConditionVirtualServiceReady, status.VirtualServiceStatus},
+		{gatewayv1beta1.ConditionAccessRuleReady, status.AccessRuleStatus},
+		{gatewayv1beta1.ConditionAuthorizationPolicyReady, status.AuthorizationPolicyStatus},
+		{gatewayv1beta1.ConditionRequestAuthenticationReady, status.RequestAuthenticationStatus},
+		{gatewayv1beta1.ConditionEnvoyFilterReady, status.EnvoyFilterStatus},
+	}
+
+	for _, subresource := range subresources {
+		if subresource.status == nil {
+			meta.RemoveStatusCondition(conditions, subresource.conditionType)
+			continue
+		}
+		meta.SetStatusCondition(conditions, subresourceCondition(subresource.conditionType, subresource.status, generation))
+	}
+}
+
+func (status ReconciliationStatus) validatedCondition(generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               gatewayv1beta1.ConditionValidated,
+		ObservedGeneration: generation,
+	}
+
+	switch {
+	case status.ValidationStatus == nil:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = gatewayv1beta1.ReasonValidationSkipped
+		condition.Message = "Validation of the APIRule was not executed"
+	case status.ValidationStatus.Code == gatewayv1beta1.StatusOK:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = gatewayv1beta1.ReasonValidationSucceeded
+		condition.Message = "APIRule is valid"
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = gatewayv1beta1.ReasonValidationFailed
+		condition.Message = status.ValidationStatus.Description
+	}
+
+	return condition
+}
+
+func (status ReconciliationStatus) readyCondition(generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               gatewayv1beta1.ConditionReady,
+		ObservedGeneration: generation,
+	}
+
+	if status.ApiRuleStatus != nil && status.ApiRuleStatus.Code == gatewayv1beta1.StatusOK && !status.HasError() {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = gatewayv1beta1.ReasonReconciled
+		condition.Message = "APIRule is reconciled"
+		return condition
+	}
+
+	condition.Status = metav1.ConditionFalse
+	if status.ApiRuleStatus != nil {
+		condition.Message = status.ApiRuleStatus.Description
+	}
+
+	switch {
+	case status.ValidationStatus != nil && status.ValidationStatus.Code == gatewayv1beta1.StatusError:
+		condition.Reason = gatewayv1beta1.ReasonValidationFailed
+	case status.hasSubresourceError():
+		condition.Reason = gatewayv1beta1.ReasonSubresourceFailed
+	default:
+		condition.Reason = gatewayv1beta1.ReasonReconciliationFailed
+	}
+
+	return condition
+}
+
+func (status ReconciliationStatus) hasSubresourceError() bool {
+	for _, s := range []*gatewayv1beta1.APIRuleResourceStatus{
+		status.VirtualServiceStatus,
+		status.AccessRuleStatus,
+		status.AuthorizationPolicyStatus,
+		status.RequestAuthenticationStatus,
+		status.EnvoyFilterStatus,
+	} {
+		if s != nil && s.Code == gatewayv1beta1.StatusError {
+			return true
+		}
+	}
+	return false
+}
+
+func subresourceCondition(conditionType string, status *gatewayv1beta1.APIRuleResourceStatus, generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		ObservedGeneration: generation,
+		Message:            status.Description,
+	}
+
+	switch status.Code {
+	case gatewayv1beta1.StatusOK:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = gatewayv1beta1.ReasonReconciled
+	case gatewayv1beta1.StatusSkipped:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = gatewayv1beta1.ReasonReconciliationSkipped
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = gatewayv1beta1.ReasonReconciliationFailed
+	}
+
+	if condition.Message == "" {
+		condition.Message = fmt.Sprintf("Status of the subresource is %s", status.Code)
+	}
+
+	return condition
+}
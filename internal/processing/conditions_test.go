@@ -0,0 +1,136 @@
+package processing
+
+import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("SetConditions", func() {
+
+	const generation int64 = 3
+
+	It("should set all conditions to true when the reconciliation succeeded", func() {
+		// given
+		status := ReconciliationStatus{
+			ApiRuleStatus:               toStatus(gatewayv1beta1.StatusOK, ""),
+			VirtualServiceStatus:        toStatus(gatewayv1beta1.StatusOK, ""),
+			AuthorizationPolicyStatus:   toStatus(gatewayv1beta1.StatusOK, ""),
+			RequestAuthenticationStatus: toStatus(gatewayv1beta1.StatusOK, ""),
+			ValidationStatus:            toStatus(gatewayv1beta1.StatusOK, ""),
+		}
+		var conditions []metav1.Condition
+
+		// when
+		status.SetConditions(&conditions, generation)
+
+		// then
+		Expect(conditions).To(HaveLen(5))
+		for _, c := range conditions {
+			Expect(c.Status).To(Equal(metav1.ConditionTrue))
+			Expect(c.ObservedGeneration).To(Equal(generation))
+		}
+		Expect(meta.FindStatusCondition(conditions, gatewayv1beta1.ConditionReady).Reason).To(Equal(gatewayv1beta1.ReasonReconciled))
+		Expect(meta.FindStatusCondition(conditions, gatewayv1beta1.ConditionValidated).Reason).To(Equal(gatewayv1beta1.ReasonValidationSucceeded))
+		Expect(meta.FindStatusCondition(conditions, gatewayv1beta1.ConditionAccessRuleReady)).To(BeNil())
+	})
+
+	It("should set ready and validated to false when the validation failed", func() {
+		// given
+		validationStatus := toStatus(gatewayv1beta1.StatusError, "Validation error: Attribute \"spec.host\": Host was nil")
+		status := ReconciliationStatus{
+			ApiRuleStatus:        validationStatus,
+			VirtualServiceStatus: toStatus(gatewayv1beta1.StatusSkipped, ""),
+			AccessRuleStatus:     toStatus(gatewayv1beta1.StatusSkipped, ""),
+			ValidationStatus:     validationStatus,
+		}
+		var conditions []metav1.Condition
+
+		// when
+		status.SetConditions(&conditions, generation)
+
+		// then
+		ready := meta.FindStatusCondition(conditions, gatewayv1beta1.ConditionReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(gatewayv1beta1.ReasonValidationFailed))
+		Expect(ready.Message).To(Equal(validationStatus.Description))
+
+		validated := meta.FindStatusCondition(conditions, gatewayv1beta1.ConditionValidated)
+		Expect(validated.Status).To(Equal(metav1.ConditionFalse))
+		Expect(validated.Reason).To(Equal(gatewayv1beta1.ReasonValidationFailed))
+
+		vs := meta.FindStatusCondition(conditions, gatewayv1beta1.ConditionVirtualServiceReady)
+		Expect(vs.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(vs.Reason).To(Equal(gatewayv1beta1.ReasonReconciliationSkipped))
+	})
+
+	It("should set ready to false with subresource reason when a subresource failed", func() {
+		// given
+		status := ReconciliationStatus{
+			ApiRuleStatus:        toStatus(gatewayv1beta1.StatusError, "Error has happened on subresource VirtualService"),
+			VirtualServiceStatus: toStatus(gatewayv1beta1.StatusError, "virtual service error"),
+			AccessRuleStatus:     toStatus(gatewayv1beta1.StatusOK, ""),
+			ValidationStatus:     toStatus(gatewayv1beta1.StatusOK, ""),
+		}
+		var conditions []metav1.Condition
+
+		// when
+		status.SetConditions(&conditions, generation)
+
+		// then
+		ready := meta.FindStatusCondition(conditions, gatewayv1beta1.ConditionReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(gatewayv1beta1.ReasonSubresourceFailed))
+
+		vs := meta.FindStatusCondition(conditions, gatewayv1beta1.ConditionVirtualServiceReady)
+		Expect(vs.Status).To(Equal(metav1.ConditionFalse))
+		Expect(vs.Reason).To(Equal(gatewayv1beta1.ReasonReconciliationFailed))
+		Expect(vs.Message).To(Equal("virtual service error"))
+
+		Expect(meta.IsStatusConditionTrue(conditions, gatewayv1beta1.ConditionAccessRuleReady)).To(BeTrue())
+	})
+
+	It("should set validated to unknown when the validation was not executed", func() {
+		// given
+		status := ReconciliationStatus{
+			ApiRuleStatus:        toStatus(gatewayv1beta1.StatusError, "error during validation"),
+			VirtualServiceStatus: toStatus(gatewayv1beta1.StatusSkipped, ""),
+		}
+		var conditions []metav1.Condition
+
+		// when
+		status.SetConditions(&conditions, generation)
+
+		// then
+		ready := meta.FindStatusCondition(conditions, gatewayv1beta1.ConditionReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(gatewayv1beta1.ReasonReconciliationFailed))
+
+		validated := meta.FindStatusCondition(conditions, gatewayv1beta1.ConditionValidated)
+		Expect(validated.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(validated.Reason).To(Equal(gatewayv1beta1.ReasonValidationSkipped))
+	})
+
+	It("should remove conditions of subresources that are no longer used", func() {
+		// given
+		conditions := []metav1.Condition{{
+			Type:   gatewayv1beta1.ConditionAccessRuleReady,
+			Status: metav1.ConditionTrue,
+			Reason: gatewayv1beta1.ReasonReconciled,
+		}}
+		status := ReconciliationStatus{
+			ApiRuleStatus:        toStatus(gatewayv1beta1.StatusOK, ""),
+			VirtualServiceStatus: toStatus(gatewayv1beta1.StatusOK, ""),
+			ValidationStatus:     toStatus(gatewayv1beta1.StatusOK, ""),
+		}
+
+		// when
+		status.SetConditions(&conditions, generation)
+
+		// then
+		Expect(meta.FindStatusCondition(conditions, gatewayv1beta1.ConditionAccessRuleReady)).To(BeNil())
+		Expect(meta.IsStatusConditionTrue(conditions, gatewayv1beta1.ConditionVirtualServiceReady)).To(BeTrue())
+	})
+})
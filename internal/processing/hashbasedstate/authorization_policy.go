@@ -3,16 +3,19 @@ package hashbasedstate
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/mitchellh/hashstructure/v2"
+	"istio.io/api/security/v1beta1"
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// AddLabelsToAuthorizationPolicy adds hashing labels.
-func AddLabelsToAuthorizationPolicy(ap *securityv1beta1.AuthorizationPolicy, indexInYaml int) error {
+// AddLabelsToAuthorizationPolicy adds hashing labels. The gateways are the gateways the rule of the AuthorizationPolicy
+// is restricted to, since rules with the same path and methods can be restricted to different gateways.
+func AddLabelsToAuthorizationPolicy(ap *securityv1beta1.AuthorizationPolicy, indexInYaml int, gateways ...string) error {
 
-	hash, err := GetAuthorizationPolicyHash(ap)
+	hash, err := GetAuthorizationPolicyHash(ap, gateways...)
 	if err != nil {
 		return err
 	}
@@ -22,7 +25,7 @@ func AddLabelsToAuthorizationPolicy(ap *securityv1beta1.AuthorizationPolicy, ind
 	return nil
 }
 
-func GetAuthorizationPolicyHash(ap *securityv1beta1.AuthorizationPolicy) (string, error) {
+func GetAuthorizationPolicyHash(ap *securityv1beta1.AuthorizationPolicy, gateways ...string) (string, error) {
 	hashService, err := hashstructure.Hash(ap.Spec.Selector, hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true})
 	if err != nil {
 		return "", err
@@ -30,7 +33,30 @@ func GetAuthorizationPolicyHash(ap *securityv1beta1.AuthorizationPolicy) (string
 
 	var hashTo uint64
 	if len(ap.Spec.Rules) > 0 && ap.Spec.Rules[0].To != nil {
-		hash, err := hashstructure.Hash(ap.Spec.Rules[0].To, hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true})
+		// Rules with the same path and methods are distinguished by their header conditions, and the CUSTOM AuthorizationPolicy
+		// of an external authorizer has the same operation as the ALLOW AuthorizationPolicy of the rule. The claim conditions
+		// matching any of several values reflect the match mode of the scopes and audiences. The conditions, the action and
+		// the gateways are only part of the hash if they are defined, so that the hash of the other AuthorizationPolicies
+		// doesn't change.
+		var hashInput interface{} = ap.Spec.Rules[0].To
+		var additionalHashInputs []interface{}
+		if headerConditions := getHeaderConditions(ap.Spec.Rules[0].When); len(headerConditions) > 0 {
+			additionalHashInputs = append(additionalHashInputs, headerConditions)
+		}
+		if anyOfClaimKeys := getAnyOfClaimConditionKeys(ap.Spec.Rules[0].When); len(anyOfClaimKeys) > 0 {
+			additionalHashInputs = append(additionalHashInputs, anyOfClaimKeys)
+		}
+		if ap.Spec.Action != v1beta1.AuthorizationPolicy_ALLOW {
+			additionalHashInputs = append(additionalHashInputs, ap.Spec.Action.String())
+		}
+		if len(gateways) > 0 {
+			additionalHashInputs = append(additionalHashInputs, gateways)
+		}
+		if len(additionalHashInputs) > 0 {
+			hashInput = append([]interface{}{ap.Spec.Rules[0].To}, additionalHashInputs...)
+		}
+
+		hash, err := hashstructure.Hash(hashInput, hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true})
 		if err != nil {
 			return "", err
 		}
@@ -40,6 +66,28 @@ func GetAuthorizationPolicyHash(ap *securityv1beta1.AuthorizationPolicy) (string
 	return fmt.Sprintf("%s.%s.%s", ap.Namespace, strconv.FormatUint(hashService, 32), strconv.FormatUint(hashTo, 32)), nil
 }
 
+func getHeaderConditions(conditions []*v1beta1.Condition) []*v1beta1.Condition {
+	var headerConditions []*v1beta1.Condition
+	for _, condition := range conditions {
+		if condition != nil && strings.HasPrefix(condition.Key, "request.headers[") {
+			headerConditions = append(headerConditions, condition)
+		}
+	}
+	return headerConditions
+}
+
+// getAnyOfClaimConditionKeys returns the keys of the claim conditions matching any of several values. Only the keys are
+// returned, so that a change of the values updates the AuthorizationPolicy instead of replacing it.
+func getAnyOfClaimConditionKeys(conditions []*v1beta1.Condition) []string {
+	var keys []string
+	for _, condition := range conditions {
+		if condition != nil && strings.HasPrefix(condition.Key, "request.auth.claims[") && len(condition.Values) > 1 {
+			keys = append(keys, condition.Key)
+		}
+	}
+	return keys
+}
+
 type AuthorizationPolicyHashable struct {
 	ap *securityv1beta1.AuthorizationPolicy
 }
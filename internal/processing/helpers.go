@@ -1,9 +1,14 @@
 package processing
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"golang.org/x/exp/slices"
 )
 
 var (
@@ -29,6 +34,65 @@ func IsJwtSecured(rule gatewayv1beta1.Rule) bool {
 	return false
 }
 
+// IsOptionalJwtSecured returns true if the JWT of the requests to the rule is only validated if present, so that requests
+// without a JWT are allowed
+func IsOptionalJwtSecured(rule gatewayv1beta1.Rule) bool {
+	for _, strat := range rule.AccessStrategies {
+		if strat.Name == "jwt" && strat.Config != nil {
+			var config gatewayv1beta1.JwtConfig
+			return json.Unmarshal(strat.Config.Raw, &config) == nil && config.Optional
+		}
+	}
+	return false
+}
+
+// GetOptionalJwtClaimHeaders returns the sorted headers the claims of the JWT are forwarded in, if the JWT of the
+// requests to the rule is optional
+func GetOptionalJwtClaimHeaders(rule gatewayv1beta1.Rule) []string {
+	var headers []string
+	for _, strat := range rule.AccessStrategies {
+		if strat.Name != "jwt" || strat.Config == nil {
+			continue
+		}
+		var config gatewayv1beta1.JwtConfig
+		if json.Unmarshal(strat.Config.Raw, &config) != nil || !config.Optional {
+			continue
+		}
+		for _, authentication := range config.Authentications {
+			for _, claimToHeader := range authentication.OutputClaimToHeaders {
+				if !slices.Contains(headers, claimToHeader.Header) {
+					headers = append(headers, claimToHeader.Header)
+				}
+			}
+		}
+	}
+	sort.Strings(headers)
+	return headers
+}
+
+// IsExtAuthSecured returns true if the rule delegates the authorization to an external authorizer
+func IsExtAuthSecured(rule gatewayv1beta1.Rule) bool {
+	for _, strat := range rule.AccessStrategies {
+		if strat.Name == "extAuth" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIntrospectionSecured returns true if the tokens of the requests to the rule are introspected without Oathkeeper, which
+// is the case if an introspection URL is configured for the oauth2_introspection access strategy.
+func IsIntrospectionSecured(rule gatewayv1beta1.Rule) bool {
+	config, err := rule.GetIntrospectionConfig()
+	return err == nil && config.IntrospectionUrl != ""
+}
+
+// IsIstioSecured returns true if the access strategies of the rule are enforced by Istio, so that the requests are routed
+// directly to the service when the Istio jwt handler is used.
+func IsIstioSecured(rule gatewayv1beta1.Rule) bool {
+	return IsJwtSecured(rule) || IsExtAuthSecured(rule) || IsIntrospectionSecured(rule)
+}
+
 func IsSecured(rule gatewayv1beta1.Rule) bool {
 	if len(rule.Mutators) > 0 {
 		return true
@@ -41,23 +105,139 @@ func IsSecured(rule gatewayv1beta1.Rule) bool {
 	return false
 }
 
+// HasIpRestriction returns true if the rule restricts the client IPs allowed to access it
+func HasIpRestriction(rule gatewayv1beta1.Rule) bool {
+	return len(rule.IPAllowList) > 0 || len(rule.IPDenyList) > 0
+}
+
+// HasSourceRestriction returns true if the rule restricts the mesh workloads allowed to access it
+func HasSourceRestriction(rule gatewayv1beta1.Rule) bool {
+	return rule.From != nil
+}
+
+// RequiresAuthorizationPolicies returns true if AuthorizationPolicies are needed to enforce the jwt access strategy or the
+// IP and source restrictions of the rules. As soon as one AuthorizationPolicy applies to a workload, Istio denies the
+// requests not allowed by any policy, so in this case the policies are generated for all rules.
+func RequiresAuthorizationPolicies(rules []gatewayv1beta1.Rule) bool {
+	for _, rule := range rules {
+		if IsJwtSecured(rule) || HasIpRestriction(rule) || HasSourceRestriction(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// RoutesToService returns false if the rule answers the requests itself with a redirect or a direct response
+func RoutesToService(rule gatewayv1beta1.Rule) bool {
+	return rule.Redirect == nil && rule.DirectResponse == nil
+}
+
+// FindPathAndMethodDuplicates returns the indexes of the rules for which include returns true and that have the path and
+// method of a previous of these rules, but different header, query parameter or gateway match conditions. Rules matching
+// exactly the same requests are rejected by the APIRule validation already.
+func FindPathAndMethodDuplicates(rules []gatewayv1beta1.Rule, include func(gatewayv1beta1.Rule) bool) []int {
+	var indexes []int
+	matchKeys := make(map[string]string)
+	for i, rule := range rules {
+		if !include(rule) {
+			continue
+		}
+
+		matchKey := helpers.GetRuleMatchKey(rule)
+		duplicate := false
+		for _, method := range rule.Methods {
+			key := fmt.Sprintf("%s:%s", helpers.GetPathRegex(rule), method)
+			if previousMatchKey, ok := matchKeys[key]; ok && previousMatchKey != matchKey {
+				duplicate = true
+			}
+			matchKeys[key] = matchKey
+		}
+		if duplicate {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// GetForwardedHost returns the value of the x-forwarded-host header set for requests to the given hosts. If there is more than
+// one host, the authority of the request is used, since the called host is known only at request time.
+func GetForwardedHost(hosts []string) string {
+	if len(hosts) == 1 {
+		return hosts[0]
+	}
+	return "%REQ(:authority)%"
+}
+
 func GetOwnerLabels(api *gatewayv1beta1.APIRule) map[string]string {
 	labels := make(map[string]string)
 	labels[OwnerLabel] = fmt.Sprintf("%s.%s", api.ObjectMeta.Name, api.ObjectMeta.Namespace)
 	return labels
 }
 
-func FilterDuplicatePaths(rules []gatewayv1beta1.Rule) []gatewayv1beta1.Rule {
-	duplicates := make(map[string]bool)
-	var filteredRules []gatewayv1beta1.Rule
-	for _, rule := range rules {
-		if _, exists := duplicates[rule.Path]; !exists {
-			duplicates[rule.Path] = true
-			filteredRules = append(filteredRules, rule)
+// HttpRouteRule is a rule for which a HTTP route of the VirtualService is created
+type HttpRouteRule struct {
+	gatewayv1beta1.Rule
+	// Index is the index of the rule in the rules of the APIRule
+	Index int
+	// MatchMethods are the request methods matched by the route. If empty, the route matches all methods.
+	MatchMethods []string
+}
+
+// GetHttpRouteRules returns the rules for which HTTP routes of the VirtualService are created. If several rules match the same
+// requests apart from the method, the routes of all but the first rule match the methods of their rule, and the route of the
+// first rule comes last and matches all remaining methods, for example the CORS preflight requests. Rules with header or
+// query parameter match conditions come first, so that their routes take precedence over the routes of the same path
+// without conditions.
+func GetHttpRouteRules(rules []gatewayv1beta1.Rule) []HttpRouteRule {
+	var matchKeys []string
+	groups := make(map[string][]int)
+	for i, rule := range rules {
+		key := helpers.GetRuleMatchKey(rule)
+		if _, exists := groups[key]; !exists {
+			matchKeys = append(matchKeys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	var routeRules []HttpRouteRule
+	for _, key := range matchKeys {
+		group := groups[key]
+		for _, i := range group[1:] {
+			routeRules = append(routeRules, HttpRouteRule{Rule: rules[i], Index: i, MatchMethods: rules[i].Methods})
 		}
+		routeRules = append(routeRules, HttpRouteRule{Rule: rules[group[0]], Index: group[0]})
 	}
 
-	return filteredRules
+	sort.SliceStable(routeRules, func(i, j int) bool {
+		return HasMatchConditions(routeRules[i].Rule) && !HasMatchConditions(routeRules[j].Rule)
+	})
+
+	return routeRules
+}
+
+// HasMatchConditions returns true if the rule matches the requests by headers, query parameters or gateways in addition to
+// the path
+func HasMatchConditions(rule gatewayv1beta1.Rule) bool {
+	return len(rule.Headers) > 0 || len(rule.QueryParams) > 0 || len(rule.Gateways) > 0
+}
+
+// GetRuleGateways returns the gateways the requests to the rule are received through, which are the gateways the rule is
+// restricted to or all gateways of the APIRule.
+func GetRuleGateways(api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule) []string {
+	if len(rule.Gateways) > 0 {
+		return rule.Gateways
+	}
+	return api.Spec.GetGateways()
+}
+
+// GetGatewayPrincipal returns the principal of the ingress gateway configured in the gateway principals and whether one is
+// configured. Gateways without a namespace are in the namespace of the APIRule.
+func GetGatewayPrincipal(api *gatewayv1beta1.APIRule, gateway string, gatewayPrincipals map[string]string) (string, bool) {
+	if !strings.Contains(gateway, "/") {
+		gateway = fmt.Sprintf("%s/%s", api.Namespace, gateway)
+	}
+	principal, ok := gatewayPrincipals[gateway]
+	return principal, ok
 }
 
 func FilterAccessStrategies(accessStrategies []*gatewayv1beta1.Authenticator, includeAllow bool, includeOryOnly bool, includeJwt bool) []*gatewayv1beta1.Authenticator {
@@ -0,0 +1,124 @@
+package processing
+
+import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("GetHttpRouteRules", func() {
+
+	It("should keep the rules of the same path with different match conditions and order them first", func() {
+		// given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}},
+			{Path: "/orders", Methods: []string{"GET"}, Headers: map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}},
+			{Path: "/items", Methods: []string{"GET"}, QueryParams: map[string]*gatewayv1beta1.StringMatch{"beta": {Exact: "true"}}},
+		}
+
+		// when
+		routeRules := GetHttpRouteRules(rules)
+
+		// then
+		Expect(routeRules).To(HaveLen(3))
+		Expect(routeRules[0].Path).To(Equal("/orders"))
+		Expect(routeRules[0].Headers).To(HaveKey("X-Api-Version"))
+		Expect(routeRules[1].Path).To(Equal("/items"))
+		Expect(routeRules[2].Path).To(Equal("/orders"))
+		Expect(routeRules[2].Headers).To(BeEmpty())
+		for _, routeRule := range routeRules {
+			Expect(routeRule.MatchMethods).To(BeEmpty())
+		}
+	})
+
+	It("should match the methods of the rules sharing the path and route the remaining methods to the first rule", func() {
+		// given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/items", Methods: []string{"GET"}},
+			{Path: "/items", Methods: []string{"POST", "PUT"}},
+			{Path: "/items", Methods: []string{"DELETE"}},
+			{Path: "/orders", Methods: []string{"GET"}},
+		}
+
+		// when
+		routeRules := GetHttpRouteRules(rules)
+
+		// then
+		Expect(routeRules).To(HaveLen(4))
+		Expect(routeRules[0].Methods).To(Equal([]string{"POST", "PUT"}))
+		Expect(routeRules[0].MatchMethods).To(Equal([]string{"POST", "PUT"}))
+		Expect(routeRules[1].Methods).To(Equal([]string{"DELETE"}))
+		Expect(routeRules[1].MatchMethods).To(Equal([]string{"DELETE"}))
+		Expect(routeRules[2].Methods).To(Equal([]string{"GET"}))
+		Expect(routeRules[2].MatchMethods).To(BeEmpty())
+		Expect(routeRules[3].Path).To(Equal("/orders"))
+		Expect(routeRules[3].MatchMethods).To(BeEmpty())
+	})
+})
+
+var _ = Describe("FindPathAndMethodDuplicates", func() {
+
+	all := func(gatewayv1beta1.Rule) bool { return true }
+
+	It("should return the rules with the path and method of a previous rule but different match conditions", func() {
+		// given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}},
+			{Path: "/orders", Methods: []string{"POST"}, Headers: map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}},
+			{Path: "/orders", Methods: []string{"GET", "PUT"}, Headers: map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}},
+		}
+
+		// when
+		indexes := FindPathAndMethodDuplicates(rules, all)
+
+		// then
+		Expect(indexes).To(Equal([]int{2}))
+	})
+
+	It("should not return rules matching exactly the same requests", func() {
+		// given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}},
+			{Path: "/orders", Methods: []string{"GET"}},
+		}
+
+		// when
+		indexes := FindPathAndMethodDuplicates(rules, all)
+
+		// then
+		Expect(indexes).To(BeEmpty())
+	})
+
+	It("should only consider the included rules", func() {
+		// given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}},
+			{Path: "/orders", Methods: []string{"GET"}, Headers: map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}},
+		}
+
+		// when
+		indexes := FindPathAndMethodDuplicates(rules, func(rule gatewayv1beta1.Rule) bool { return len(rule.Headers) > 0 })
+
+		// then
+		Expect(indexes).To(BeEmpty())
+	})
+})
+
+var _ = Describe("IsOptionalJwtSecured", func() {
+
+	getRule := func(handler string, config string) gatewayv1beta1.Rule {
+		return gatewayv1beta1.Rule{
+			AccessStrategies: []*gatewayv1beta1.Authenticator{
+				{Handler: &gatewayv1beta1.Handler{Name: handler, Config: &runtime.RawExtension{Raw: []byte(config)}}},
+			},
+		}
+	}
+
+	It("should return true only for jwt access strategies with optional set", func() {
+		Expect(IsOptionalJwtSecured(getRule("jwt", `{"optional": true}`))).To(BeTrue())
+		Expect(IsOptionalJwtSecured(getRule("jwt", `{"optional": false}`))).To(BeFalse())
+		Expect(IsOptionalJwtSecured(getRule("jwt", `{}`))).To(BeFalse())
+		Expect(IsOptionalJwtSecured(getRule("extAuth", `{"optional": true}`))).To(BeFalse())
+	})
+})
@@ -57,6 +57,32 @@ var _ = Describe("Access Rule Processor", func() {
 		})
 	})
 
+	When("handler is oauth2_introspection with introspection URL", func() {
+		It("should not create access rules", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name:   "oauth2_introspection",
+						Config: &runtime.RawExtension{Raw: []byte(`{"introspectionUrl": "https://auth.example.com/introspect"}`)},
+					},
+				},
+			}
+
+			rule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient()
+			processor := istio.NewAccessRuleProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+	})
+
 	When("handler is jwt", func() {
 		It("should not create access rules", func() {
 			// given
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/processing"
 	"github.com/kyma-project/api-gateway/internal/validation"
 	"golang.org/x/exp/slices"
 )
@@ -16,14 +17,30 @@ func (o *asValidator) Validate(attributePath string, accessStrategies []*gateway
 	if len(accessStrategies) > 1 {
 		allowIndex := slices.IndexFunc(accessStrategies, func(a *gatewayv1beta1.Authenticator) bool { return a.Handler.Name == "allow" })
 		jwtIndex := slices.IndexFunc(accessStrategies, func(a *gatewayv1beta1.Authenticator) bool { return a.Handler.Name == "jwt" })
+		extAuthIndex := slices.IndexFunc(accessStrategies, func(a *gatewayv1beta1.Authenticator) bool { return a.Handler.Name == "extAuth" })
+		introspectionIndex := slices.IndexFunc(accessStrategies, func(a *gatewayv1beta1.Authenticator) bool {
+			return processing.IsIntrospectionSecured(gatewayv1beta1.Rule{AccessStrategies: []*gatewayv1beta1.Authenticator{a}})
+		})
+		// The jwt access strategy can only be combined with a single extAuth access strategy, since both are enforced by Istio
+		isJwtCombinedWithExtAuth := len(accessStrategies) == 2 && jwtIndex > -1 && extAuthIndex > -1
 		if allowIndex > -1 {
 			attrPath := fmt.Sprintf("%s[%d]%s", attributePath+".accessStrategies", allowIndex, ".handler")
 			problems = append(problems, validation.Failure{AttributePath: attrPath, Message: "allow access strategy is not allowed in combination with other access strategies"})
 		}
-		if jwtIndex > -1 {
+		if jwtIndex > -1 && !isJwtCombinedWithExtAuth {
 			attrPath := fmt.Sprintf("%s[%d]%s", attributePath+".accessStrategies", jwtIndex, ".handler")
 			problems = append(problems, validation.Failure{AttributePath: attrPath, Message: "jwt access strategy is not allowed in combination with other access strategies"})
 		}
+		if extAuthIndex > -1 && !isJwtCombinedWithExtAuth {
+			attrPath := fmt.Sprintf("%s[%d]%s", attributePath+".accessStrategies", extAuthIndex, ".handler")
+			problems = append(problems, validation.Failure{AttributePath: attrPath, Message: "extAuth access strategy is only allowed in combination with the jwt access strategy"})
+		}
+		// Natively introspected tokens are enforced by a CUSTOM AuthorizationPolicy, which can't be combined with the
+		// access rules of other Oathkeeper access strategies
+		if introspectionIndex > -1 {
+			attrPath := fmt.Sprintf("%s[%d]%s", attributePath+".accessStrategies", introspectionIndex, ".handler")
+			problems = append(problems, validation.Failure{AttributePath: attrPath, Message: "oauth2_introspection access strategy with introspectionUrl is not allowed in combination with other access strategies"})
+		}
 	}
 
 	return problems
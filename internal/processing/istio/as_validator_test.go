@@ -4,6 +4,7 @@ import (
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var _ = Describe("AccessStrategies Istio Validator", func() {
@@ -125,4 +126,72 @@ var _ = Describe("AccessStrategies Istio Validator", func() {
 		Expect(problems[0].AttributePath).To(Equal("some.attribute.accessStrategies[0].handler"))
 		Expect(problems[0].Message).To(Equal("jwt access strategy is not allowed in combination with other access strategies"))
 	})
+
+	It("Should succeed with jwt and extAuth handlers on same path", func() {
+		//given
+		strategies := []*gatewayv1beta1.Authenticator{
+			{
+				Handler: &gatewayv1beta1.Handler{
+					Name: "extAuth",
+				},
+			},
+			{
+				Handler: &gatewayv1beta1.Handler{
+					Name: "jwt",
+				},
+			},
+		}
+		//when
+		problems := (&asValidator{}).Validate("some.attribute", strategies)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail with noop and extAuth handlers on same path", func() {
+		//given
+		strategies := []*gatewayv1beta1.Authenticator{
+			{
+				Handler: &gatewayv1beta1.Handler{
+					Name: "noop",
+				},
+			},
+			{
+				Handler: &gatewayv1beta1.Handler{
+					Name: "extAuth",
+				},
+			},
+		}
+		//when
+		problems := (&asValidator{}).Validate("some.attribute", strategies)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.accessStrategies[1].handler"))
+		Expect(problems[0].Message).To(Equal("extAuth access strategy is only allowed in combination with the jwt access strategy"))
+	})
+
+	It("Should fail with oauth2_introspection with introspection URL and noop handlers on same path", func() {
+		//given
+		strategies := []*gatewayv1beta1.Authenticator{
+			{
+				Handler: &gatewayv1beta1.Handler{
+					Name:   "oauth2_introspection",
+					Config: &runtime.RawExtension{Raw: []byte(`{"introspectionUrl": "https://auth.example.com/introspect"}`)},
+				},
+			},
+			{
+				Handler: &gatewayv1beta1.Handler{
+					Name: "noop",
+				},
+			},
+		}
+		//when
+		problems := (&asValidator{}).Validate("some.attribute", strategies)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.accessStrategies[0].handler"))
+		Expect(problems[0].Message).To(Equal("oauth2_introspection access strategy with introspectionUrl is not allowed in combination with other access strategies"))
+	})
 })
@@ -3,6 +3,7 @@ package istio
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/go-logr/logr"
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
@@ -11,103 +12,265 @@ import (
 	"github.com/kyma-project/api-gateway/internal/processing"
 	"github.com/kyma-project/api-gateway/internal/processing/hashbasedstate"
 	"github.com/kyma-project/api-gateway/internal/processing/processors"
+	"golang.org/x/exp/slices"
 	"istio.io/api/security/v1beta1"
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	audienceKey string = "request.auth.claims[aud]"
+	audienceKey     string = "request.auth.claims[aud]"
+	headerKeyFormat string = "request.headers[%s]"
 )
 
 var (
-	defaultScopeKeys = []string{"request.auth.claims[scp]", "request.auth.claims[scope]", "request.auth.claims[scopes]"}
+	// defaultScopeClaimKeys are the claims containing the scopes of the JWT, if no other claims are configured
+	defaultScopeClaimKeys = []string{"scp", "scope", "scopes"}
 )
 
 // NewAuthorizationPolicyProcessor returns a AuthorizationPolicyProcessor with the desired state handling specific for the Istio handler.
 func NewAuthorizationPolicyProcessor(config processing.ReconciliationConfig, log *logr.Logger) processors.AuthorizationPolicyProcessor {
 	return processors.AuthorizationPolicyProcessor{
 		Creator: authorizationPolicyCreator{
-			additionalLabels: config.AdditionalLabels,
+			additionalLabels:      config.AdditionalLabels,
+			introspectionProvider: config.IntrospectionProvider,
+			scopeClaimKeys:        config.ScopeClaimKeys,
+			gatewayPrincipals:     config.GatewayPrincipals,
 		},
 		Log: log,
 	}
 }
 
 type authorizationPolicyCreator struct {
-	additionalLabels map[string]string
+	additionalLabels      map[string]string
+	introspectionProvider string
+	scopeClaimKeys        []string
+	gatewayPrincipals     map[string]string
 }
 
 // Create returns the JwtAuthorization Policy using the configuration of the APIRule.
 func (r authorizationPolicyCreator) Create(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule) (hashbasedstate.Desired, error) {
 	state := hashbasedstate.NewDesired()
-	hasJwtRule := processing.HasJwtRule(api)
-	if hasJwtRule {
-		for _, rule := range api.Spec.Rules {
-			aps, err := generateAuthorizationPolicies(ctx, client, api, rule, r.additionalLabels)
+	requiresAuthorizationPolicies := processing.RequiresAuthorizationPolicies(api.Spec.Rules)
+	for _, rule := range api.Spec.Rules {
+		// Redirects and direct responses are returned by the Ingress Gateway and never reach a workload
+		if !processing.RoutesToService(rule) {
+			continue
+		}
+
+		var aps []*securityv1beta1.AuthorizationPolicy
+		if requiresAuthorizationPolicies {
+			allowAps, err := generateAuthorizationPolicies(ctx, client, api, rule, r.getGatewaySource(api, rule), r.scopeClaimKeys, r.additionalLabels)
+			if err != nil {
+				return state, err
+			}
+			aps = append(aps, allowAps.Items...)
+		}
+
+		// The CUSTOM AuthorizationPolicies don't deny the requests not matching them, so they are independent of the
+		// ALLOW AuthorizationPolicies
+		provider, err := r.getCustomProvider(rule)
+		if err != nil {
+			return state, err
+		}
+		if provider != "" {
+			customAps, err := generateCustomAuthorizationPolicies(ctx, client, api, rule, provider, r.additionalLabels)
 			if err != nil {
 				return state, err
 			}
+			aps = append(aps, customAps...)
+		}
 
-			for _, ap := range aps.Items {
-				h := hashbasedstate.NewAuthorizationPolicy(ap)
-				err := state.Add(&h)
+		for _, ap := range aps {
+			h := hashbasedstate.NewAuthorizationPolicy(ap)
+			err := state.Add(&h)
 
-				if err != nil {
-					return state, err
-				}
+			if err != nil {
+				return state, err
 			}
 		}
 	}
 	return state, nil
 }
 
-func generateAuthorizationPolicies(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, additionalLabels map[string]string) (*securityv1beta1.AuthorizationPolicyList, error) {
+// generateAuthorizationPolicies returns the AuthorizationPolicies of the rule for every workload the traffic of the rule is routed to.
+func generateAuthorizationPolicies(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, gateways gatewaySource, scopeClaimKeys []string, additionalLabels map[string]string) (*securityv1beta1.AuthorizationPolicyList, error) {
 	authorizationPolicyList := securityv1beta1.AuthorizationPolicyList{}
+
+	for _, service := range helpers.GetRuleServices(api, &rule) {
+		aps, err := generateServiceAuthorizationPolicies(ctx, client, api, rule, service, gateways, scopeClaimKeys, additionalLabels)
+		if err != nil {
+			return &authorizationPolicyList, err
+		}
+		authorizationPolicyList.Items = append(authorizationPolicyList.Items, aps...)
+	}
+
+	return &authorizationPolicyList, nil
+}
+
+func generateServiceAuthorizationPolicies(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, service *gatewayv1beta1.WeightedService, gateways gatewaySource, scopeClaimKeys []string, additionalLabels map[string]string) ([]*securityv1beta1.AuthorizationPolicy, error) {
+	var authorizationPolicies []*securityv1beta1.AuthorizationPolicy
 	ruleAuthorizations := rule.GetJwtIstioAuthorizations()
 
 	if len(ruleAuthorizations) == 0 {
-		ap, err := generateAuthorizationPolicy(ctx, client, api, rule, additionalLabels, &gatewayv1beta1.JwtAuthorization{})
+		ap, err := generateAuthorizationPolicy(ctx, client, api, rule, service, gateways, additionalLabels, &gatewayv1beta1.JwtAuthorization{})
 		if err != nil {
-			return &authorizationPolicyList, err
+			return authorizationPolicies, err
 		}
 
 		// If there is no other authorization we can safely assume that the index of this authorization in the array
 		// in the yaml is 0.
-		err = hashbasedstate.AddLabelsToAuthorizationPolicy(ap, 0)
+		err = hashbasedstate.AddLabelsToAuthorizationPolicy(ap, 0, rule.Gateways...)
 		if err != nil {
-			return &authorizationPolicyList, err
+			return authorizationPolicies, err
 		}
 
-		authorizationPolicyList.Items = append(authorizationPolicyList.Items, ap)
+		authorizationPolicies = append(authorizationPolicies, ap)
 	} else {
 		for indexInYaml, authorization := range ruleAuthorizations {
-			ap, err := generateAuthorizationPolicy(ctx, client, api, rule, additionalLabels, authorization)
+			// The scope claim keys of the authorization take precedence over the keys configured globally
+			if len(authorization.ScopeClaimKeys) == 0 {
+				authorization.ScopeClaimKeys = scopeClaimKeys
+			}
+
+			ap, err := generateAuthorizationPolicy(ctx, client, api, rule, service, gateways, additionalLabels, authorization)
 			if err != nil {
-				return &authorizationPolicyList, err
+				return authorizationPolicies, err
 			}
 
-			err = hashbasedstate.AddLabelsToAuthorizationPolicy(ap, indexInYaml)
+			err = hashbasedstate.AddLabelsToAuthorizationPolicy(ap, indexInYaml, rule.Gateways...)
 			if err != nil {
-				return &authorizationPolicyList, err
+				return authorizationPolicies, err
 			}
 
-			authorizationPolicyList.Items = append(authorizationPolicyList.Items, ap)
+			authorizationPolicies = append(authorizationPolicies, ap)
 		}
 	}
 
-	return &authorizationPolicyList, nil
+	return authorizationPolicies, nil
 }
 
-func generateAuthorizationPolicy(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, additionalLabels map[string]string, authorization *gatewayv1beta1.JwtAuthorization) (*securityv1beta1.AuthorizationPolicy, error) {
-	namePrefix := fmt.Sprintf("%s-", api.ObjectMeta.Name)
-	namespace := helpers.FindServiceNamespace(api, &rule)
-
-	spec, err := generateAuthorizationPolicySpec(ctx, client, api, rule, authorization)
+func generateAuthorizationPolicy(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, service *gatewayv1beta1.WeightedService, gateways gatewaySource, additionalLabels map[string]string, authorization *gatewayv1beta1.JwtAuthorization) (*securityv1beta1.AuthorizationPolicy, error) {
+	spec, err := generateAuthorizationPolicySpec(ctx, client, api, rule, &service.Service, gateways, authorization)
 	if err != nil {
 		return nil, err
 	}
 
+	return buildAuthorizationPolicy(api, service, spec, additionalLabels), nil
+}
+
+// gatewaySource are the principals of the workloads the requests through the gateways of a rule come from
+type gatewaySource struct {
+	principals    []string
+	notPrincipals []string
+	// ingressPrincipals are the principals of the ingress gateways of the rule, without the mesh workloads
+	ingressPrincipals []string
+}
+
+// getGatewaySource returns the principals of the gateways the requests to the rule are received through. Requests
+// through the mesh gateway don't pass an ingress gateway, so all workloads in the service mesh are allowed in this case.
+// If the rule is restricted to gateways, the ingress gateways the rule is not restricted to are excluded from the mesh
+// workloads, so that the requests through them are not allowed.
+// Gateways without a configured principal are expected to run as the Istio Ingress Gateway. The validation rejects rules
+// restricted to such gateways, so that a restricted rule never allows the requests through another gateway.
+func (r authorizationPolicyCreator) getGatewaySource(api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule) gatewaySource {
+	var principals []string
+	for _, gateway := range processing.GetRuleGateways(api, rule) {
+		if gateway == gatewayv1beta1.MeshGateway {
+			continue
+		}
+		principal := r.getGatewayPrincipal(api, gateway)
+		if !slices.Contains(principals, principal) {
+			principals = append(principals, principal)
+		}
+	}
+
+	if !slices.Contains(processing.GetRuleGateways(api, rule), gatewayv1beta1.MeshGateway) {
+		return gatewaySource{principals: principals, ingressPrincipals: principals}
+	}
+	if len(rule.Gateways) == 0 {
+		return gatewaySource{principals: []string{"*"}, ingressPrincipals: principals}
+	}
+
+	ingressPrincipals := []string{builders.IstioIngressGatewayPrincipal}
+	for _, gateway := range api.Spec.GetGateways() {
+		if gateway != gatewayv1beta1.MeshGateway {
+			ingressPrincipals = append(ingressPrincipals, r.getGatewayPrincipal(api, gateway))
+		}
+	}
+	for _, principal := range r.gatewayPrincipals {
+		ingressPrincipals = append(ingressPrincipals, principal)
+	}
+
+	var notPrincipals []string
+	for _, principal := range ingressPrincipals {
+		if !slices.Contains(principals, principal) && !slices.Contains(notPrincipals, principal) {
+			notPrincipals = append(notPrincipals, principal)
+		}
+	}
+	sort.Strings(notPrincipals)
+
+	return gatewaySource{principals: []string{"*"}, notPrincipals: notPrincipals, ingressPrincipals: principals}
+}
+
+// getGatewayPrincipal returns the configured principal of the gateway, or the principal of the Istio Ingress Gateway if
+// none is configured
+func (r authorizationPolicyCreator) getGatewayPrincipal(api *gatewayv1beta1.APIRule, gateway string) string {
+	if principal, ok := processing.GetGatewayPrincipal(api, gateway, r.gatewayPrincipals); ok {
+		return principal
+	}
+	return builders.IstioIngressGatewayPrincipal
+}
+
+// getCustomProvider returns the extension provider the authorization of the requests to the rule is delegated to. It is
+// empty if the rule has neither the extAuth access strategy nor natively introspected tokens.
+func (r authorizationPolicyCreator) getCustomProvider(rule gatewayv1beta1.Rule) (string, error) {
+	switch {
+	case processing.IsExtAuthSecured(rule):
+		config, err := rule.GetExtAuthConfig()
+		return config.Provider, err
+	case processing.IsIntrospectionSecured(rule):
+		return r.introspectionProvider, nil
+	}
+	return "", nil
+}
+
+// generateCustomAuthorizationPolicies returns the AuthorizationPolicies with the CUSTOM action delegating the authorization
+// of the requests to the rule to the extension provider, for every workload the traffic of the rule is routed to.
+func generateCustomAuthorizationPolicies(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, provider string, additionalLabels map[string]string) ([]*securityv1beta1.AuthorizationPolicy, error) {
+	var authorizationPolicies []*securityv1beta1.AuthorizationPolicy
+
+	for _, service := range helpers.GetRuleServices(api, &rule) {
+		labelSelector, err := helpers.GetLabelSelectorFromService(ctx, client, &service.Service, api, &rule)
+		if err != nil {
+			return authorizationPolicies, err
+		}
+
+		ruleBuilder := withHeaderConditions(withTo(builders.NewRuleBuilder(), rule), rule)
+		spec := builders.NewAuthorizationPolicySpecBuilder().
+			WithSelector(labelSelector).
+			WithAction(v1beta1.AuthorizationPolicy_CUSTOM).
+			WithProvider(provider).
+			WithRule(ruleBuilder.Get()).
+			Get()
+
+		ap := buildAuthorizationPolicy(api, service, spec, additionalLabels)
+		// There is only one access strategy delegating the authorization per rule
+		err = hashbasedstate.AddLabelsToAuthorizationPolicy(ap, 0)
+		if err != nil {
+			return authorizationPolicies, err
+		}
+
+		authorizationPolicies = append(authorizationPolicies, ap)
+	}
+
+	return authorizationPolicies, nil
+}
+
+func buildAuthorizationPolicy(api *gatewayv1beta1.APIRule, service *gatewayv1beta1.WeightedService, spec *v1beta1.AuthorizationPolicy, additionalLabels map[string]string) *securityv1beta1.AuthorizationPolicy {
+	namePrefix := fmt.Sprintf("%s-", api.ObjectMeta.Name)
+	namespace := *service.Namespace
+
 	apBuilder := builders.NewAuthorizationPolicyBuilder().
 		WithGenerateName(namePrefix).
 		WithNamespace(namespace).
@@ -118,17 +281,10 @@ func generateAuthorizationPolicy(ctx context.Context, client client.Client, api
 		apBuilder.WithLabel(k, v)
 	}
 
-	return apBuilder.Get(), nil
+	return apBuilder.Get()
 }
 
-func generateAuthorizationPolicySpec(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, authorization *gatewayv1beta1.JwtAuthorization) (*v1beta1.AuthorizationPolicy, error) {
-	var service *gatewayv1beta1.Service
-	if rule.Service != nil {
-		service = rule.Service
-	} else {
-		service = api.Spec.Service
-	}
-
+func generateAuthorizationPolicySpec(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, service *gatewayv1beta1.Service, gateways gatewaySource, authorization *gatewayv1beta1.JwtAuthorization) (*v1beta1.AuthorizationPolicy, error) {
 	labelSelector, err := helpers.GetLabelSelectorFromService(ctx, client, service, api, &rule)
 	if err != nil {
 		return nil, err
@@ -136,28 +292,20 @@ func generateAuthorizationPolicySpec(ctx context.Context, client client.Client,
 
 	authorizationPolicySpecBuilder := builders.NewAuthorizationPolicySpecBuilder().WithSelector(labelSelector)
 
-	// If RequiredScopes are configured, we need to generate a separate Rule for each scopeKey in defaultScopeKeys
+	// If RequiredScopes are configured, we need to generate a separate Rule for each scope claim, since the scopes can be
+	// in any of them
 	if len(authorization.RequiredScopes) > 0 {
-		for _, scopeKey := range defaultScopeKeys {
-			ruleBuilder := baseRuleBuilder(rule)
-			for _, scope := range authorization.RequiredScopes {
-				ruleBuilder.WithWhenCondition(
-					builders.NewConditionBuilder().WithKey(scopeKey).WithValues([]string{scope}).Get())
-			}
-
-			for _, aud := range authorization.Audiences {
-				ruleBuilder.WithWhenCondition(
-					builders.NewConditionBuilder().WithKey(audienceKey).WithValues([]string{aud}).Get())
-			}
-
+		for _, scopeKey := range getScopeConditionKeys(authorization.ScopeClaimKeys) {
+			ruleBuilder := baseRuleBuilder(rule, gateways)
+			withMatchConditions(ruleBuilder, scopeKey, authorization.RequiredScopes, authorization.RequiredScopesMatch)
+			withMatchConditions(ruleBuilder, audienceKey, authorization.Audiences, authorization.AudiencesMatch)
+			withClaimConditions(ruleBuilder, authorization.Claims)
 			authorizationPolicySpecBuilder.WithRule(ruleBuilder.Get())
 		}
 	} else { // Only one AP rule should be generated for other scenarios
-		ruleBuilder := baseRuleBuilder(rule)
-		for _, aud := range authorization.Audiences {
-			ruleBuilder.WithWhenCondition(
-				builders.NewConditionBuilder().WithKey(audienceKey).WithValues([]string{aud}).Get())
-		}
+		ruleBuilder := baseRuleBuilder(rule, gateways)
+		withMatchConditions(ruleBuilder, audienceKey, authorization.Audiences, authorization.AudiencesMatch)
+		withClaimConditions(ruleBuilder, authorization.Claims)
 		authorizationPolicySpecBuilder.WithRule(ruleBuilder.Get())
 	}
 
@@ -165,36 +313,138 @@ func generateAuthorizationPolicySpec(ctx context.Context, client client.Client,
 }
 
 func withTo(b *builders.RuleBuilder, rule gatewayv1beta1.Rule) *builders.RuleBuilder {
-	// APIRule and VirtualService supported a regex match. Since AuthorizationPolicy supports only prefix, suffix and wildcard
-	// and we have clusters with "/.*" in APIRule, we need special handling of this case.
-	if rule.Path == "/.*" {
-		return b.WithTo(
-			builders.NewToBuilder().
-				WithOperation(builders.NewOperationBuilder().
-					WithMethods(rule.Methods).WithPath("/*").Get()).
-				Get())
+	// AuthorizationPolicy supports only exact, prefix, suffix and template matches, so regex paths that can't be expressed
+	// with them are rejected by the validation.
+	paths, ok := helpers.GetAuthorizationPolicyPaths(rule)
+	if !ok {
+		paths = []string{rule.Path}
+	}
+
+	operationBuilder := builders.NewOperationBuilder().WithMethods(rule.Methods)
+	for _, path := range paths {
+		operationBuilder.WithPath(path)
 	}
+
 	return b.WithTo(
 		builders.NewToBuilder().
-			WithOperation(builders.NewOperationBuilder().
-				WithMethods(rule.Methods).WithPath(rule.Path).Get()).
+			WithOperation(operationBuilder.Get()).
 			Get())
 }
 
-func withFrom(b *builders.RuleBuilder, rule gatewayv1beta1.Rule) *builders.RuleBuilder {
-	if processing.IsJwtSecured(rule) {
-		return b.WithFrom(builders.NewFromBuilder().WithForcedJWTAuthorization(rule.AccessStrategies).Get())
-	} else if processing.IsSecured(rule) {
-		return b.WithFrom(builders.NewFromBuilder().WithOathkeeperProxySource().Get())
+func withFrom(b *builders.RuleBuilder, rule gatewayv1beta1.Rule, gateways gatewaySource) *builders.RuleBuilder {
+	fromBuilder := builders.NewFromBuilder()
+	// Requests to rules with optional JWT don't need a request principal, since the RequestAuthentication still rejects
+	// invalid tokens
+	if processing.IsJwtSecured(rule) && !processing.IsOptionalJwtSecured(rule) {
+		fromBuilder.WithForcedJWTAuthorization(rule.AccessStrategies)
+		// A rule restricted to gateways must not allow the requests with a JWT received through the other gateways
+		if len(rule.Gateways) > 0 {
+			fromBuilder.WithGatewaySource(gateways.principals).WithoutPrincipals(gateways.notPrincipals)
+		}
+	} else if processing.IsSecured(rule) && !processing.IsIstioSecured(rule) {
+		fromBuilder.WithOathkeeperProxySource()
+	} else {
+		fromBuilder.WithGatewaySource(gateways.principals).WithoutPrincipals(gateways.notPrincipals)
+	}
+
+	// The IP blocks are set on the same source as the principals, so a request must fulfill both. The client IP is taken
+	// from the X-Forwarded-For header, which only the ingress gateways set, so the requests must come from them. The
+	// validation rejects IP lists for rules that are not exposed through an ingress gateway.
+	if processing.HasIpRestriction(rule) {
+		fromBuilder.WithIpBlocks(rule.IPAllowList, rule.IPDenyList).WithGatewaySource(gateways.ingressPrincipals)
+	}
+
+	// The workload source is set on the same source as the request principals, so a request from the workload must still
+	// have a JWT if the rule requires one
+	if processing.HasSourceRestriction(rule) {
+		fromBuilder.WithWorkloadSource(rule.From.Principals, rule.From.Namespaces)
+	}
+
+	return b.WithFrom(fromBuilder.Get())
+}
+
+// withHeaderConditions adds a When condition for every header match of the rule, so that the policy applies only to the
+// requests routed to the rule. Regex header matches can't be expressed as a condition and are rejected by the validation.
+func withHeaderConditions(b *builders.RuleBuilder, rule gatewayv1beta1.Rule) *builders.RuleBuilder {
+	var names []string
+	for name := range rule.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		match := rule.Headers[name]
+		var value string
+		switch {
+		case match == nil:
+			continue
+		case match.Exact != "":
+			value = match.Exact
+		case match.Prefix != "":
+			value = match.Prefix + "*"
+		default:
+			continue
+		}
+		b.WithWhenCondition(builders.NewConditionBuilder().WithKey(fmt.Sprintf(headerKeyFormat, name)).WithValues([]string{value}).Get())
+	}
+
+	return b
+}
+
+// withMatchConditions adds the When conditions requiring the claim to contain the values. For the any match mode a single
+// condition matching any of the values is added, otherwise there is a condition for every value, so that all must match.
+func withMatchConditions(b *builders.RuleBuilder, key string, values []string, mode gatewayv1beta1.MatchMode) *builders.RuleBuilder {
+	if len(values) == 0 {
+		return b
+	}
+
+	if mode == gatewayv1beta1.MatchModeAny {
+		return b.WithWhenCondition(builders.NewConditionBuilder().WithKey(key).WithValues(values).Get())
+	}
+
+	for _, value := range values {
+		b.WithWhenCondition(builders.NewConditionBuilder().WithKey(key).WithValues([]string{value}).Get())
+	}
+	return b
+}
+
+// getScopeConditionKeys returns the distinct AuthorizationPolicy condition keys of the scope claims, or of the default scope
+// claims if none are configured
+func getScopeConditionKeys(scopeClaimKeys []string) []string {
+	if len(scopeClaimKeys) == 0 {
+		scopeClaimKeys = defaultScopeClaimKeys
+	}
+
+	var keys []string
+	for _, claim := range scopeClaimKeys {
+		key := helpers.GetClaimConditionKey(claim)
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// withClaimConditions adds a When condition for every claim condition of the authorization. The condition is fulfilled
+// if the claim contains one of the values and none of the not values.
+func withClaimConditions(b *builders.RuleBuilder, claims []*gatewayv1beta1.JwtClaim) *builders.RuleBuilder {
+	for _, claim := range claims {
+		b.WithWhenCondition(builders.NewConditionBuilder().
+			WithKey(helpers.GetClaimConditionKey(claim.Key)).
+			WithValues(claim.Values).
+			WithNotValues(claim.NotValues).
+			Get())
 	}
-	return b.WithFrom(builders.NewFromBuilder().WithIngressGatewaySource().Get())
+
+	return b
 }
 
-// baseRuleBuilder returns RuleBuilder with To and From
-func baseRuleBuilder(rule gatewayv1beta1.Rule) *builders.RuleBuilder {
+// baseRuleBuilder returns RuleBuilder with To, From and the When conditions of the header matches
+func baseRuleBuilder(rule gatewayv1beta1.Rule, gateways gatewaySource) *builders.RuleBuilder {
 	builder := builders.NewRuleBuilder()
 	builder = withTo(builder, rule)
-	builder = withFrom(builder, rule)
+	builder = withFrom(builder, rule, gateways)
+	builder = withHeaderConditions(builder, rule)
 
 	return builder
 }
@@ -17,6 +17,7 @@ import (
 	"golang.org/x/exp/slices"
 	"istio.io/api/security/v1beta1"
 	typev1beta1 "istio.io/api/type/v1beta1"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -195,6 +196,34 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		Expect(ap.Spec.Rules[0].To[0].Operation.Paths).To(ContainElement("/*"))
 	})
 
+	It("should not produce an AP for a Rule returning a redirect", func() {
+		// given
+		jwt := createIstioJwtAccessStrategy()
+		allow := &gatewayv1beta1.Authenticator{Handler: &gatewayv1beta1.Handler{Name: "allow"}}
+		service := &gatewayv1beta1.Service{
+			Name: &ServiceName,
+			Port: &ServicePort,
+		}
+
+		ruleJwt := GetRuleWithServiceFor(HeadersApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{jwt}, service)
+		ruleRedirect := GetRuleFor("/old/.*", ApiMethods, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{allow})
+		ruleRedirect.Redirect = &gatewayv1beta1.Redirect{URI: "/new"}
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{ruleJwt, ruleRedirect})
+		svc := GetService(*apiRule.Spec.Service.Name)
+		client := GetFakeClient(svc)
+		processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+
+		ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+		Expect(ap.Spec.Rules[0].To[0].Operation.Paths).To(ContainElement(HeadersApiPath))
+	})
+
 	It("should produce two APs for a rule with one issuer and two paths", func() {
 		// given
 		jwt := createIstioJwtAccessStrategy()
@@ -366,6 +395,35 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		Expect(ap.Spec.Selector.MatchLabels[TestSelectorKey]).To(Equal(ServiceName))
 	})
 
+	It("should produce one AP for every weighted service of a Rule", func() {
+		// given
+		jwt := createIstioJwtAccessStrategy()
+		ruleJwt := GetRuleFor(HeadersApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{jwt})
+		canaryServiceName := "canary-service"
+		canaryNamespace := "canary-namespace"
+		ruleJwt.Services = []*gatewayv1beta1.WeightedService{
+			{Service: gatewayv1beta1.Service{Name: &ServiceName, Port: &ServicePort}, Weight: 90},
+			{Service: gatewayv1beta1.Service{Name: &canaryServiceName, Namespace: &canaryNamespace, Port: &ServicePort}, Weight: 10},
+		}
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{ruleJwt})
+		client := GetFakeClient(GetService(ServiceName), GetService(canaryServiceName, canaryNamespace))
+		processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(2))
+
+		selectors := map[string]string{}
+		for _, r := range result {
+			ap := r.Obj.(*securityv1beta1.AuthorizationPolicy)
+			selectors[ap.Spec.Selector.MatchLabels[TestSelectorKey]] = ap.Namespace
+		}
+		Expect(selectors).To(Equal(map[string]string{ServiceName: ApiNamespace, canaryServiceName: canaryNamespace}))
+	})
+
 	It("should produce AP with service from Rule, when service is configured on Rule and ApiRule level", func() {
 		// given
 		jwt := createIstioJwtAccessStrategy()
@@ -1355,6 +1413,773 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		})
 	})
 
+	When("Rules match on headers", func() {
+		It("should create APs with header conditions and different hashes for the same path", func() {
+			// given
+			methods := []string{"GET"}
+			path := "/orders"
+			serviceName := "test-service"
+
+			exactRule := getRuleForApTest(methods, path, serviceName)
+			exactRule.Headers = map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}
+			prefixRule := getRuleForApTest(methods, path, serviceName)
+			prefixRule.Headers = map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Prefix: "3"}}
+			rules := []gatewayv1beta1.Rule{exactRule, prefixRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient(GetService(serviceName))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(2))
+
+			ap1 := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			ap2 := result[1].Obj.(*securityv1beta1.AuthorizationPolicy)
+
+			var values []string
+			for _, ap := range []*securityv1beta1.AuthorizationPolicy{ap1, ap2} {
+				Expect(ap.Spec.Rules[0].When).To(HaveLen(1))
+				Expect(ap.Spec.Rules[0].When[0].Key).To(Equal("request.headers[X-Api-Version]"))
+				values = append(values, ap.Spec.Rules[0].When[0].Values...)
+			}
+			Expect(values).To(ConsistOf("2", "3*"))
+			Expect(ap1.Labels["gateway.kyma-project.io/hash"]).NotTo(Equal(ap2.Labels["gateway.kyma-project.io/hash"]))
+		})
+	})
+
+	When("Rules use path types", func() {
+		It("should create APs with paths matching the same requests as the rules", func() {
+			// given
+			methods := []string{"GET"}
+			serviceName := "test-service"
+
+			exactRule := getRuleForApTest(methods, "/users/{id}/orders", serviceName)
+			exactRule.PathType = gatewayv1beta1.PathTypeExact
+			prefixRule := getRuleForApTest(methods, "/items/{id}", serviceName)
+			prefixRule.PathType = gatewayv1beta1.PathTypePrefix
+			regexRule := getRuleForApTest(methods, "/static/.*", serviceName)
+			rules := []gatewayv1beta1.Rule{exactRule, prefixRule, regexRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient(GetService(serviceName))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(3))
+
+			var paths [][]string
+			for _, r := range result {
+				ap := r.Obj.(*securityv1beta1.AuthorizationPolicy)
+				paths = append(paths, ap.Spec.Rules[0].To[0].Operation.Paths)
+			}
+			Expect(paths).To(ConsistOf(
+				[]string{"/users/{*}/orders"},
+				[]string{"/items/{*}", "/items/{*}/{**}"},
+				[]string{"/static/*"},
+			))
+		})
+	})
+
+	When("Rules restrict the client IPs", func() {
+		It("should create APs for all rules and combine the IP blocks with the ingress gateway source", func() {
+			// given
+			allow := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+			port := uint32(8080)
+			serviceName := "test-service"
+			service := &gatewayv1beta1.Service{Name: &serviceName, Port: &port}
+
+			adminRule := GetRuleWithServiceFor("/admin", []string{"GET"}, []*gatewayv1beta1.Mutator{}, allow, service)
+			adminRule.IPAllowList = []string{"10.0.0.0/8"}
+			adminRule.IPDenyList = []string{"10.1.0.0/16"}
+			publicRule := GetRuleWithServiceFor("/public", []string{"GET"}, []*gatewayv1beta1.Mutator{}, allow, service)
+			rules := []gatewayv1beta1.Rule{adminRule, publicRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient(GetService(serviceName))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(2))
+
+			for _, r := range result {
+				ap := r.Obj.(*securityv1beta1.AuthorizationPolicy)
+				source := ap.Spec.Rules[0].From[0].Source
+				Expect(source.Principals).To(ConsistOf("cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"))
+
+				if ap.Spec.Rules[0].To[0].Operation.Paths[0] == "/admin" {
+					Expect(source.RemoteIpBlocks).To(ConsistOf("10.0.0.0/8"))
+					Expect(source.NotRemoteIpBlocks).To(ConsistOf("10.1.0.0/16"))
+				} else {
+					Expect(source.RemoteIpBlocks).To(BeEmpty())
+					Expect(source.NotRemoteIpBlocks).To(BeEmpty())
+				}
+			}
+		})
+
+		It("should combine the IP blocks with the JWT request principals", func() {
+			// given
+			rule := getRuleForApTest([]string{"GET"}, "/admin", "test-service")
+			rule.IPAllowList = []string{"10.0.0.0/8", "192.168.0.1"}
+			rules := []gatewayv1beta1.Rule{rule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient(GetService("test-service"))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			Expect(ap.Spec.Rules[0].From).To(HaveLen(1))
+			Expect(ap.Spec.Rules[0].From[0].Source.RequestPrincipals).To(ConsistOf(JwtIssuer + "/*"))
+			Expect(ap.Spec.Rules[0].From[0].Source.Principals).To(ConsistOf("cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"))
+			Expect(ap.Spec.Rules[0].From[0].Source.RemoteIpBlocks).To(ConsistOf("10.0.0.0/8", "192.168.0.1"))
+		})
+
+		It("should combine the IP blocks with the ingress gateway source if the service is exposed to the mesh", func() {
+			// given
+			allow := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+			port := uint32(8080)
+			serviceName := "test-service"
+			service := &gatewayv1beta1.Service{Name: &serviceName, Port: &port}
+
+			rule := GetRuleWithServiceFor("/admin", []string{"GET"}, []*gatewayv1beta1.Mutator{}, allow, service)
+			rule.IPAllowList = []string{"10.0.0.0/8"}
+			rules := []gatewayv1beta1.Rule{rule}
+
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.Gateways = []string{gatewayv1beta1.MeshGateway}
+			client := GetFakeClient(GetService(serviceName))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			Expect(ap.Spec.Rules[0].From).To(HaveLen(1))
+			Expect(ap.Spec.Rules[0].From[0].Source.Principals).To(ConsistOf("cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"))
+			Expect(ap.Spec.Rules[0].From[0].Source.RemoteIpBlocks).To(ConsistOf("10.0.0.0/8"))
+		})
+	})
+
+	When("Rules restrict the mesh workloads", func() {
+		It("should create APs for all rules and replace the ingress gateway source with the workload source", func() {
+			// given
+			allow := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+			port := uint32(8080)
+			serviceName := "test-service"
+			service := &gatewayv1beta1.Service{Name: &serviceName, Port: &port}
+
+			internalRule := GetRuleWithServiceFor("/internal", []string{"GET"}, []*gatewayv1beta1.Mutator{}, allow, service)
+			internalRule.From = &gatewayv1beta1.RuleSource{
+				Principals: []string{"cluster.local/ns/orders/sa/checkout"},
+				Namespaces: []string{"orders"},
+			}
+			publicRule := GetRuleWithServiceFor("/public", []string{"GET"}, []*gatewayv1beta1.Mutator{}, allow, service)
+			rules := []gatewayv1beta1.Rule{internalRule, publicRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient(GetService(serviceName))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(2))
+
+			for _, r := range result {
+				ap := r.Obj.(*securityv1beta1.AuthorizationPolicy)
+				source := ap.Spec.Rules[0].From[0].Source
+
+				if ap.Spec.Rules[0].To[0].Operation.Paths[0] == "/internal" {
+					Expect(source.Principals).To(ConsistOf("cluster.local/ns/orders/sa/checkout"))
+					Expect(source.Namespaces).To(ConsistOf("orders"))
+				} else {
+					Expect(source.Principals).To(ConsistOf("cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"))
+					Expect(source.Namespaces).To(BeEmpty())
+				}
+			}
+		})
+
+		It("should combine the workload source with the JWT request principals", func() {
+			// given
+			rule := getRuleForApTest([]string{"GET"}, "/orders", "test-service")
+			rule.From = &gatewayv1beta1.RuleSource{Namespaces: []string{"orders"}}
+			rules := []gatewayv1beta1.Rule{rule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient(GetService("test-service"))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			Expect(ap.Spec.Rules[0].From).To(HaveLen(1))
+			Expect(ap.Spec.Rules[0].From[0].Source.RequestPrincipals).To(ConsistOf(JwtIssuer + "/*"))
+			Expect(ap.Spec.Rules[0].From[0].Source.Principals).To(BeEmpty())
+			Expect(ap.Spec.Rules[0].From[0].Source.Namespaces).To(ConsistOf("orders"))
+		})
+	})
+
+	When("the service is exposed to the mesh", func() {
+		It("should allow the requests from all mesh workloads to rules without JWT", func() {
+			// given
+			allow := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+			port := uint32(8080)
+			serviceName := "test-service"
+			service := &gatewayv1beta1.Service{Name: &serviceName, Port: &port}
+
+			allowRule := GetRuleWithServiceFor("/public", []string{"GET"}, []*gatewayv1beta1.Mutator{}, allow, service)
+			jwtRule := getRuleForApTest([]string{"GET"}, "/orders", serviceName)
+			rules := []gatewayv1beta1.Rule{allowRule, jwtRule}
+
+			meshHost := "orders"
+			meshGateway := gatewayv1beta1.MeshGateway
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.Host = &meshHost
+			apiRule.Spec.Gateway = &meshGateway
+			client := GetFakeClient(GetService(serviceName))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(2))
+
+			for _, r := range result {
+				ap := r.Obj.(*securityv1beta1.AuthorizationPolicy)
+				source := ap.Spec.Rules[0].From[0].Source
+
+				if ap.Spec.Rules[0].To[0].Operation.Paths[0] == "/public" {
+					Expect(source.Principals).To(ConsistOf("*"))
+					Expect(source.RequestPrincipals).To(BeEmpty())
+				} else {
+					Expect(source.Principals).To(BeEmpty())
+					Expect(source.RequestPrincipals).To(ConsistOf(JwtIssuer + "/*"))
+				}
+			}
+		})
+
+		It("should allow the requests from all mesh workloads if mesh hosts are defined", func() {
+			// given
+			allow := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+			port := uint32(8080)
+			serviceName := "test-service"
+			service := &gatewayv1beta1.Service{Name: &serviceName, Port: &port}
+
+			allowRule := GetRuleWithServiceFor("/public", []string{"GET"}, []*gatewayv1beta1.Mutator{}, allow, service)
+			jwtRule := getRuleForApTest([]string{"GET"}, "/orders", serviceName)
+			rules := []gatewayv1beta1.Rule{allowRule, jwtRule}
+
+			meshHost := gatewayv1beta1.Host("orders.internal")
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.MeshHosts = []*gatewayv1beta1.Host{&meshHost}
+			client := GetFakeClient(GetService(serviceName))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(2))
+
+			for _, r := range result {
+				ap := r.Obj.(*securityv1beta1.AuthorizationPolicy)
+				if ap.Spec.Rules[0].To[0].Operation.Paths[0] == "/public" {
+					Expect(ap.Spec.Rules[0].From[0].Source.Principals).To(ConsistOf("*"))
+				}
+			}
+		})
+
+		It("should exclude the ingress gateways from the mesh workloads for rules restricted to the mesh gateway", func() {
+			// given
+			allow := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+			port := uint32(8080)
+			serviceName := "test-service"
+			service := &gatewayv1beta1.Service{Name: &serviceName, Port: &port}
+
+			jwtRule := getRuleForApTest([]string{"GET"}, "/orders", serviceName)
+			meshRule := GetRuleWithServiceFor("/orders", []string{"GET"}, []*gatewayv1beta1.Mutator{}, allow, service)
+			meshRule.Gateways = []string{gatewayv1beta1.MeshGateway}
+			rules := []gatewayv1beta1.Rule{jwtRule, meshRule}
+
+			meshHost := gatewayv1beta1.Host("orders.internal")
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.Gateways = []string{"partners/partner-gateway"}
+			apiRule.Spec.MeshHosts = []*gatewayv1beta1.Host{&meshHost}
+			client := GetFakeClient(GetService(serviceName))
+			config := GetTestConfig()
+			config.GatewayPrincipals = map[string]string{"partners/partner-gateway": "cluster.local/ns/partners/sa/partner-gateway"}
+			processor := istio.NewAuthorizationPolicyProcessor(config, &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(2))
+
+			for _, r := range result {
+				source := r.Obj.(*securityv1beta1.AuthorizationPolicy).Spec.Rules[0].From[0].Source
+				if len(source.RequestPrincipals) == 0 {
+					Expect(source.Principals).To(ConsistOf("*"))
+					Expect(source.NotPrincipals).To(ConsistOf("cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account", "cluster.local/ns/partners/sa/partner-gateway"))
+				} else {
+					Expect(source.NotPrincipals).To(BeEmpty())
+				}
+			}
+		})
+	})
+
+	When("Rule rewrites the authority", func() {
+		It("should keep the path of the route matched by the AP paths", func() {
+			// given
+			rule := getRuleForApTest([]string{"GET"}, "/orders", "test-service")
+			rule.Rewrite = &gatewayv1beta1.Rewrite{Authority: "orders.internal"}
+			rules := []gatewayv1beta1.Rule{rule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient(GetService("test-service"))
+
+			// when
+			vsResult, err := istio.NewVirtualServiceProcessor(GetTestConfig()).EvaluateReconciliation(context.TODO(), client, apiRule)
+			Expect(err).To(BeNil())
+			apResult, err := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger).EvaluateReconciliation(context.TODO(), client, apiRule)
+			Expect(err).To(BeNil())
+
+			// then
+			Expect(vsResult).To(HaveLen(1))
+			route := vsResult[0].Obj.(*networkingv1beta1.VirtualService).Spec.Http[0]
+			Expect(route.Rewrite.Authority).To(Equal("orders.internal"))
+			Expect(route.Rewrite.Uri).To(BeEmpty())
+			Expect(route.Rewrite.UriRegexRewrite).To(BeNil())
+			Expect(route.Match[0].Uri.GetRegex()).To(Equal("/orders"))
+
+			Expect(apResult).To(HaveLen(1))
+			ap := apResult[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			Expect(ap.Spec.Rules[0].To[0].Operation.Paths).To(ConsistOf("/orders"))
+		})
+	})
+
+	When("Rules are restricted to gateways", func() {
+		It("should allow the requests through the gateways of the rule with the configured principals", func() {
+			// given
+			allow := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+			port := uint32(8080)
+			serviceName := "test-service"
+			service := &gatewayv1beta1.Service{Name: &serviceName, Port: &port}
+
+			partnerRule := GetRuleWithServiceFor("/partners", []string{"GET"}, []*gatewayv1beta1.Mutator{}, allow, service)
+			partnerRule.Gateways = []string{"partners/partner-gateway"}
+			partnerRule.IPAllowList = []string{"10.1.0.0/16"}
+			publicRule := GetRuleWithServiceFor("/public", []string{"GET"}, []*gatewayv1beta1.Mutator{}, allow, service)
+			jwtRule := getRuleForApTest([]string{"GET"}, "/orders", serviceName)
+			jwtRule.Gateways = []string{ApiGateway}
+			rules := []gatewayv1beta1.Rule{partnerRule, publicRule, jwtRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.Gateways = []string{"partners/partner-gateway"}
+			client := GetFakeClient(GetService(serviceName))
+			config := GetTestConfig()
+			config.GatewayPrincipals = map[string]string{"partners/partner-gateway": "cluster.local/ns/partners/sa/partner-gateway"}
+			processor := istio.NewAuthorizationPolicyProcessor(config, &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(3))
+
+			for _, r := range result {
+				ap := r.Obj.(*securityv1beta1.AuthorizationPolicy)
+				source := ap.Spec.Rules[0].From[0].Source
+
+				switch ap.Spec.Rules[0].To[0].Operation.Paths[0] {
+				case "/partners":
+					Expect(source.Principals).To(ConsistOf("cluster.local/ns/partners/sa/partner-gateway"))
+					Expect(source.RemoteIpBlocks).To(ConsistOf("10.1.0.0/16"))
+				case "/public":
+					Expect(source.Principals).To(ConsistOf("cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account", "cluster.local/ns/partners/sa/partner-gateway"))
+				default:
+					Expect(source.RequestPrincipals).To(ConsistOf(JwtIssuer + "/*"))
+					Expect(source.Principals).To(ConsistOf("cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"))
+				}
+			}
+		})
+	})
+
+	When("Rules use the extAuth access strategy", func() {
+		extAuth := &gatewayv1beta1.Authenticator{
+			Handler: &gatewayv1beta1.Handler{
+				Name:   "extAuth",
+				Config: &runtime.RawExtension{Raw: []byte(`{"provider": "opa"}`)},
+			},
+		}
+
+		It("should create an AP with CUSTOM action delegating to the provider", func() {
+			// given
+			port := uint32(8080)
+			serviceName := "test-service"
+			service := &gatewayv1beta1.Service{Name: &serviceName, Port: &port}
+			rule := GetRuleWithServiceFor("/admin", []string{"GET", "POST"}, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{extAuth}, service)
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient(GetService(serviceName))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			Expect(ap.Spec.Action).To(Equal(v1beta1.AuthorizationPolicy_CUSTOM))
+			Expect(ap.Spec.GetProvider().Name).To(Equal("opa"))
+			Expect(ap.Spec.Selector.MatchLabels).To(HaveKeyWithValue("app", serviceName))
+			Expect(ap.Spec.Rules).To(HaveLen(1))
+			Expect(ap.Spec.Rules[0].From).To(BeEmpty())
+			Expect(ap.Spec.Rules[0].To[0].Operation.Paths).To(ConsistOf("/admin"))
+			Expect(ap.Spec.Rules[0].To[0].Operation.Methods).To(ConsistOf("GET", "POST"))
+		})
+
+		It("should create an AP with CUSTOM action next to the AP of the jwt access strategy", func() {
+			// given
+			rule := getRuleForApTest([]string{"GET"}, "/admin", "test-service")
+			rule.AccessStrategies = append(rule.AccessStrategies, extAuth)
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient(GetService("test-service"))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(2))
+
+			allowAp := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			customAp := result[1].Obj.(*securityv1beta1.AuthorizationPolicy)
+			if allowAp.Spec.Action == v1beta1.AuthorizationPolicy_CUSTOM {
+				allowAp, customAp = customAp, allowAp
+			}
+
+			Expect(allowAp.Spec.Action).To(Equal(v1beta1.AuthorizationPolicy_ALLOW))
+			Expect(allowAp.Spec.Rules[0].From[0].Source.RequestPrincipals).To(ConsistOf(JwtIssuer + "/*"))
+			Expect(customAp.Spec.Action).To(Equal(v1beta1.AuthorizationPolicy_CUSTOM))
+			Expect(customAp.Spec.GetProvider().Name).To(Equal("opa"))
+			Expect(customAp.Spec.Rules[0].To).To(Equal(allowAp.Spec.Rules[0].To))
+			Expect(customAp.Labels["gateway.kyma-project.io/hash"]).NotTo(Equal(allowAp.Labels["gateway.kyma-project.io/hash"]))
+		})
+
+		It("should allow the requests to the extAuth rule from the ingress gateway in APIRules with jwt access strategy", func() {
+			// given
+			port := uint32(8080)
+			serviceName := "test-service"
+			service := &gatewayv1beta1.Service{Name: &serviceName, Port: &port}
+			jwtRule := getRuleForApTest([]string{"GET"}, "/orders", serviceName)
+			extAuthRule := GetRuleWithServiceFor("/admin", []string{"GET"}, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{extAuth}, service)
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{jwtRule, extAuthRule})
+			client := GetFakeClient(GetService(serviceName))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(3))
+
+			var extAuthAllowAp *securityv1beta1.AuthorizationPolicy
+			for _, r := range result {
+				ap := r.Obj.(*securityv1beta1.AuthorizationPolicy)
+				if ap.Spec.Action == v1beta1.AuthorizationPolicy_ALLOW && ap.Spec.Rules[0].To[0].Operation.Paths[0] == "/admin" {
+					extAuthAllowAp = ap
+				}
+			}
+			Expect(extAuthAllowAp).NotTo(BeNil())
+			Expect(extAuthAllowAp.Spec.Rules[0].From[0].Source.Principals).To(ConsistOf("cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"))
+		})
+	})
+
+	When("Rules introspect the tokens without Oathkeeper", func() {
+		introspection := &gatewayv1beta1.Authenticator{
+			Handler: &gatewayv1beta1.Handler{
+				Name:   "oauth2_introspection",
+				Config: &runtime.RawExtension{Raw: []byte(`{"introspectionUrl": "https://auth.example.com/introspect"}`)},
+			},
+		}
+
+		It("should create an AP with CUSTOM action delegating to the introspection provider", func() {
+			// given
+			port := uint32(8080)
+			serviceName := "test-service"
+			service := &gatewayv1beta1.Service{Name: &serviceName, Port: &port}
+			rule := GetRuleWithServiceFor("/orders", []string{"GET"}, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{introspection}, service)
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient(GetService(serviceName))
+			config := GetTestConfig()
+			config.IntrospectionProvider = "api-gateway-introspection"
+			processor := istio.NewAuthorizationPolicyProcessor(config, &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			Expect(ap.Spec.Action).To(Equal(v1beta1.AuthorizationPolicy_CUSTOM))
+			Expect(ap.Spec.GetProvider().Name).To(Equal("api-gateway-introspection"))
+			Expect(ap.Spec.Rules[0].To[0].Operation.Paths).To(ConsistOf("/orders"))
+			Expect(ap.Spec.Rules[0].To[0].Operation.Methods).To(ConsistOf("GET"))
+		})
+
+		It("should not create an AP if the tokens are introspected by Oathkeeper", func() {
+			// given
+			oathkeeperIntrospection := &gatewayv1beta1.Authenticator{
+				Handler: &gatewayv1beta1.Handler{
+					Name:   "oauth2_introspection",
+					Config: &runtime.RawExtension{Raw: []byte(`{"required_scope": ["read"]}`)},
+				},
+			}
+			port := uint32(8080)
+			serviceName := "test-service"
+			service := &gatewayv1beta1.Service{Name: &serviceName, Port: &port}
+			rule := GetRuleWithServiceFor("/orders", []string{"GET"}, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{oathkeeperIntrospection}, service)
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient(GetService(serviceName))
+			config := GetTestConfig()
+			config.IntrospectionProvider = "api-gateway-introspection"
+			processor := istio.NewAuthorizationPolicyProcessor(config, &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+	})
+
+	When("Rule has authorizations with claim conditions", func() {
+		It("should create AP with when conditions for the claims", func() {
+			// given
+			serviceName := "test-service"
+			authorization := `{"requiredScopes": ["read"], "claims": [{"key": "groups", "values": ["admins"]}, {"key": "realm_access.roles", "values": ["editor"], "notValues": ["guest"]}]}`
+			jwtConfigJSON := fmt.Sprintf(`{"authentications": [{"issuer": "%s", "jwksUri": "%s"}], "authorizations": [%s]}`, JwtIssuer, JwksUri, authorization)
+			rule := getRuleForApTest([]string{"GET"}, "/", serviceName)
+			rule.AccessStrategies[0].Config = &runtime.RawExtension{Raw: []byte(jwtConfigJSON)}
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient(GetService(serviceName))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			// One AP rule is created for each scope key, and every rule has the claim conditions
+			Expect(ap.Spec.Rules).To(HaveLen(3))
+			for _, apRule := range ap.Spec.Rules {
+				Expect(apRule.When).To(ContainElements(
+					&v1beta1.Condition{Key: "request.auth.claims[groups]", Values: []string{"admins"}},
+					&v1beta1.Condition{Key: "request.auth.claims[realm_access][roles]", Values: []string{"editor"}, NotValues: []string{"guest"}},
+				))
+			}
+		})
+	})
+
+	When("Scope claim keys are configured", func() {
+		getScopeConditionKeys := func(ap *securityv1beta1.AuthorizationPolicy) []string {
+			var keys []string
+			for _, apRule := range ap.Spec.Rules {
+				Expect(apRule.When).To(HaveLen(1))
+				keys = append(keys, apRule.When[0].Key)
+			}
+			return keys
+		}
+
+		evaluate := func(authorization string, scopeClaimKeys []string) *securityv1beta1.AuthorizationPolicy {
+			serviceName := "test-service"
+			jwtConfigJSON := fmt.Sprintf(`{"authentications": [{"issuer": "%s", "jwksUri": "%s"}], "authorizations": [%s]}`, JwtIssuer, JwksUri, authorization)
+			rule := getRuleForApTest([]string{"GET"}, "/", serviceName)
+			rule.AccessStrategies[0].Config = &runtime.RawExtension{Raw: []byte(jwtConfigJSON)}
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient(GetService(serviceName))
+			config := GetTestConfig()
+			config.ScopeClaimKeys = scopeClaimKeys
+			processor := istio.NewAuthorizationPolicyProcessor(config, &testLogger)
+
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+			return result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+		}
+
+		It("should create an AP rule for each default scope claim if no keys are configured", func() {
+			ap := evaluate(`{"requiredScopes": ["read"]}`, nil)
+
+			Expect(getScopeConditionKeys(ap)).To(Equal([]string{"request.auth.claims[scp]", "request.auth.claims[scope]", "request.auth.claims[scopes]"}))
+		})
+
+		It("should create an AP rule only for each configured scope claim", func() {
+			ap := evaluate(`{"requiredScopes": ["read"]}`, []string{"permissions", "roles", "permissions"})
+
+			Expect(getScopeConditionKeys(ap)).To(Equal([]string{"request.auth.claims[permissions]", "request.auth.claims[roles]"}))
+		})
+
+		It("should prefer the scope claims of the authorization over the configured scope claims", func() {
+			ap := evaluate(`{"requiredScopes": ["read"], "scopeClaimKeys": ["realm_access.roles"]}`, []string{"permissions", "roles"})
+
+			Expect(getScopeConditionKeys(ap)).To(Equal([]string{"request.auth.claims[realm_access][roles]"}))
+		})
+	})
+
+	When("JWT is optional", func() {
+		It("should create an AP not requiring a request principal", func() {
+			// given
+			serviceName := "test-service"
+			jwtConfigJSON := fmt.Sprintf(`{"authentications": [{"issuer": "%s", "jwksUri": "%s"}], "optional": true}`, JwtIssuer, JwksUri)
+			rule := getRuleForApTest([]string{"GET"}, "/", serviceName)
+			rule.AccessStrategies[0].Config = &runtime.RawExtension{Raw: []byte(jwtConfigJSON)}
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient(GetService(serviceName))
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+			ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			Expect(ap.Spec.Rules).To(HaveLen(1))
+			Expect(ap.Spec.Rules[0].From).To(HaveLen(1))
+			Expect(ap.Spec.Rules[0].From[0].Source.RequestPrincipals).To(BeEmpty())
+			Expect(ap.Spec.Rules[0].From[0].Source.Principals).To(ConsistOf("cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"))
+			Expect(ap.Spec.Rules[0].When).To(BeEmpty())
+		})
+	})
+
+	When("Authorization defines match modes", func() {
+		getApiRule := func(authorization string) *gatewayv1beta1.APIRule {
+			jwtConfigJSON := fmt.Sprintf(`{"authentications": [{"issuer": "%s", "jwksUri": "%s"}], "authorizations": [%s]}`, JwtIssuer, JwksUri, authorization)
+			rule := getRuleForApTest([]string{"GET"}, "/", "test-service")
+			rule.AccessStrategies[0].Config = &runtime.RawExtension{Raw: []byte(jwtConfigJSON)}
+			return GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+		}
+
+		It("should create a single condition matching any of the scopes and audiences", func() {
+			// given
+			apiRule := getApiRule(`{"requiredScopes": ["read", "admin"], "requiredScopesMatch": "any", "audiences": ["a", "b"], "audiencesMatch": "any"}`)
+			client := GetFakeClient(GetService("test-service"))
+			config := GetTestConfig()
+			config.ScopeClaimKeys = []string{"scope"}
+			processor := istio.NewAuthorizationPolicyProcessor(config, &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			Expect(ap.Spec.Rules).To(HaveLen(1))
+			Expect(ap.Spec.Rules[0].When).To(HaveLen(2))
+			Expect(ap.Spec.Rules[0].When[0].Key).To(Equal("request.auth.claims[scope]"))
+			Expect(ap.Spec.Rules[0].When[0].Values).To(Equal([]string{"read", "admin"}))
+			Expect(ap.Spec.Rules[0].When[1].Key).To(Equal("request.auth.claims[aud]"))
+			Expect(ap.Spec.Rules[0].When[1].Values).To(Equal([]string{"a", "b"}))
+		})
+
+		It("should create a condition for each scope if all scopes must match", func() {
+			// given
+			apiRule := getApiRule(`{"requiredScopes": ["read", "admin"], "requiredScopesMatch": "all"}`)
+			client := GetFakeClient(GetService("test-service"))
+			config := GetTestConfig()
+			config.ScopeClaimKeys = []string{"scope"}
+			processor := istio.NewAuthorizationPolicyProcessor(config, &testLogger)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			Expect(ap.Spec.Rules[0].When).To(HaveLen(2))
+			Expect(ap.Spec.Rules[0].When[0].Values).To(Equal([]string{"read"}))
+			Expect(ap.Spec.Rules[0].When[1].Values).To(Equal([]string{"admin"}))
+		})
+
+		It("should replace the AP if the match mode changes", func() {
+			// given: Cluster state with the AP of the all match mode
+			processor := istio.NewAuthorizationPolicyProcessor(GetTestConfig(), &testLogger)
+			allApiRule := getApiRule(`{"requiredScopes": ["read", "admin"]}`)
+			created, err := processor.EvaluateReconciliation(context.TODO(), GetFakeClient(GetService("test-service")), allApiRule)
+			Expect(err).To(BeNil())
+			Expect(created).To(HaveLen(1))
+			existingAp := created[0].Obj.(*securityv1beta1.AuthorizationPolicy)
+			existingAp.Name = "existing-ap"
+			client := GetFakeClient(GetService("test-service"), existingAp)
+
+			// given: New resources
+			anyApiRule := getApiRule(`{"requiredScopes": ["read", "admin"], "requiredScopesMatch": "any"}`)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, anyApiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(2))
+
+			var actions []string
+			for _, change := range result {
+				actions = append(actions, change.Action.String())
+			}
+			Expect(actions).To(ConsistOf("create", "delete"))
+		})
+	})
+
 	When("Service has custom selector spec", func() {
 		It("should create AP with selector from service", func() {
 			// given: New resources
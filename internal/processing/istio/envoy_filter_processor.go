@@ -0,0 +1,29 @@
+package istio
+
+import (
+	"context"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/processing/processors"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewEnvoyFilterProcessor returns an EnvoyFilterProcessor with the desired state handling specific for the Istio handler.
+func NewEnvoyFilterProcessor(config processing.ReconciliationConfig) processors.EnvoyFilterProcessor {
+	return processors.EnvoyFilterProcessor{
+		Creator: envoyFilterCreator{
+			additionalLabels: config.AdditionalLabels,
+		},
+	}
+}
+
+type envoyFilterCreator struct {
+	additionalLabels map[string]string
+}
+
+// Create returns the EnvoyFilters enforcing the rate limits configured in the APIRule.
+func (r envoyFilterCreator) Create(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule) (map[string]*networkingv1alpha3.EnvoyFilter, error) {
+	return processors.GenerateRateLimitEnvoyFilters(ctx, client, api, r.additionalLabels)
+}
@@ -0,0 +1,61 @@
+package istio
+
+import (
+	"encoding/json"
+	"fmt"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+type extAuthValidator struct{}
+
+// Validate validates that the configuration of the extAuth access strategy references an extension provider
+func (v *extAuthValidator) Validate(attributePath string, handler *gatewayv1beta1.Handler) []validation.Failure {
+	if !validation.ConfigNotEmpty(handler.Config) {
+		return []validation.Failure{{AttributePath: attributePath + ".config", Message: "supplied config cannot be empty"}}
+	}
+
+	var config gatewayv1beta1.ExtAuthConfig
+	if err := json.Unmarshal(handler.Config.Raw, &config); err != nil {
+		return []validation.Failure{{AttributePath: attributePath + ".config", Message: "Can't read json: " + err.Error()}}
+	}
+
+	if config.Provider == "" {
+		return []validation.Failure{{AttributePath: attributePath + ".config.provider", Message: "provider cannot be empty"}}
+	}
+
+	return nil
+}
+
+// validateExtAuthProviders rejects rules referencing different extension providers, since Istio supports only one
+// provider for the CUSTOM AuthorizationPolicies of a workload. Rules with natively introspected tokens use the
+// introspection provider.
+func validateExtAuthProviders(attrPath string, rules []gatewayv1beta1.Rule, introspectionProvider string) []validation.Failure {
+	var failures []validation.Failure
+
+	provider := ""
+	for i, rule := range rules {
+		var ruleProvider string
+		if processing.IsIntrospectionSecured(rule) {
+			ruleProvider = introspectionProvider
+		} else if config, err := rule.GetExtAuthConfig(); err == nil {
+			ruleProvider = config.Provider
+		}
+		if ruleProvider == "" {
+			continue
+		}
+
+		if provider == "" {
+			provider = ruleProvider
+		} else if ruleProvider != provider {
+			failures = append(failures, validation.Failure{
+				AttributePath: fmt.Sprintf("%s[%d].accessStrategies", attrPath, i),
+				Message:       fmt.Sprintf("All extAuth access strategies must use the same provider %s", provider),
+			})
+		}
+	}
+
+	return failures
+}
@@ -0,0 +1,80 @@
+package istio
+
+import (
+	"github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("ExtAuth validator", func() {
+
+	extAuth := func(config string) *v1beta1.Authenticator {
+		return &v1beta1.Authenticator{Handler: &v1beta1.Handler{Name: "extAuth", Config: &runtime.RawExtension{Raw: []byte(config)}}}
+	}
+
+	It("Should succeed for config with provider", func() {
+		//when
+		problems := (&extAuthValidator{}).Validate("some.attribute", extAuth(`{"provider": "opa"}`).Handler)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for empty config", func() {
+		//when
+		problems := (&extAuthValidator{}).Validate("some.attribute", &v1beta1.Handler{Name: "extAuth"})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config"))
+		Expect(problems[0].Message).To(Equal("supplied config cannot be empty"))
+	})
+
+	It("Should fail for config without provider", func() {
+		//when
+		problems := (&extAuthValidator{}).Validate("some.attribute", extAuth(`{"provider": ""}`).Handler)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config.provider"))
+		Expect(problems[0].Message).To(Equal("provider cannot be empty"))
+	})
+
+	It("Should fail for rules with different providers", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/admin", Methods: []string{"GET"}, AccessStrategies: []*v1beta1.Authenticator{extAuth(`{"provider": "opa"}`)}},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: []*v1beta1.Authenticator{extAuth(`{"provider": "opa"}`)}},
+			{Path: "/items", Methods: []string{"GET"}, AccessStrategies: []*v1beta1.Authenticator{extAuth(`{"provider": "oauth2-proxy"}`)}},
+		}
+
+		//when
+		problems := validateExtAuthProviders(".spec.rules", rules, "")
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[2].accessStrategies"))
+		Expect(problems[0].Message).To(Equal("All extAuth access strategies must use the same provider opa"))
+	})
+
+	It("Should fail for rules with a provider different from the introspection provider", func() {
+		//given
+		introspection := &v1beta1.Authenticator{Handler: &v1beta1.Handler{
+			Name:   "oauth2_introspection",
+			Config: &runtime.RawExtension{Raw: []byte(`{"introspectionUrl": "https://auth.example.com/introspect"}`)},
+		}}
+		rules := []v1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: []*v1beta1.Authenticator{introspection}},
+			{Path: "/admin", Methods: []string{"GET"}, AccessStrategies: []*v1beta1.Authenticator{extAuth(`{"provider": "opa"}`)}},
+		}
+
+		//when
+		problems := validateExtAuthProviders(".spec.rules", rules, "api-gateway-introspection")
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].accessStrategies"))
+		Expect(problems[0].Message).To(Equal("All extAuth access strategies must use the same provider api-gateway-introspection"))
+	})
+})
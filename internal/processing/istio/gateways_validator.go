@@ -0,0 +1,71 @@
+package istio
+
+import (
+	"fmt"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/builders"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/validation"
+	"golang.org/x/exp/slices"
+)
+
+type gatewaysValidator struct {
+	gatewayPrincipals map[string]string
+}
+
+// Validate validates that the AuthorizationPolicies of rules restricted to gateways can distinguish the requests through
+// these gateways from the requests through the other gateways of the APIRule. This requires a configured principal for
+// every gateway of the rule, that is not the principal of another gateway of the APIRule. Rules with IP lists must be
+// exposed through an ingress gateway, since the client IP is only set by the ingress gateways.
+func (v *gatewaysValidator) Validate(attributePath string, api *gatewayv1beta1.APIRule) []validation.Failure {
+	var failures []validation.Failure
+
+	for i, rule := range api.Spec.Rules {
+		if processing.HasIpRestriction(rule) && !slices.ContainsFunc(processing.GetRuleGateways(api, rule), isIngressGateway) {
+			failures = append(failures, validation.Failure{
+				AttributePath: fmt.Sprintf("%s[%d]", attributePath, i),
+				Message:       "IP allow and deny lists are not supported for rules exposed only to the mesh",
+			})
+		}
+
+		for j, gateway := range rule.Gateways {
+			if gateway == gatewayv1beta1.MeshGateway {
+				continue
+			}
+			gatewayAttributePath := fmt.Sprintf("%s[%d].gateways[%d]", attributePath, i, j)
+
+			principal, ok := processing.GetGatewayPrincipal(api, gateway, v.gatewayPrincipals)
+			if !ok {
+				failures = append(failures, validation.Failure{
+					AttributePath: gatewayAttributePath,
+					Message:       fmt.Sprintf("No principal is configured for gateway %s, so the rule can't be restricted to it", gateway),
+				})
+				continue
+			}
+
+			for _, other := range api.Spec.GetGateways() {
+				if other == gateway || other == gatewayv1beta1.MeshGateway {
+					continue
+				}
+				otherPrincipal, ok := processing.GetGatewayPrincipal(api, other, v.gatewayPrincipals)
+				if !ok {
+					otherPrincipal = builders.IstioIngressGatewayPrincipal
+				}
+				if otherPrincipal == principal {
+					failures = append(failures, validation.Failure{
+						AttributePath: gatewayAttributePath,
+						Message:       fmt.Sprintf("Gateway %s has the same principal as gateway %s, so the rule can't be restricted to it", gateway, other),
+					})
+					break
+				}
+			}
+		}
+	}
+
+	return failures
+}
+
+func isIngressGateway(gateway string) bool {
+	return gateway != gatewayv1beta1.MeshGateway
+}
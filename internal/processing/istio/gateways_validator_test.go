@@ -0,0 +1,92 @@
+package istio
+
+import (
+	"github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Gateways validator", func() {
+
+	allow := []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "allow"}}}
+	jwt := []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "jwt"}}}
+	defaultGateway := "kyma-system/kyma-gateway"
+	partnerGateway := "partners/partner-gateway"
+
+	getApiRule := func(rules ...v1beta1.Rule) *v1beta1.APIRule {
+		return &v1beta1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: v1beta1.APIRuleSpec{
+				Gateway:  &defaultGateway,
+				Gateways: []string{partnerGateway},
+				Rules:    rules,
+			},
+		}
+	}
+
+	It("Should succeed for rules restricted to gateways with distinct configured principals", func() {
+		//given
+		api := getApiRule(
+			v1beta1.Rule{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt},
+			v1beta1.Rule{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, Gateways: []string{partnerGateway}},
+		)
+		validator := &gatewaysValidator{gatewayPrincipals: map[string]string{partnerGateway: "cluster.local/ns/partners/sa/partner-gateway"}}
+
+		//when
+		problems := validator.Validate(".spec.rules", api)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for a rule restricted to a gateway without configured principal", func() {
+		//given
+		api := getApiRule(
+			v1beta1.Rule{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt},
+			v1beta1.Rule{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, Gateways: []string{partnerGateway}},
+		)
+
+		//when
+		problems := (&gatewaysValidator{}).Validate(".spec.rules", api)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].gateways[0]"))
+		Expect(problems[0].Message).To(Equal("No principal is configured for gateway partners/partner-gateway, so the rule can't be restricted to it"))
+	})
+
+	It("Should fail for a rule restricted to a gateway with the principal of another gateway", func() {
+		//given
+		api := getApiRule(
+			v1beta1.Rule{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, Gateways: []string{partnerGateway}},
+		)
+		validator := &gatewaysValidator{gatewayPrincipals: map[string]string{partnerGateway: "cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"}}
+
+		//when
+		problems := validator.Validate(".spec.rules", api)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].gateways[0]"))
+		Expect(problems[0].Message).To(Equal("Gateway partners/partner-gateway has the same principal as gateway kyma-system/kyma-gateway, so the rule can't be restricted to it"))
+	})
+
+	It("Should fail for IP allow and deny lists in rules exposed only to the mesh", func() {
+		//given
+		api := getApiRule(
+			v1beta1.Rule{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, IPAllowList: []string{"10.0.0.0/8"}},
+			v1beta1.Rule{Path: "/admin", Methods: []string{"GET"}, AccessStrategies: allow, IPAllowList: []string{"10.0.0.0/8"},
+				Gateways: []string{v1beta1.MeshGateway}},
+		)
+		api.Spec.Gateways = append(api.Spec.Gateways, v1beta1.MeshGateway)
+
+		//when
+		problems := (&gatewaysValidator{}).Validate(".spec.rules", api)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1]"))
+		Expect(problems[0].Message).To(Equal("IP allow and deny lists are not supported for rules exposed only to the mesh"))
+	})
+})
@@ -0,0 +1,66 @@
+package istio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/validation"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+type introspectionValidator struct {
+	provider string
+}
+
+// Validate validates the configuration of the oauth2_introspection access strategy if the tokens are introspected without
+// Oathkeeper, which is the case if an introspection URL is configured. Other configurations are passed to Oathkeeper.
+func (v *introspectionValidator) Validate(attributePath string, handler *gatewayv1beta1.Handler) []validation.Failure {
+	if !validation.ConfigNotEmpty(handler.Config) {
+		return nil
+	}
+
+	var config gatewayv1beta1.IntrospectionConfig
+	if err := json.Unmarshal(handler.Config.Raw, &config); err != nil {
+		return []validation.Failure{{AttributePath: attributePath + ".config", Message: "Can't read json: " + err.Error()}}
+	}
+
+	if config.IntrospectionUrl == "" {
+		return nil
+	}
+
+	var failures []validation.Failure
+
+	if v.provider == "" {
+		failures = append(failures, validation.Failure{
+			AttributePath: attributePath + ".config.introspectionUrl",
+			Message:       "Token introspection without Oathkeeper is not enabled, since no introspection provider is configured",
+		})
+	}
+
+	if u, err := url.Parse(config.IntrospectionUrl); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		failures = append(failures, validation.Failure{
+			AttributePath: attributePath + ".config.introspectionUrl",
+			Message:       "introspectionUrl must be an absolute http or https URL",
+		})
+	}
+
+	for i, scope := range config.RequiredScopes {
+		if scope == "" {
+			failures = append(failures, validation.Failure{
+				AttributePath: fmt.Sprintf("%s.config.requiredScopes[%d]", attributePath, i),
+				Message:       "scope value is empty",
+			})
+		}
+	}
+
+	if config.ClientCredentialsSecret != "" && len(k8svalidation.IsDNS1123Subdomain(config.ClientCredentialsSecret)) > 0 {
+		failures = append(failures, validation.Failure{
+			AttributePath: attributePath + ".config.clientCredentialsSecret",
+			Message:       fmt.Sprintf("Invalid Secret name: %s", config.ClientCredentialsSecret),
+		})
+	}
+
+	return failures
+}
@@ -0,0 +1,57 @@
+package istio
+
+import (
+	"github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Introspection validator", func() {
+
+	handler := func(config string) *v1beta1.Handler {
+		return &v1beta1.Handler{Name: "oauth2_introspection", Config: &runtime.RawExtension{Raw: []byte(config)}}
+	}
+
+	It("Should succeed for config handled by Oathkeeper", func() {
+		//when
+		problems := (&introspectionValidator{}).Validate("some.attribute", handler(`{"required_scope": ["read"]}`))
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed for config with introspection URL, scopes and client credentials", func() {
+		//when
+		problems := (&introspectionValidator{provider: "api-gateway-introspection"}).Validate("some.attribute",
+			handler(`{"introspectionUrl": "https://auth.example.com/introspect", "requiredScopes": ["read"], "clientCredentialsSecret": "credentials"}`))
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for config with introspection URL if no introspection provider is configured", func() {
+		//when
+		problems := (&introspectionValidator{}).Validate("some.attribute", handler(`{"introspectionUrl": "https://auth.example.com/introspect"}`))
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config.introspectionUrl"))
+		Expect(problems[0].Message).To(Equal("Token introspection without Oathkeeper is not enabled, since no introspection provider is configured"))
+	})
+
+	It("Should fail for invalid introspection URL, empty scope and invalid Secret name", func() {
+		//when
+		problems := (&introspectionValidator{provider: "api-gateway-introspection"}).Validate("some.attribute",
+			handler(`{"introspectionUrl": "/introspect", "requiredScopes": ["read", ""], "clientCredentialsSecret": "Credentials"}`))
+
+		//then
+		Expect(problems).To(HaveLen(3))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.config.introspectionUrl"))
+		Expect(problems[0].Message).To(Equal("introspectionUrl must be an absolute http or https URL"))
+		Expect(problems[1].AttributePath).To(Equal("some.attribute.config.requiredScopes[1]"))
+		Expect(problems[1].Message).To(Equal("scope value is empty"))
+		Expect(problems[2].AttributePath).To(Equal("some.attribute.config.clientCredentialsSecret"))
+		Expect(problems[2].Message).To(Equal("Invalid Secret name: Credentials"))
+	})
+})
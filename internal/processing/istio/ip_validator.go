@@ -0,0 +1,39 @@
+package istio
+
+import (
+	"fmt"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+// validateIpRestrictions rejects IP allow and deny lists and workload sources on rules handled by Oathkeeper. Oathkeeper
+// proxies the requests to the workload, so the AuthorizationPolicy of the workload can't see the IP or the identity of the
+// client anymore.
+func validateIpRestrictions(attrPath string, rules []gatewayv1beta1.Rule) []validation.Failure {
+	var failures []validation.Failure
+
+	for i, rule := range rules {
+		if processing.HasIpRestriction(rule) && processing.IsSecured(rule) && !processing.IsIstioSecured(rule) {
+			failures = append(failures, validation.Failure{
+				AttributePath: fmt.Sprintf("%s[%d]", attrPath, i),
+				Message:       "IP allow and deny lists are not supported for access strategies handled by Oathkeeper",
+			})
+		}
+		if processing.HasIpRestriction(rule) && processing.HasSourceRestriction(rule) {
+			failures = append(failures, validation.Failure{
+				AttributePath: fmt.Sprintf("%s[%d]", attrPath, i),
+				Message:       "IP allow and deny lists can't be combined with workload sources, since they apply only to the requests through ingress gateways",
+			})
+		}
+		if processing.HasSourceRestriction(rule) && processing.IsSecured(rule) && !processing.IsIstioSecured(rule) {
+			failures = append(failures, validation.Failure{
+				AttributePath: fmt.Sprintf("%s[%d].from", attrPath, i),
+				Message:       "Workload sources are not supported for access strategies handled by Oathkeeper",
+			})
+		}
+	}
+
+	return failures
+}
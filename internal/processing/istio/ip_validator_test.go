@@ -0,0 +1,78 @@
+package istio
+
+import (
+	"github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IP restrictions validator", func() {
+
+	allow := []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "allow"}}}
+	jwt := []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "jwt"}}}
+	noop := []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "noop"}}}
+
+	It("Should succeed for IP allow and deny lists in rules with allow and jwt access strategies", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/admin", Methods: []string{"GET"}, AccessStrategies: allow, IPAllowList: []string{"10.0.0.0/8"}},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt, IPDenyList: []string{"10.1.0.0/16"}},
+		}
+
+		//when
+		problems := validateIpRestrictions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for IP allow and deny lists in rules handled by Oathkeeper", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/admin", Methods: []string{"GET"}, AccessStrategies: allow, IPAllowList: []string{"10.0.0.0/8"}},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: noop, IPDenyList: []string{"10.1.0.0/16"}},
+		}
+
+		//when
+		problems := validateIpRestrictions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1]"))
+		Expect(problems[0].Message).To(Equal("IP allow and deny lists are not supported for access strategies handled by Oathkeeper"))
+	})
+
+	It("Should fail for workload sources in rules handled by Oathkeeper", func() {
+		//given
+		from := &v1beta1.RuleSource{Namespaces: []string{"orders"}}
+		rules := []v1beta1.Rule{
+			{Path: "/admin", Methods: []string{"GET"}, AccessStrategies: allow, From: from},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt, From: from},
+			{Path: "/payments", Methods: []string{"GET"}, AccessStrategies: noop, From: from},
+		}
+
+		//when
+		problems := validateIpRestrictions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[2].from"))
+		Expect(problems[0].Message).To(Equal("Workload sources are not supported for access strategies handled by Oathkeeper"))
+	})
+
+	It("Should fail for IP allow and deny lists combined with workload sources", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/admin", Methods: []string{"GET"}, AccessStrategies: allow, IPAllowList: []string{"10.0.0.0/8"},
+				From: &v1beta1.RuleSource{Namespaces: []string{"orders"}}},
+		}
+
+		//when
+		problems := validateIpRestrictions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0]"))
+		Expect(problems[0].Message).To(Equal("IP allow and deny lists can't be combined with workload sources, since they apply only to the requests through ingress gateways"))
+	})
+})
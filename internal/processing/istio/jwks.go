@@ -0,0 +1,87 @@
+package istio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resolveJwks returns the access strategies with the JWKS referenced by jwksFrom read from the Secrets and ConfigMaps in
+// the namespace and set inline, so that the JWT rules of the RequestAuthentication can be built from them.
+func resolveJwks(ctx context.Context, k8sClient client.Client, namespace string, accessStrategies []*gatewayv1beta1.Authenticator) ([]*gatewayv1beta1.Authenticator, error) {
+	resolved := make([]*gatewayv1beta1.Authenticator, 0, len(accessStrategies))
+
+	for _, accessStrategy := range accessStrategies {
+		if accessStrategy.Name != "jwt" || accessStrategy.Config == nil {
+			resolved = append(resolved, accessStrategy)
+			continue
+		}
+
+		var config gatewayv1beta1.JwtConfig
+		if err := json.Unmarshal(accessStrategy.Config.Raw, &config); err != nil {
+			return nil, err
+		}
+
+		hasJwksFrom := false
+		for _, authentication := range config.Authentications {
+			if authentication.JwksFrom == nil {
+				continue
+			}
+			jwks, err := getJwks(ctx, k8sClient, namespace, authentication.JwksFrom)
+			if err != nil {
+				return nil, err
+			}
+			authentication.Jwks = jwks
+			authentication.JwksFrom = nil
+			hasJwksFrom = true
+		}
+
+		if !hasJwksFrom {
+			resolved = append(resolved, accessStrategy)
+			continue
+		}
+
+		raw, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		resolvedAccessStrategy := accessStrategy.DeepCopy()
+		resolvedAccessStrategy.Config = &runtime.RawExtension{Raw: raw}
+		resolved = append(resolved, resolvedAccessStrategy)
+	}
+
+	return resolved, nil
+}
+
+func getJwks(ctx context.Context, k8sClient client.Client, namespace string, source *gatewayv1beta1.JwksSource) (string, error) {
+	switch {
+	case source.SecretKeyRef != nil:
+		var secret corev1.Secret
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: source.SecretKeyRef.Name}, &secret); err != nil {
+			return "", err
+		}
+		jwks, ok := secret.Data[source.SecretKeyRef.Key]
+		if !ok {
+			return "", fmt.Errorf("key %s not found in Secret %s/%s", source.SecretKeyRef.Key, namespace, source.SecretKeyRef.Name)
+		}
+		return string(jwks), nil
+	case source.ConfigMapKeyRef != nil:
+		var configMap corev1.ConfigMap
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: source.ConfigMapKeyRef.Name}, &configMap); err != nil {
+			return "", err
+		}
+		jwks, ok := configMap.Data[source.ConfigMapKeyRef.Key]
+		if !ok {
+			return "", fmt.Errorf("key %s not found in ConfigMap %s/%s", source.ConfigMapKeyRef.Key, namespace, source.ConfigMapKeyRef.Name)
+		}
+		return jwks, nil
+	}
+
+	return "", fmt.Errorf("jwksFrom has no Secret or ConfigMap reference")
+}
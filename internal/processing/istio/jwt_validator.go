@@ -5,13 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/kyma-project/api-gateway/api/v1beta1"
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
 	oryjwt "github.com/kyma-project/api-gateway/internal/types/ory"
 	"github.com/kyma-project/api-gateway/internal/validation"
 	apiv1beta1 "istio.io/api/type/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -39,7 +42,19 @@ func (o *handlerValidator) Validate(attributePath string, handler *gatewayv1beta
 	failures = append(failures, checkForOryConfig(attributePath, handler)...)
 
 	failures = append(failures, hasInvalidAuthorizations(attributePath, template.Authorizations)...)
-	failures = append(failures, hasInvalidAuthentications(attributePath, template.Authentications)...)
+
+	// An optional JWT without authentications would not validate any token, so the rule would be exposed without
+	// authentication
+	if template.Optional && len(template.Authentications) == 0 {
+		failures = append(failures, validation.Failure{AttributePath: attributePath + ".config.authentications", Message: "Authentications are required for optional JWT"})
+	} else {
+		failures = append(failures, hasInvalidAuthentications(attributePath, template.Authentications)...)
+	}
+
+	// Authorizations require claims of the JWT, so they can't be fulfilled by requests without a JWT
+	if template.Optional && len(template.Authorizations) > 0 {
+		failures = append(failures, validation.Failure{AttributePath: attributePath + ".config.authorizations", Message: "Authorizations are not supported for optional JWT"})
+	}
 
 	return failures
 }
@@ -81,14 +96,14 @@ func hasInvalidRequiredScopes(authorization v1beta1.JwtAuthorization) error {
 	return nil
 }
 
-func hasInvalidAudiences(authorization v1beta1.JwtAuthorization) error {
-	if authorization.Audiences == nil {
+func hasInvalidAudiences(audiences []string) error {
+	if audiences == nil {
 		return nil
 	}
-	if len(authorization.Audiences) == 0 {
+	if len(audiences) == 0 {
 		return errors.New("value is empty")
 	}
-	for _, audience := range authorization.Audiences {
+	for _, audience := range audiences {
 		if audience == "" {
 			return errors.New("audience value is empty")
 		}
@@ -112,11 +127,7 @@ func hasInvalidAuthentications(attributePath string, authentications []*v1beta1.
 			attrPath := fmt.Sprintf("%s%s[%d]%s", attributePath, ".config.authentications", i, ".issuer")
 			failures = append(failures, validation.Failure{AttributePath: attrPath, Message: fmt.Sprintf("value is empty or not a valid url err=%s", err)})
 		}
-		invalidJwksUri, err := validation.IsInvalidURL(authentication.JwksUri)
-		if invalidJwksUri {
-			attrPath := fmt.Sprintf("%s%s[%d]%s", attributePath, ".config.authentications", i, ".jwksUri")
-			failures = append(failures, validation.Failure{AttributePath: attrPath, Message: fmt.Sprintf("value is empty or not a valid url err=%s", err)})
-		}
+		failures = append(failures, hasInvalidJwks(fmt.Sprintf("%s%s[%d]", attributePath, ".config.authentications", i), authentication)...)
 		if len(authentication.FromHeaders) > 0 {
 			if hasFromParams {
 				attrPath := fmt.Sprintf("%s%s[%d]%s", attributePath, ".config.authentications", i, ".fromHeaders")
@@ -139,6 +150,93 @@ func hasInvalidAuthentications(attributePath string, authentications []*v1beta1.
 			attrPath := fmt.Sprintf("%s%s[%d]%s", attributePath, ".config.authentications", i, ".fromParams")
 			failures = append(failures, validation.Failure{AttributePath: attrPath, Message: "multiple fromParams are not supported"})
 		}
+		attrPath := fmt.Sprintf("%s%s[%d]%s", attributePath, ".config.authentications", i, ".outputClaimToHeaders")
+		failures = append(failures, hasInvalidClaimToHeaders(attrPath, authentication.OutputClaimToHeaders)...)
+		if err := hasInvalidAudiences(authentication.Audiences); err != nil {
+			attrPath := fmt.Sprintf("%s%s[%d]%s", attributePath, ".config.authentications", i, ".audiences")
+			failures = append(failures, validation.Failure{AttributePath: attrPath, Message: err.Error()})
+		}
+	}
+	return failures
+}
+
+// hasInvalidJwks validates that the JWKS of the authentication is defined either by jwksUri, inline by jwks or by a reference
+// in jwksFrom
+func hasInvalidJwks(attributePath string, authentication *v1beta1.JwtAuthentication) (failures []validation.Failure) {
+	definedSources := 0
+	if authentication.JwksUri != "" {
+		definedSources++
+	}
+	if authentication.Jwks != "" {
+		definedSources++
+	}
+	if authentication.JwksFrom != nil {
+		definedSources++
+	}
+	if definedSources > 1 {
+		return []validation.Failure{{AttributePath: attributePath, Message: "Only one of jwksUri, jwks or jwksFrom can be defined"}}
+	}
+
+	switch {
+	case authentication.Jwks != "":
+		if !isValidJwks(authentication.Jwks) {
+			failures = append(failures, validation.Failure{AttributePath: attributePath + ".jwks", Message: "value is not a JSON Web Key Set with at least one key"})
+		}
+	case authentication.JwksFrom != nil:
+		failures = append(failures, hasInvalidJwksSource(attributePath+".jwksFrom", authentication.JwksFrom)...)
+	default:
+		invalidJwksUri, err := validation.IsInvalidURL(authentication.JwksUri)
+		if invalidJwksUri {
+			failures = append(failures, validation.Failure{AttributePath: attributePath + ".jwksUri", Message: fmt.Sprintf("value is empty or not a valid url err=%s", err)})
+		}
+	}
+	return failures
+}
+
+func isValidJwks(jwks string) bool {
+	var keySet struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	return json.Unmarshal([]byte(jwks), &keySet) == nil && len(keySet.Keys) > 0
+}
+
+func hasInvalidJwksSource(attributePath string, source *v1beta1.JwksSource) (failures []validation.Failure) {
+	if (source.SecretKeyRef == nil) == (source.ConfigMapKeyRef == nil) {
+		return []validation.Failure{{AttributePath: attributePath, Message: "Exactly one of secretKeyRef or configMapKeyRef must be defined"}}
+	}
+
+	selectorPath, selector := attributePath+".secretKeyRef", source.SecretKeyRef
+	if source.ConfigMapKeyRef != nil {
+		selectorPath, selector = attributePath+".configMapKeyRef", source.ConfigMapKeyRef
+	}
+	if selector.Name == "" {
+		failures = append(failures, validation.Failure{AttributePath: selectorPath + ".name", Message: "value is empty"})
+	}
+	if selector.Key == "" {
+		failures = append(failures, validation.Failure{AttributePath: selectorPath + ".key", Message: "value is empty"})
+	}
+	return failures
+}
+
+func hasInvalidClaimToHeaders(attributePath string, claimToHeaders []*v1beta1.JwtClaimToHeader) (failures []validation.Failure) {
+	headers := make(map[string]bool)
+	for i, claimToHeader := range claimToHeaders {
+		if claimToHeader == nil {
+			attrPath := fmt.Sprintf("%s[%d]", attributePath, i)
+			failures = append(failures, validation.Failure{AttributePath: attrPath, Message: "value is empty"})
+			continue
+		}
+		headerPath := fmt.Sprintf("%s[%d]%s", attributePath, i, ".header")
+		if errs := k8svalidation.IsHTTPHeaderName(claimToHeader.Header); len(errs) > 0 {
+			failures = append(failures, validation.Failure{AttributePath: headerPath, Message: fmt.Sprintf("Invalid header name %q", claimToHeader.Header)})
+		} else if headers[strings.ToLower(claimToHeader.Header)] {
+			failures = append(failures, validation.Failure{AttributePath: headerPath, Message: fmt.Sprintf("Header %q is already used for another claim", claimToHeader.Header)})
+		}
+		headers[strings.ToLower(claimToHeader.Header)] = true
+		if claimToHeader.Claim == "" {
+			attrPath := fmt.Sprintf("%s[%d]%s", attributePath, i, ".claim")
+			failures = append(failures, validation.Failure{AttributePath: attrPath, Message: "value is empty"})
+		}
 	}
 	return failures
 }
@@ -166,16 +264,74 @@ func hasInvalidAuthorizations(attributePath string, authorizations []*v1beta1.Jw
 			failures = append(failures, validation.Failure{AttributePath: attrPath, Message: err.Error()})
 		}
 
-		err = hasInvalidAudiences(*authorization)
+		err = hasInvalidAudiences(authorization.Audiences)
 		if err != nil {
 			attrPath := fmt.Sprintf("%s%s[%d]%s", attributePath, ".config.authorizations", i, ".audiences")
 			failures = append(failures, validation.Failure{AttributePath: attrPath, Message: err.Error()})
 		}
+
+		if !isValidMatchMode(authorization.RequiredScopesMatch) {
+			attrPath := fmt.Sprintf("%s%s[%d]%s", attributePath, ".config.authorizations", i, ".requiredScopesMatch")
+			failures = append(failures, validation.Failure{AttributePath: attrPath, Message: fmt.Sprintf("Unsupported match mode %s, must be all or any", authorization.RequiredScopesMatch)})
+		}
+
+		if !isValidMatchMode(authorization.AudiencesMatch) {
+			attrPath := fmt.Sprintf("%s%s[%d]%s", attributePath, ".config.authorizations", i, ".audiencesMatch")
+			failures = append(failures, validation.Failure{AttributePath: attrPath, Message: fmt.Sprintf("Unsupported match mode %s, must be all or any", authorization.AudiencesMatch)})
+		}
+
+		for j, key := range authorization.ScopeClaimKeys {
+			if !helpers.IsValidClaimPath(key) {
+				attrPath := fmt.Sprintf("%s%s[%d]%s[%d]", attributePath, ".config.authorizations", i, ".scopeClaimKeys", j)
+				failures = append(failures, validation.Failure{AttributePath: attrPath, Message: fmt.Sprintf("Invalid claim path %q", key)})
+			}
+		}
+
+		failures = append(failures, hasInvalidClaims(fmt.Sprintf("%s%s[%d]%s", attributePath, ".config.authorizations", i, ".claims"), authorization.Claims)...)
 	}
 
 	return
 }
 
+func isValidMatchMode(mode v1beta1.MatchMode) bool {
+	return mode == "" || mode == v1beta1.MatchModeAll || mode == v1beta1.MatchModeAny
+}
+
+func hasInvalidClaims(attributePath string, claims []*v1beta1.JwtClaim) (failures []validation.Failure) {
+	for i, claim := range claims {
+		claimPath := fmt.Sprintf("%s[%d]", attributePath, i)
+		if claim == nil {
+			failures = append(failures, validation.Failure{AttributePath: claimPath, Message: "claim is empty"})
+			continue
+		}
+
+		if !helpers.IsValidClaimPath(claim.Key) {
+			failures = append(failures, validation.Failure{
+				AttributePath: claimPath + ".key",
+				Message:       fmt.Sprintf("Invalid claim path %q, nested claims must be separated by single dots and names can't contain brackets or whitespace", claim.Key),
+			})
+		}
+
+		if len(claim.Values) == 0 && len(claim.NotValues) == 0 {
+			failures = append(failures, validation.Failure{AttributePath: claimPath, Message: "values or notValues must be defined"})
+		}
+
+		for j, value := range claim.Values {
+			if value == "" {
+				failures = append(failures, validation.Failure{AttributePath: fmt.Sprintf("%s.values[%d]", claimPath, j), Message: "value is empty"})
+			}
+		}
+
+		for j, value := range claim.NotValues {
+			if value == "" {
+				failures = append(failures, validation.Failure{AttributePath: fmt.Sprintf("%s.notValues[%d]", claimPath, j), Message: "value is empty"})
+			}
+		}
+	}
+
+	return failures
+}
+
 type injectionValidator struct {
 	ctx    context.Context
 	client client.Client
@@ -215,6 +371,7 @@ func containsSidecar(pod corev1.Pod) bool {
 }
 
 type rulesValidator struct {
+	introspectionProvider string
 }
 
 func (v *rulesValidator) Validate(attrPath string, rules []gatewayv1beta1.Rule) []validation.Failure {
@@ -243,11 +400,18 @@ func (v *rulesValidator) Validate(attrPath string, rules []gatewayv1beta1.Rule)
 			}
 		}
 	}
+	failures = append(failures, validateMatchConditions(attrPath, rules)...)
+	failures = append(failures, validateIpRestrictions(attrPath, rules)...)
+	failures = append(failures, validateExtAuthProviders(attrPath, rules, v.introspectionProvider)...)
+	failures = append(failures, validateRewrites(attrPath, rules)...)
 	return failures
 }
 
 func isJwtAuthenticationsEqual(auth1 *gatewayv1beta1.JwtAuthentication, auth2 *gatewayv1beta1.JwtAuthentication) bool {
-	if auth1.Issuer != auth2.Issuer || auth1.JwksUri != auth2.JwksUri {
+	if auth1.Issuer != auth2.Issuer || auth1.JwksUri != auth2.JwksUri || auth1.Jwks != auth2.Jwks {
+		return false
+	}
+	if !isJwksSourceEqual(auth1.JwksFrom, auth2.JwksFrom) {
 		return false
 	}
 	if len(auth1.FromHeaders) != len(auth2.FromHeaders) {
@@ -266,5 +430,48 @@ func isJwtAuthenticationsEqual(auth1 *gatewayv1beta1.JwtAuthentication, auth2 *g
 			return false
 		}
 	}
-	return true
+	if len(auth1.Audiences) != len(auth2.Audiences) {
+		return false
+	}
+	for i, auth1Audience := range auth1.Audiences {
+		if auth1Audience != auth2.Audiences[i] {
+			return false
+		}
+	}
+	if len(auth1.OutputClaimToHeaders) != len(auth2.OutputClaimToHeaders) {
+		return false
+	}
+	for i, auth1ClaimToHeader := range auth1.OutputClaimToHeaders {
+		auth2ClaimToHeader := auth2.OutputClaimToHeaders[i]
+		if auth1ClaimToHeader == nil || auth2ClaimToHeader == nil {
+			if auth1ClaimToHeader != auth2ClaimToHeader {
+				return false
+			}
+			continue
+		}
+		if auth1ClaimToHeader.Header != auth2ClaimToHeader.Header || auth1ClaimToHeader.Claim != auth2ClaimToHeader.Claim {
+			return false
+		}
+	}
+	return isForwardOriginalToken(auth1) == isForwardOriginalToken(auth2)
+}
+
+func isJwksSourceEqual(source1 *gatewayv1beta1.JwksSource, source2 *gatewayv1beta1.JwksSource) bool {
+	if source1 == nil || source2 == nil {
+		return source1 == source2
+	}
+	return isJwksKeySelectorEqual(source1.SecretKeyRef, source2.SecretKeyRef) &&
+		isJwksKeySelectorEqual(source1.ConfigMapKeyRef, source2.ConfigMapKeyRef)
+}
+
+func isJwksKeySelectorEqual(selector1 *gatewayv1beta1.JwksKeySelector, selector2 *gatewayv1beta1.JwksKeySelector) bool {
+	if selector1 == nil || selector2 == nil {
+		return selector1 == selector2
+	}
+	return *selector1 == *selector2
+}
+
+// isForwardOriginalToken returns whether the original token is forwarded, which is the default
+func isForwardOriginalToken(auth *gatewayv1beta1.JwtAuthentication) bool {
+	return auth.ForwardOriginalToken == nil || *auth.ForwardOriginalToken
 }
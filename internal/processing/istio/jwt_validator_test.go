@@ -330,6 +330,224 @@ var _ = Describe("JWT Handler validation", func() {
 			Expect(problems[0].AttributePath).To(Equal(".config.authentications[1].fromHeaders"))
 			Expect(problems[0].Message).To(Equal("mixture of multiple fromHeaders and fromParams is not supported"))
 		})
+
+		It("Should succeed validation when authentication has valid outputClaimToHeaders", func() {
+			//given
+			forwardOriginalToken := false
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Authentications: []*gatewayv1beta1.JwtAuthentication{
+						{
+							Issuer:               "https://issuer.test/",
+							JwksUri:              "file://.well-known/jwks.json",
+							OutputClaimToHeaders: []*gatewayv1beta1.JwtClaimToHeader{{Header: "x-user", Claim: "sub"}, {Header: "X-Email", Claim: "email"}},
+							ForwardOriginalToken: &forwardOriginalToken,
+						},
+					},
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(HaveLen(0))
+		})
+
+		It("Should succeed validation when authentication has inline JWKS or JWKS from a Secret or ConfigMap", func() {
+			//given
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Authentications: []*gatewayv1beta1.JwtAuthentication{
+						{
+							Issuer: "https://issuer.test/",
+							Jwks:   `{"keys":[{"kty":"RSA","kid":"key","n":"n","e":"AQAB"}]}`,
+						},
+						{
+							Issuer:   "https://another.issuer.test/",
+							JwksFrom: &gatewayv1beta1.JwksSource{SecretKeyRef: &gatewayv1beta1.JwksKeySelector{Name: "jwks", Key: "jwks.json"}},
+						},
+						{
+							Issuer:   "https://third.issuer.test/",
+							JwksFrom: &gatewayv1beta1.JwksSource{ConfigMapKeyRef: &gatewayv1beta1.JwksKeySelector{Name: "jwks", Key: "jwks.json"}},
+						},
+					},
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(HaveLen(0))
+		})
+
+		It("Should fail validation when authentication has invalid JWKS sources", func() {
+			//given
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Authentications: []*gatewayv1beta1.JwtAuthentication{
+						{
+							Issuer:  "https://issuer.test/",
+							JwksUri: "file://.well-known/jwks.json",
+							Jwks:    `{"keys":[{"kty":"RSA","kid":"key","n":"n","e":"AQAB"}]}`,
+						},
+						{
+							Issuer: "https://another.issuer.test/",
+							Jwks:   `{"keys":[]}`,
+						},
+						{
+							Issuer: "https://third.issuer.test/",
+							JwksFrom: &gatewayv1beta1.JwksSource{
+								SecretKeyRef:    &gatewayv1beta1.JwksKeySelector{Name: "jwks", Key: "jwks.json"},
+								ConfigMapKeyRef: &gatewayv1beta1.JwksKeySelector{Name: "jwks", Key: "jwks.json"},
+							},
+						},
+						{
+							Issuer:   "https://fourth.issuer.test/",
+							JwksFrom: &gatewayv1beta1.JwksSource{SecretKeyRef: &gatewayv1beta1.JwksKeySelector{Name: "jwks"}},
+						},
+					},
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(HaveLen(4))
+			Expect(problems[0].AttributePath).To(Equal(".config.authentications[0]"))
+			Expect(problems[0].Message).To(Equal("Only one of jwksUri, jwks or jwksFrom can be defined"))
+			Expect(problems[1].AttributePath).To(Equal(".config.authentications[1].jwks"))
+			Expect(problems[1].Message).To(Equal("value is not a JSON Web Key Set with at least one key"))
+			Expect(problems[2].AttributePath).To(Equal(".config.authentications[2].jwksFrom"))
+			Expect(problems[2].Message).To(Equal("Exactly one of secretKeyRef or configMapKeyRef must be defined"))
+			Expect(problems[3].AttributePath).To(Equal(".config.authentications[3].jwksFrom.secretKeyRef.key"))
+			Expect(problems[3].Message).To(Equal("value is empty"))
+		})
+
+		It("Should fail validation when optional JWT has authorizations", func() {
+			//given
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Authentications: []*gatewayv1beta1.JwtAuthentication{
+						{
+							Issuer:  "https://issuer.test/",
+							JwksUri: "file://.well-known/jwks.json",
+						},
+					},
+					Authorizations: []*gatewayv1beta1.JwtAuthorization{{RequiredScopes: []string{"read"}}},
+					Optional:       true,
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(HaveLen(1))
+			Expect(problems[0].AttributePath).To(Equal(".config.authorizations"))
+			Expect(problems[0].Message).To(Equal("Authorizations are not supported for optional JWT"))
+		})
+
+		It("Should fail validation when optional JWT has no authentications", func() {
+			//given
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Optional: true,
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(HaveLen(1))
+			Expect(problems[0].AttributePath).To(Equal(".config.authentications"))
+			Expect(problems[0].Message).To(Equal("Authentications are required for optional JWT"))
+		})
+
+		It("Should fail validation when authentication has an empty audience", func() {
+			//given
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Authentications: []*gatewayv1beta1.JwtAuthentication{
+						{
+							Issuer:    "https://issuer.test/",
+							JwksUri:   "file://.well-known/jwks.json",
+							Audiences: []string{"audience", ""},
+						},
+					},
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(HaveLen(1))
+			Expect(problems[0].AttributePath).To(Equal(".config.authentications[0].audiences"))
+			Expect(problems[0].Message).To(Equal("audience value is empty"))
+		})
+
+		It("Should fail validation when outputClaimToHeaders has invalid header names or empty claims", func() {
+			//given
+			config := processingtest.GetRawConfig(
+				gatewayv1beta1.JwtConfig{
+					Authentications: []*gatewayv1beta1.JwtAuthentication{
+						{
+							Issuer:  "https://issuer.test/",
+							JwksUri: "file://.well-known/jwks.json",
+							OutputClaimToHeaders: []*gatewayv1beta1.JwtClaimToHeader{
+								{Header: "x user", Claim: "sub"},
+								{Header: "x-email", Claim: ""},
+								{Header: "X-Email", Claim: "email"},
+							},
+						},
+					},
+				})
+
+			handler := &gatewayv1beta1.Handler{
+				Name:   "jwt",
+				Config: config,
+			}
+
+			//when
+			problems := (&handlerValidator{}).Validate("", handler)
+
+			//then
+			Expect(problems).To(HaveLen(3))
+			Expect(problems[0].AttributePath).To(Equal(".config.authentications[0].outputClaimToHeaders[0].header"))
+			Expect(problems[0].Message).To(Equal(`Invalid header name "x user"`))
+			Expect(problems[1].AttributePath).To(Equal(".config.authentications[0].outputClaimToHeaders[1].claim"))
+			Expect(problems[1].Message).To(Equal("value is empty"))
+			Expect(problems[2].AttributePath).To(Equal(".config.authentications[0].outputClaimToHeaders[2].header"))
+			Expect(problems[2].Message).To(Equal(`Header "X-Email" is already used for another claim`))
+		})
 	})
 
 	Context("for authorizations", func() {
@@ -551,6 +769,108 @@ var _ = Describe("JWT Handler validation", func() {
 				Expect(problems).To(HaveLen(0))
 			})
 		})
+
+		Context("claims", func() {
+
+			It("Should successful validate config with nested claims", func() {
+				//given
+				authorizations := []*gatewayv1beta1.JwtAuthorization{
+					{
+						Claims: []*gatewayv1beta1.JwtClaim{
+							{Key: "groups", Values: []string{"admins"}},
+							{Key: "realm_access.roles", NotValues: []string{"guest"}},
+						},
+					},
+				}
+				handler := &gatewayv1beta1.Handler{
+					Name:   "jwt",
+					Config: testURLJWTIstioConfigWithAuthorizations(authorizations),
+				}
+
+				//when
+				problems := (&handlerValidator{}).Validate("", handler)
+
+				//then
+				Expect(problems).To(BeEmpty())
+			})
+
+			It("Should fail validation for unsupported match modes", func() {
+				//given
+				authorizations := []*gatewayv1beta1.JwtAuthorization{
+					{
+						RequiredScopes:      []string{"read", "admin"},
+						RequiredScopesMatch: "one",
+						Audiences:           []string{"www.example.com"},
+						AudiencesMatch:      gatewayv1beta1.MatchModeAny,
+					},
+				}
+				handler := &gatewayv1beta1.Handler{
+					Name:   "jwt",
+					Config: testURLJWTIstioConfigWithAuthorizations(authorizations),
+				}
+
+				//when
+				problems := (&handlerValidator{}).Validate("", handler)
+
+				//then
+				Expect(problems).To(HaveLen(1))
+				Expect(problems[0].AttributePath).To(Equal(".config.authorizations[0].requiredScopesMatch"))
+				Expect(problems[0].Message).To(Equal("Unsupported match mode one, must be all or any"))
+			})
+
+			It("Should fail validation for invalid scope claim keys", func() {
+				//given
+				authorizations := []*gatewayv1beta1.JwtAuthorization{
+					{
+						RequiredScopes: []string{"read"},
+						ScopeClaimKeys: []string{"permissions", "roles[0]"},
+					},
+				}
+				handler := &gatewayv1beta1.Handler{
+					Name:   "jwt",
+					Config: testURLJWTIstioConfigWithAuthorizations(authorizations),
+				}
+
+				//when
+				problems := (&handlerValidator{}).Validate("", handler)
+
+				//then
+				Expect(problems).To(HaveLen(1))
+				Expect(problems[0].AttributePath).To(Equal(".config.authorizations[0].scopeClaimKeys[1]"))
+				Expect(problems[0].Message).To(Equal(`Invalid claim path "roles[0]"`))
+			})
+
+			It("Should fail validation for invalid claim paths and values", func() {
+				//given
+				authorizations := []*gatewayv1beta1.JwtAuthorization{
+					{
+						Claims: []*gatewayv1beta1.JwtClaim{
+							{Key: "realm_access..roles", Values: []string{"admin"}},
+							{Key: "groups[0]", Values: []string{"admins"}},
+							{Key: "tenant"},
+							{Key: "roles", Values: []string{"admin"}, NotValues: []string{""}},
+						},
+					},
+				}
+				handler := &gatewayv1beta1.Handler{
+					Name:   "jwt",
+					Config: testURLJWTIstioConfigWithAuthorizations(authorizations),
+				}
+
+				//when
+				problems := (&handlerValidator{}).Validate("", handler)
+
+				//then
+				Expect(problems).To(HaveLen(4))
+				Expect(problems[0].AttributePath).To(Equal(".config.authorizations[0].claims[0].key"))
+				Expect(problems[0].Message).To(Equal(`Invalid claim path "realm_access..roles", nested claims must be separated by single dots and names can't contain brackets or whitespace`))
+				Expect(problems[1].AttributePath).To(Equal(".config.authorizations[0].claims[1].key"))
+				Expect(problems[2].AttributePath).To(Equal(".config.authorizations[0].claims[2]"))
+				Expect(problems[2].Message).To(Equal("values or notValues must be defined"))
+				Expect(problems[3].AttributePath).To(Equal(".config.authorizations[0].claims[3].notValues[0]"))
+				Expect(problems[3].Message).To(Equal("value is empty"))
+			})
+		})
 	})
 
 	Context("for rules validation", func() {
@@ -623,6 +943,70 @@ var _ = Describe("JWT Handler validation", func() {
 			Expect(problems[0].Message).To(Equal("multiple jwt configurations that differ for the same issuer"))
 		})
 
+		It("Should fail validation when multiple authentications for the same issuer differ in outputClaimToHeaders", func() {
+			//given
+			rule := gatewayv1beta1.Rule{
+				AccessStrategies: []*gatewayv1beta1.Authenticator{{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "jwt",
+						Config: getRawConfig(
+							gatewayv1beta1.JwtConfig{
+								Authentications: []*gatewayv1beta1.JwtAuthentication{
+									{
+										Issuer:               "https://issuer.test/",
+										JwksUri:              "file://.well-known/jwks.json",
+										OutputClaimToHeaders: []*gatewayv1beta1.JwtClaimToHeader{{Header: "x-user", Claim: "sub"}},
+									},
+									{
+										Issuer:  "https://issuer.test/",
+										JwksUri: "file://.well-known/jwks.json",
+									},
+								},
+							}),
+					}},
+				},
+			}
+
+			//when
+			problems := (&rulesValidator{}).Validate(".spec.rules", []gatewayv1beta1.Rule{rule})
+
+			//then
+			Expect(problems).To(HaveLen(1))
+			Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].accessStrategy[0].config.authentications[1]"))
+			Expect(problems[0].Message).To(Equal("multiple jwt configurations that differ for the same issuer"))
+		})
+
+		It("Should fail validation when multiple jwt handlers for the same issuer differ in audiences", func() {
+			//given
+			getRule := func(audiences ...string) gatewayv1beta1.Rule {
+				return gatewayv1beta1.Rule{
+					AccessStrategies: []*gatewayv1beta1.Authenticator{{
+						Handler: &gatewayv1beta1.Handler{
+							Name: "jwt",
+							Config: getRawConfig(
+								gatewayv1beta1.JwtConfig{
+									Authentications: []*gatewayv1beta1.JwtAuthentication{
+										{
+											Issuer:    "https://issuer.test/",
+											JwksUri:   "file://.well-known/jwks.json",
+											Audiences: audiences,
+										},
+									},
+								}),
+						}},
+					},
+				}
+			}
+
+			//when
+			problems := (&rulesValidator{}).Validate(".spec.rules", []gatewayv1beta1.Rule{getRule("audience1"), getRule("audience1", "audience2")})
+
+			//then
+			Expect(problems).To(HaveLen(1))
+			Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].accessStrategy[0].config.authentications[0]"))
+			Expect(problems[0].Message).To(Equal("multiple jwt configurations that differ for the same issuer"))
+		})
+
 		It("Should fail when multiple jwt handlers specify different token from types of configurations", func() {
 			//given
 			ruleFromHeaders := gatewayv1beta1.Rule{
@@ -0,0 +1,212 @@
+package istio
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/validation"
+	"golang.org/x/exp/slices"
+)
+
+// validateMatchConditions validates the paths and the header and query parameter match conditions of the rules. The
+// AuthorizationPolicies of the rules can only reflect exact, prefix and template path matches and exact and prefix header
+// matches, so other match conditions are rejected if the APIRule requires AuthorizationPolicies. Oathkeeper matches the
+// access rules by URL and method only, so rules handled by Oathkeeper can't be distinguished by match conditions.
+// The ALLOW AuthorizationPolicies of all rules apply to every request, so rules that can match the same requests must
+// have the same access configuration. Otherwise, the policy of the less restricted rule would allow the requests routed
+// to the other rule.
+func validateMatchConditions(attrPath string, rules []gatewayv1beta1.Rule) []validation.Failure {
+	var failures []validation.Failure
+
+	if processing.RequiresAuthorizationPolicies(rules) {
+		for i, rule := range rules {
+			if _, ok := helpers.GetAuthorizationPolicyPaths(rule); !ok && processing.RoutesToService(rule) {
+				failures = append(failures, validation.Failure{
+					AttributePath: fmt.Sprintf("%s[%d].path", attrPath, i),
+					Message:       "The path can't be enforced by an AuthorizationPolicy. Use the Exact or Prefix path type, or a regex path that is a literal path optionally followed by .*",
+				})
+			}
+			if len(rule.QueryParams) > 0 {
+				failures = append(failures, validation.Failure{
+					AttributePath: fmt.Sprintf("%s[%d].queryParams", attrPath, i),
+					Message:       "Query parameter matches are not supported in APIRules with the jwt access strategy or IP allow and deny lists",
+				})
+			}
+			for name, match := range rule.Headers {
+				if match != nil && match.Regex != "" {
+					failures = append(failures, validation.Failure{
+						AttributePath: fmt.Sprintf("%s[%d].headers[%s].regex", attrPath, i, name),
+						Message:       "Regex header matches are not supported in APIRules with the jwt access strategy or IP allow and deny lists",
+					})
+				}
+			}
+		}
+	}
+
+	if processing.RequiresAuthorizationPolicies(rules) {
+		failures = append(failures, validateOverlappingRulesAccess(attrPath, rules)...)
+	}
+
+	isHandledByOathkeeper := func(rule gatewayv1beta1.Rule) bool {
+		return processing.IsSecured(rule) && !processing.IsIstioSecured(rule)
+	}
+	for _, i := range processing.FindPathAndMethodDuplicates(rules, isHandledByOathkeeper) {
+		failures = append(failures, validation.Failure{
+			AttributePath: fmt.Sprintf("%s[%d]", attrPath, i),
+			Message:       "Rules with access strategies handled by Oathkeeper can't have the same path and method",
+		})
+	}
+
+	return failures
+}
+
+// validateOverlappingRulesAccess rejects rules with a different access configuration than a previous rule matching the same
+// requests. This is allowed if the requests matched by both rules are routed to the more specific rule and it has no access
+// restrictions, since its AuthorizationPolicy allows only requests that are routed to it.
+func validateOverlappingRulesAccess(attrPath string, rules []gatewayv1beta1.Rule) []validation.Failure {
+	var failures []validation.Failure
+	for i, rule := range rules {
+		if !processing.RoutesToService(rule) {
+			continue
+		}
+		for j, previous := range rules[:i] {
+			if !processing.RoutesToService(previous) || !canMatchSameRequests(previous, rule) || hasSameAccess(previous, rule) {
+				continue
+			}
+			if isUnrestricted(previous) && takesPrecedence(previous, j, rule, i) || isUnrestricted(rule) && takesPrecedence(rule, i, previous, j) {
+				continue
+			}
+			failures = append(failures, validation.Failure{
+				AttributePath: fmt.Sprintf("%s[%d]", attrPath, i),
+				Message:       "Rules with the same path and method must have the same access strategies, unless they match different values of the same header",
+			})
+			break
+		}
+	}
+	return failures
+}
+
+// takesPrecedence returns true if the route of rule a takes precedence over the route of rule b and a matches a subset of
+// the requests matched by b. Routes with match conditions take precedence over the routes without, otherwise the order
+// of the rules applies.
+func takesPrecedence(a gatewayv1beta1.Rule, aIndex int, b gatewayv1beta1.Rule, bIndex int) bool {
+	if !processing.HasMatchConditions(a) || (processing.HasMatchConditions(b) && bIndex < aIndex) {
+		return false
+	}
+	for nameB, matchB := range b.Headers {
+		if matchB == nil {
+			continue
+		}
+		found := false
+		for nameA, matchA := range a.Headers {
+			if strings.EqualFold(nameA, nameB) && matchA != nil && *matchA == *matchB {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(b.Gateways) == 0 {
+		return true
+	}
+	if len(a.Gateways) == 0 {
+		return false
+	}
+	for _, gateway := range a.Gateways {
+		if !slices.Contains(b.Gateways, gateway) {
+			return false
+		}
+	}
+	return true
+}
+
+// isUnrestricted returns true if the rule allows all requests without access strategies, IP or source restrictions
+func isUnrestricted(rule gatewayv1beta1.Rule) bool {
+	for _, strategy := range rule.AccessStrategies {
+		if strategy.Handler == nil || strategy.Name != "allow" {
+			return false
+		}
+	}
+	return !processing.HasIpRestriction(rule) && !processing.HasSourceRestriction(rule)
+}
+
+// canMatchSameRequests returns false if no request can match both rules. The AuthorizationPolicies can distinguish the
+// requests only by path, method, header and the principal of the gateway, so query parameter matches are not considered.
+// Rules matching exactly the same requests are rejected by the APIRule validation already.
+func canMatchSameRequests(a gatewayv1beta1.Rule, b gatewayv1beta1.Rule) bool {
+	if helpers.GetPathRegex(a) != helpers.GetPathRegex(b) || !methodsOverlap(a.Methods, b.Methods) {
+		return false
+	}
+	if helpers.GetRuleMatchKey(a) == helpers.GetRuleMatchKey(b) && reflect.DeepEqual(a.Methods, b.Methods) {
+		return false
+	}
+	if gatewaysDisjoint(a.Gateways, b.Gateways) {
+		return false
+	}
+	for nameA, matchA := range a.Headers {
+		for nameB, matchB := range b.Headers {
+			if strings.EqualFold(nameA, nameB) && headerMatchesDisjoint(matchA, matchB) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// gatewaysDisjoint returns true if both rules are restricted to gateways and have no gateway in common. The
+// AuthorizationPolicies of rules restricted to the mesh gateway exclude the ingress gateways, so the requests through
+// the mesh gateway are distinguished from the requests through other gateways too.
+func gatewaysDisjoint(a []string, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	for _, gateway := range a {
+		if slices.Contains(b, gateway) {
+			return false
+		}
+	}
+	return true
+}
+
+// methodsOverlap returns true if a method is in both lists. An empty list matches all methods.
+func methodsOverlap(a []string, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, method := range a {
+		if slices.Contains(b, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// headerMatchesDisjoint returns true if no header value can fulfill both exact or prefix matches
+func headerMatchesDisjoint(a *gatewayv1beta1.StringMatch, b *gatewayv1beta1.StringMatch) bool {
+	if a == nil || b == nil || a.Regex != "" || b.Regex != "" {
+		return false
+	}
+	switch {
+	case a.Exact != "" && b.Exact != "":
+		return a.Exact != b.Exact
+	case a.Exact != "":
+		return !strings.HasPrefix(a.Exact, b.Prefix)
+	case b.Exact != "":
+		return !strings.HasPrefix(b.Exact, a.Prefix)
+	default:
+		return !strings.HasPrefix(a.Prefix, b.Prefix) && !strings.HasPrefix(b.Prefix, a.Prefix)
+	}
+}
+
+// hasSameAccess returns true if the rules have the same access strategies and the same IP and source restrictions
+func hasSameAccess(a gatewayv1beta1.Rule, b gatewayv1beta1.Rule) bool {
+	return reflect.DeepEqual(a.AccessStrategies, b.AccessStrategies) &&
+		reflect.DeepEqual(a.IPAllowList, b.IPAllowList) &&
+		reflect.DeepEqual(a.IPDenyList, b.IPDenyList) &&
+		reflect.DeepEqual(a.From, b.From)
+}
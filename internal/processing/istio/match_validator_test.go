@@ -0,0 +1,189 @@
+package istio
+
+import (
+	"github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Match conditions validator", func() {
+
+	allow := []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "allow"}}}
+	jwt := []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "jwt"}}}
+	noop := []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "noop"}}}
+
+	It("Should succeed for query parameter and regex header matches without jwt access strategy", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, QueryParams: map[string]*v1beta1.StringMatch{"beta": {Exact: "true"}}},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, Headers: map[string]*v1beta1.StringMatch{"X-Api-Version": {Regex: "2|3"}}},
+		}
+
+		//when
+		problems := validateMatchConditions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for query parameter and regex header matches with jwt access strategy", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt, Headers: map[string]*v1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, QueryParams: map[string]*v1beta1.StringMatch{"beta": {Exact: "true"}}},
+			{Path: "/items", Methods: []string{"GET"}, AccessStrategies: allow, Headers: map[string]*v1beta1.StringMatch{"X-Api-Version": {Regex: "2|3"}}},
+		}
+
+		//when
+		problems := validateMatchConditions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(3))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].queryParams"))
+		Expect(problems[0].Message).To(Equal("Query parameter matches are not supported in APIRules with the jwt access strategy or IP allow and deny lists"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[2].headers[X-Api-Version].regex"))
+		Expect(problems[1].Message).To(Equal("Regex header matches are not supported in APIRules with the jwt access strategy or IP allow and deny lists"))
+		Expect(problems[2].AttributePath).To(Equal(".spec.rules[1]"))
+		Expect(problems[2].Message).To(Equal("Rules with the same path and method must have the same access strategies, unless they match different values of the same header"))
+	})
+
+	It("Should fail for rules with the same path and method and different access strategies that can match the same requests", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt, Headers: map[string]*v1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow},
+		}
+
+		//when
+		problems := validateMatchConditions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1]"))
+		Expect(problems[0].Message).To(Equal("Rules with the same path and method must have the same access strategies, unless they match different values of the same header"))
+	})
+
+	It("Should succeed for rules with different access strategies restricted to different gateways", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt, Gateways: []string{"kyma-system/kyma-gateway"}},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, Gateways: []string{"partners/partner-gateway"}},
+		}
+
+		//when
+		problems := validateMatchConditions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for rules with different access strategies restricted to overlapping gateways", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt, Gateways: []string{"partners/partner-gateway"}},
+		}
+
+		//when
+		problems := validateMatchConditions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1]"))
+	})
+
+	It("Should succeed for a rule without access restrictions restricted to the mesh gateway next to a rule with jwt access strategy", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, Gateways: []string{"mesh"}},
+			{Path: "/items", Methods: []string{"GET"}, AccessStrategies: jwt},
+			{Path: "/items", Methods: []string{"GET"}, AccessStrategies: allow, Headers: map[string]*v1beta1.StringMatch{"X-Public": {Exact: "true"}}},
+		}
+
+		//when
+		problems := validateMatchConditions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for a rule with IP restrictions restricted to the mesh gateway next to a rule with jwt access strategy", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, Gateways: []string{"mesh"}, IPAllowList: []string{"10.0.0.0/8"}},
+		}
+
+		//when
+		problems := validateMatchConditions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1]"))
+	})
+
+	It("Should succeed for rules with different access strategies that match different values of the same header", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt, Headers: map[string]*v1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, Headers: map[string]*v1beta1.StringMatch{"x-api-version": {Prefix: "1"}}},
+			{Path: "/orders", Methods: []string{"POST"}, AccessStrategies: allow},
+		}
+
+		//when
+		problems := validateMatchConditions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for rules handled by Oathkeeper with the same path and method", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: noop},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow, Headers: map[string]*v1beta1.StringMatch{"X-Api-Version": {Exact: "3"}}},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: noop, Headers: map[string]*v1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}},
+		}
+
+		//when
+		problems := validateMatchConditions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[2]"))
+		Expect(problems[0].Message).To(Equal("Rules with access strategies handled by Oathkeeper can't have the same path and method"))
+	})
+
+	It("Should fail for regex paths that can't be enforced by an AuthorizationPolicy with jwt access strategy", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/orders/.*", Methods: []string{"GET"}, AccessStrategies: jwt},
+			{Path: "/users/{id}", PathType: v1beta1.PathTypeExact, Methods: []string{"GET"}, AccessStrategies: jwt},
+			{Path: "/items/[0-9]+", Methods: []string{"GET"}, AccessStrategies: allow},
+			{Path: "/legacy/[0-9]+", Methods: []string{"GET"}, AccessStrategies: noop},
+		}
+
+		//when
+		problems := validateMatchConditions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[2].path"))
+		Expect(problems[0].Message).To(Equal("The path can't be enforced by an AuthorizationPolicy. Use the Exact or Prefix path type, or a regex path that is a literal path optionally followed by .*"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[3].path"))
+	})
+
+	It("Should succeed for regex paths that can't be enforced by an AuthorizationPolicy without jwt access strategy", func() {
+		//given
+		rules := []v1beta1.Rule{
+			{Path: "/items/[0-9]+", Methods: []string{"GET"}, AccessStrategies: allow},
+		}
+
+		//when
+		problems := validateMatchConditions(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+})
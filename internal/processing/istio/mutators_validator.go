@@ -8,15 +8,15 @@ import (
 	"github.com/kyma-project/api-gateway/internal/validation"
 )
 
-// mutatorsValidator is used to validate Istio-based mutator configurations. Since currently only the jwt access strategy
-// supports these mutators, validation is skipped for rules without jwt access strategy.
+// mutatorsValidator is used to validate Istio-based mutator configurations. Since currently only the jwt and extAuth access
+// strategies support these mutators, validation is skipped for rules without these access strategies.
 type mutatorsValidator struct {
 }
 
 func (m mutatorsValidator) Validate(attributePath string, rule v1beta1.Rule) []validation.Failure {
 	var failures []validation.Failure
 
-	if !processing.IsJwtSecured(rule) {
+	if !processing.IsIstioSecured(rule) {
 		return nil
 	}
 
@@ -21,9 +21,10 @@ func NewIstioReconciliation(config processing.ReconciliationConfig, log *logr.Lo
 	vsProcessor := NewVirtualServiceProcessor(config)
 	apProcessor := NewAuthorizationPolicyProcessor(config, log)
 	raProcessor := NewRequestAuthenticationProcessor(config)
+	efProcessor := NewEnvoyFilterProcessor(config)
 
 	return Reconciliation{
-		processors: []processing.ReconciliationProcessor{vsProcessor, raProcessor, apProcessor, acProcessor},
+		processors: []processing.ReconciliationProcessor{vsProcessor, raProcessor, apProcessor, acProcessor, efProcessor},
 		config:     config,
 	}
 }
@@ -36,10 +37,15 @@ func (r Reconciliation) Validate(ctx context.Context, client client.Client, apiR
 
 	validator := validation.APIRuleValidator{
 		HandlerValidator:          &handlerValidator{},
+		ExtAuthValidator:          &extAuthValidator{},
+		IntrospectionValidator:    &introspectionValidator{provider: r.config.IntrospectionProvider},
 		AccessStrategiesValidator: &asValidator{},
 		MutatorsValidator:         &mutatorsValidator{},
 		InjectionValidator:        &injectionValidator{ctx: ctx, client: client},
-		RulesValidator:            &rulesValidator{},
+		RulesValidator:            &rulesValidator{introspectionProvider: r.config.IntrospectionProvider},
+		ServicesValidator:         &servicesValidator{},
+		RewriteValidator:          &rewriteValidator{},
+		GatewaysValidator:         &gatewaysValidator{gatewayPrincipals: r.config.GatewayPrincipals},
 		ServiceBlockList:          r.config.ServiceBlockList,
 		DomainAllowList:           r.config.DomainAllowList,
 		HostBlockList:             r.config.HostBlockList,
@@ -32,21 +32,24 @@ func (r requestAuthenticationCreator) Create(ctx context.Context, client client.
 	requestAuthentications := make(map[string]*securityv1beta1.RequestAuthentication)
 	for _, rule := range api.Spec.Rules {
 		if processing.IsJwtSecured(rule) {
-			ra, err := generateRequestAuthentication(ctx, client, api, rule, r.additionalLabels)
-			if err != nil {
-				return requestAuthentications, err
+			// A RequestAuthentication is required for every workload the traffic of the rule is routed to
+			for _, service := range helpers.GetRuleServices(api, &rule) {
+				ra, err := generateRequestAuthentication(ctx, client, api, rule, service, r.additionalLabels)
+				if err != nil {
+					return requestAuthentications, err
+				}
+				requestAuthentications[processors.GetRequestAuthenticationKey(ra)] = ra
 			}
-			requestAuthentications[processors.GetRequestAuthenticationKey(ra)] = ra
 		}
 	}
 	return requestAuthentications, nil
 }
 
-func generateRequestAuthentication(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, additionalLabels map[string]string) (*securityv1beta1.RequestAuthentication, error) {
+func generateRequestAuthentication(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, service *gatewayv1beta1.WeightedService, additionalLabels map[string]string) (*securityv1beta1.RequestAuthentication, error) {
 	namePrefix := fmt.Sprintf("%s-", api.ObjectMeta.Name)
-	namespace := helpers.FindServiceNamespace(api, &rule)
+	namespace := *service.Namespace
 
-	spec, err := generateRequestAuthenticationSpec(ctx, client, api, rule)
+	spec, err := generateRequestAuthenticationSpec(ctx, client, api, rule, &service.Service)
 	if err != nil {
 		return nil, err
 	}
@@ -64,22 +67,20 @@ func generateRequestAuthentication(ctx context.Context, client client.Client, ap
 	return raBuilder.Get(), nil
 }
 
-func generateRequestAuthenticationSpec(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule) (*v1beta1.RequestAuthentication, error) {
-	var service *gatewayv1beta1.Service
-	if rule.Service != nil {
-		service = rule.Service
-	} else {
-		service = api.Spec.Service
+func generateRequestAuthenticationSpec(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, service *gatewayv1beta1.Service) (*v1beta1.RequestAuthentication, error) {
+	labelSelector, err := helpers.GetLabelSelectorFromService(ctx, client, service, api, &rule)
+	if err != nil {
+		return nil, err
 	}
 
-	labelSelector, err := helpers.GetLabelSelectorFromService(ctx, client, service, api, &rule)
+	accessStrategies, err := resolveJwks(ctx, client, api.Namespace, rule.AccessStrategies)
 	if err != nil {
 		return nil, err
 	}
 
 	requestAuthenticationSpec := builders.NewRequestAuthenticationSpecBuilder().
 		WithSelector(labelSelector).
-		WithJwtRules(*builders.NewJwtRuleBuilder().From(rule.AccessStrategies).Get())
+		WithJwtRules(*builders.NewJwtRuleBuilder().From(accessStrategies).Get())
 
 	return requestAuthenticationSpec.Get(), nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/kyma-project/api-gateway/internal/processing"
 	"istio.io/api/security/v1beta1"
 	typev1beta1 "istio.io/api/type/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
@@ -139,6 +140,30 @@ var _ = Describe("Request Authentication Processor", func() {
 		Expect(ra.Spec.Selector.MatchLabels[TestSelectorKey]).To(Equal(ServiceName))
 	})
 
+	It("should produce one RA for every weighted service of a Rule", func() {
+		// given
+		jwt := createIstioJwtAccessStrategy()
+		canaryServiceName := "canary-service"
+		ruleJwt := GetRuleFor(HeadersApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{jwt})
+		ruleJwt.Services = []*gatewayv1beta1.WeightedService{
+			{Service: gatewayv1beta1.Service{Name: &ServiceName, Port: &ServicePort}, Weight: 50},
+			{Service: gatewayv1beta1.Service{Name: &canaryServiceName, Port: &ServicePort}, Weight: 50},
+		}
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{ruleJwt})
+		client := GetFakeClient(GetService(ServiceName), GetService(canaryServiceName))
+		processor := istio.NewRequestAuthenticationProcessor(GetTestConfig())
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(ConsistOf(
+			getActionMatcher("create", ApiNamespace, ServiceName, JwksUri, JwtIssuer),
+			getActionMatcher("create", ApiNamespace, canaryServiceName, JwksUri, JwtIssuer),
+		))
+	})
+
 	It("should produce RA with service from Rule, when service is configured on Rule and ApiRule level", func() {
 		// given
 		jwt := createIstioJwtAccessStrategy()
@@ -689,4 +714,100 @@ var _ = Describe("Request Authentication Processor", func() {
 			Expect(ra.Spec.Selector.MatchLabels).To(HaveKeyWithValue("second-custom", "blah"))
 		})
 	})
+
+	When("JWT authentication defines the JWKS without URI", func() {
+		const jwks = `{"keys":[{"kty":"RSA","kid":"key","n":"n","e":"AQAB"}]}`
+
+		getJwksRule := func(authentication string) gatewayv1beta1.Rule {
+			accessStrategy := &gatewayv1beta1.Authenticator{
+				Handler: &gatewayv1beta1.Handler{
+					Name:   "jwt",
+					Config: &runtime.RawExtension{Raw: []byte(`{"authentications": [` + authentication + `]}`)},
+				},
+			}
+			return GetRuleFor("/", []string{"GET"}, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{accessStrategy})
+		}
+
+		It("should create RA with the inline JWKS", func() {
+			// given
+			rule := getJwksRule(fmt.Sprintf(`{"issuer": "%s", "jwks": %q}`, JwtIssuer, jwks))
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient(GetService(ServiceName))
+			processor := istio.NewRequestAuthenticationProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+			ra := result[0].Obj.(*securityv1beta1.RequestAuthentication)
+			Expect(ra.Spec.JwtRules).To(HaveLen(1))
+			Expect(ra.Spec.JwtRules[0].Issuer).To(Equal(JwtIssuer))
+			Expect(ra.Spec.JwtRules[0].JwksUri).To(BeEmpty())
+			Expect(ra.Spec.JwtRules[0].Jwks).To(Equal(jwks))
+		})
+
+		It("should create RA with the JWKS read from the referenced Secret", func() {
+			// given
+			rule := getJwksRule(fmt.Sprintf(`{"issuer": "%s", "jwksFrom": {"secretKeyRef": {"name": "jwks", "key": "jwks.json"}}}`, JwtIssuer))
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "jwks", Namespace: ApiNamespace},
+				Data:       map[string][]byte{"jwks.json": []byte(jwks)},
+			}
+			client := GetFakeClient(GetService(ServiceName), secret)
+			processor := istio.NewRequestAuthenticationProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+			ra := result[0].Obj.(*securityv1beta1.RequestAuthentication)
+			Expect(ra.Spec.JwtRules).To(HaveLen(1))
+			Expect(ra.Spec.JwtRules[0].Jwks).To(Equal(jwks))
+		})
+
+		It("should create RA with the JWKS read from the referenced ConfigMap", func() {
+			// given
+			rule := getJwksRule(fmt.Sprintf(`{"issuer": "%s", "jwksFrom": {"configMapKeyRef": {"name": "jwks", "key": "jwks.json"}}}`, JwtIssuer))
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "jwks", Namespace: ApiNamespace},
+				Data:       map[string]string{"jwks.json": jwks},
+			}
+			client := GetFakeClient(GetService(ServiceName), configMap)
+			processor := istio.NewRequestAuthenticationProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+			ra := result[0].Obj.(*securityv1beta1.RequestAuthentication)
+			Expect(ra.Spec.JwtRules).To(HaveLen(1))
+			Expect(ra.Spec.JwtRules[0].Jwks).To(Equal(jwks))
+		})
+
+		It("should fail when the referenced Secret doesn't have the key", func() {
+			// given
+			rule := getJwksRule(fmt.Sprintf(`{"issuer": "%s", "jwksFrom": {"secretKeyRef": {"name": "jwks", "key": "jwks.json"}}}`, JwtIssuer))
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "jwks", Namespace: ApiNamespace},
+				Data:       map[string][]byte{"other": []byte(jwks)},
+			}
+			client := GetFakeClient(GetService(ServiceName), secret)
+			processor := istio.NewRequestAuthenticationProcessor(GetTestConfig())
+
+			// when
+			_, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(MatchError(fmt.Sprintf("key jwks.json not found in Secret %s/jwks", ApiNamespace)))
+		})
+	})
 })
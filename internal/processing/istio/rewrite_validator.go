@@ -0,0 +1,56 @@
+package istio
+
+import (
+	"fmt"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+type rewriteValidator struct{}
+
+// Validate rejects rewriting the URI or the authority for rules routed through Oathkeeper, since Oathkeeper matches the
+// original URL of the request and supports stripping a path prefix only.
+func (v *rewriteValidator) Validate(attributePath string, rule gatewayv1beta1.Rule) []validation.Failure {
+	if rule.Rewrite == nil || !processing.IsSecured(rule) || processing.IsIstioSecured(rule) {
+		return nil
+	}
+
+	var problems []validation.Failure
+	if rule.Rewrite.URI != "" {
+		problems = append(problems, validation.Failure{AttributePath: attributePath + ".uri", Message: "Rewriting the URI is not supported for access strategies handled by Oathkeeper"})
+	}
+	if rule.Rewrite.Authority != "" {
+		problems = append(problems, validation.Failure{AttributePath: attributePath + ".authority", Message: "Rewriting the authority is not supported for access strategies handled by Oathkeeper"})
+	}
+
+	return problems
+}
+
+// validateRewrites rejects rewriting the path of rules enforced by AuthorizationPolicies. The sidecar of the workload
+// receives the rewritten path, but the AuthorizationPolicies and the introspection authorizer match the original path of
+// the rule, so the rewritten requests would be denied or allowed by the policy of another rule.
+func validateRewrites(attrPath string, rules []gatewayv1beta1.Rule) []validation.Failure {
+	var failures []validation.Failure
+
+	requiresAuthorizationPolicies := processing.RequiresAuthorizationPolicies(rules)
+	for i, rule := range rules {
+		if rule.Rewrite == nil || !processing.RoutesToService(rule) {
+			continue
+		}
+		if !requiresAuthorizationPolicies && !processing.IsExtAuthSecured(rule) && !processing.IsIntrospectionSecured(rule) {
+			continue
+		}
+
+		attributePath := fmt.Sprintf("%s[%d].rewrite", attrPath, i)
+		if rule.Rewrite.URI != "" {
+			failures = append(failures, validation.Failure{AttributePath: attributePath + ".uri", Message: "Rewriting the path is not supported for rules enforced by AuthorizationPolicies"})
+		}
+		if rule.Rewrite.StripPrefix != "" {
+			failures = append(failures, validation.Failure{AttributePath: attributePath + ".stripPrefix", Message: "Rewriting the path is not supported for rules enforced by AuthorizationPolicies"})
+		}
+	}
+
+	return failures
+}
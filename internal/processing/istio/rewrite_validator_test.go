@@ -0,0 +1,128 @@
+package istio
+
+import (
+	"github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rewrite validator", func() {
+
+	rewrite := &v1beta1.Rewrite{URI: "/items", Authority: "orders.internal"}
+
+	It("Should succeed for rule with jwt access strategy", func() {
+		//given
+		rule := v1beta1.Rule{
+			AccessStrategies: []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "jwt"}}},
+			Rewrite:          rewrite,
+		}
+
+		//when
+		problems := (&rewriteValidator{}).Validate("some.attribute", rule)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should succeed for stripping the prefix in rule with access strategy handled by Oathkeeper", func() {
+		//given
+		rule := v1beta1.Rule{
+			AccessStrategies: []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "noop"}}},
+			Rewrite:          &v1beta1.Rewrite{StripPrefix: "/orders/v1"},
+		}
+
+		//when
+		problems := (&rewriteValidator{}).Validate("some.attribute", rule)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for rewriting the URI and authority in rule with access strategy handled by Oathkeeper", func() {
+		//given
+		rule := v1beta1.Rule{
+			AccessStrategies: []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "noop"}}},
+			Rewrite:          rewrite,
+		}
+
+		//when
+		problems := (&rewriteValidator{}).Validate("some.attribute", rule)
+
+		//then
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.uri"))
+		Expect(problems[0].Message).To(Equal("Rewriting the URI is not supported for access strategies handled by Oathkeeper"))
+		Expect(problems[1].AttributePath).To(Equal("some.attribute.authority"))
+	})
+
+	Context("rules enforced by AuthorizationPolicies", func() {
+		It("Should fail for rewriting the path of rules in an APIRule with the jwt access strategy", func() {
+			//given
+			jwtRule := v1beta1.Rule{
+				Path:             "/orders/.*",
+				AccessStrategies: []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "jwt"}}},
+				Rewrite:          &v1beta1.Rewrite{URI: "/items"},
+			}
+			allowRule := v1beta1.Rule{
+				Path:             "/public/.*",
+				AccessStrategies: []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "allow"}}},
+				Rewrite:          &v1beta1.Rewrite{StripPrefix: "/public"},
+			}
+
+			//when
+			problems := validateRewrites(".spec.rules", []v1beta1.Rule{jwtRule, allowRule})
+
+			//then
+			Expect(problems).To(HaveLen(2))
+			Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].rewrite.uri"))
+			Expect(problems[0].Message).To(Equal("Rewriting the path is not supported for rules enforced by AuthorizationPolicies"))
+			Expect(problems[1].AttributePath).To(Equal(".spec.rules[1].rewrite.stripPrefix"))
+		})
+
+		It("Should fail for rewriting the path of a rule with the extAuth access strategy", func() {
+			//given
+			rule := v1beta1.Rule{
+				Path:             "/orders/.*",
+				AccessStrategies: []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "extAuth"}}},
+				Rewrite:          &v1beta1.Rewrite{StripPrefix: "/orders"},
+			}
+
+			//when
+			problems := validateRewrites(".spec.rules", []v1beta1.Rule{rule})
+
+			//then
+			Expect(problems).To(HaveLen(1))
+			Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].rewrite.stripPrefix"))
+		})
+
+		It("Should succeed for rewriting the authority of rules in an APIRule with the jwt access strategy", func() {
+			//given
+			rule := v1beta1.Rule{
+				Path:             "/orders/.*",
+				AccessStrategies: []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "jwt"}}},
+				Rewrite:          &v1beta1.Rewrite{Authority: "orders.internal"},
+			}
+
+			//when
+			problems := validateRewrites(".spec.rules", []v1beta1.Rule{rule})
+
+			//then
+			Expect(problems).To(BeEmpty())
+		})
+
+		It("Should succeed for rewriting the path of rules in an APIRule without AuthorizationPolicies", func() {
+			//given
+			rule := v1beta1.Rule{
+				Path:             "/public/.*",
+				AccessStrategies: []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "allow"}}},
+				Rewrite:          &v1beta1.Rewrite{URI: "/"},
+			}
+
+			//when
+			problems := validateRewrites(".spec.rules", []v1beta1.Rule{rule})
+
+			//then
+			Expect(problems).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,40 @@
+package istio
+
+import (
+	"github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Services validator", func() {
+
+	services := []*v1beta1.WeightedService{{Weight: 50}, {Weight: 50}}
+
+	It("Should succeed for rule with jwt access strategy", func() {
+		//given
+		rule := v1beta1.Rule{
+			AccessStrategies: []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "jwt"}}},
+		}
+
+		//when
+		problems := (&servicesValidator{}).Validate("some.attribute", rule, services)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for rule with access strategy handled by Oathkeeper", func() {
+		//given
+		rule := v1beta1.Rule{
+			AccessStrategies: []*v1beta1.Authenticator{{Handler: &v1beta1.Handler{Name: "noop"}}},
+		}
+
+		//when
+		problems := (&servicesValidator{}).Validate("some.attribute", rule, services)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.services"))
+		Expect(problems[0].Message).To(Equal("Splitting the traffic between several services is only supported for allow, jwt and extAuth access strategies"))
+	})
+})
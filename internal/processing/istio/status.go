@@ -23,5 +23,8 @@ func StatusBase(statusCode gatewayv1beta1.StatusCode) processing.ReconciliationS
 		RequestAuthenticationStatus: &gatewayv1beta1.APIRuleResourceStatus{
 			Code: statusCode,
 		},
+		EnvoyFilterStatus: &gatewayv1beta1.APIRuleResourceStatus{
+			Code: statusCode,
+		},
 	}
 }
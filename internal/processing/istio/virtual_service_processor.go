@@ -2,10 +2,12 @@ package istio
 
 import (
 	"fmt"
+	"time"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	"github.com/kyma-project/api-gateway/internal/builders"
 	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/introspection"
 	"github.com/kyma-project/api-gateway/internal/processing"
 	"github.com/kyma-project/api-gateway/internal/processing/processors"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
@@ -36,58 +38,97 @@ type virtualServiceCreator struct {
 func (r virtualServiceCreator) Create(api *gatewayv1beta1.APIRule) (*networkingv1beta1.VirtualService, error) {
 	virtualServiceNamePrefix := fmt.Sprintf("%s-", api.ObjectMeta.Name)
 
-	vsSpecBuilder := builders.VirtualServiceSpec()
-	vsSpecBuilder.Host(helpers.GetHostWithDomain(*api.Spec.Host, r.defaultDomainName))
-	vsSpecBuilder.Gateway(*api.Spec.Gateway)
-	filteredRules := processing.FilterDuplicatePaths(api.Spec.Rules)
+	hosts := helpers.GetAPIRuleHosts(api.Spec, r.defaultDomainName)
 
-	for _, rule := range filteredRules {
+	vsSpecBuilder := builders.VirtualServiceSpec()
+	for _, host := range hosts {
+		vsSpecBuilder.Host(host)
+	}
+	for _, gateway := range api.Spec.GetGateways() {
+		vsSpecBuilder.Gateway(gateway)
+	}
+	for _, routeRule := range processing.GetHttpRouteRules(api.Spec.Rules) {
+		rule := routeRule.Rule
 		httpRouteBuilder := builders.HTTPRoute()
-		serviceNamespace := helpers.FindServiceNamespace(api, &rule)
 		routeDirectlyToService := false
 		if !processing.IsSecured(rule) {
 			routeDirectlyToService = true
-		} else if processing.IsJwtSecured(rule) {
+		} else if processing.IsIstioSecured(rule) {
 			routeDirectlyToService = true
 		}
 
-		var host string
-		var port uint32
-
-		if routeDirectlyToService {
-			// Use rule level service if it exists
-			if rule.Service != nil {
-				host = helpers.GetHostLocalDomain(*rule.Service.Name, serviceNamespace)
-				port = *rule.Service.Port
-			} else {
-				// Otherwise use service defined on APIRule spec level
-				host = helpers.GetHostLocalDomain(*api.Spec.Service.Name, serviceNamespace)
-				port = *api.Spec.Service.Port
+		switch {
+		case rule.Redirect != nil:
+			httpRouteBuilder.Redirect(builders.Redirect().
+				Uri(rule.Redirect.URI).
+				Authority(rule.Redirect.Authority).
+				Scheme(rule.Redirect.Scheme).
+				Code(rule.Redirect.Code))
+		case rule.DirectResponse != nil:
+			httpRouteBuilder.DirectResponse(builders.DirectResponse().
+				Status(rule.DirectResponse.Status).
+				Body(rule.DirectResponse.Body))
+		case routeDirectlyToService:
+			// Rule level services take precedence over the services defined on APIRule spec level
+			for _, service := range helpers.GetRuleServices(api, &rule) {
+				host := helpers.GetHostLocalDomain(*service.Name, *service.Namespace)
+				httpRouteBuilder.Route(builders.RouteDestination().Host(host).Port(*service.Port).Weight(int32(service.Weight)))
 			}
-		} else {
-			host = r.oathkeeperSvc
-			port = r.oathkeeperSvcPort
+		default:
+			httpRouteBuilder.Route(builders.RouteDestination().Host(r.oathkeeperSvc).Port(r.oathkeeperSvcPort))
 		}
 
-		httpRouteBuilder.Route(builders.RouteDestination().Host(host).Port(port))
-
-		if rule.Path == "/*" {
-			httpRouteBuilder.Match(builders.MatchRequest().Uri().Prefix("/"))
+		matchRequest := builders.MatchRequest()
+		if pathRegex := helpers.GetPathRegex(rule); pathRegex == "/*" {
+			matchRequest.Uri().Prefix("/")
 		} else {
-			httpRouteBuilder.Match(builders.MatchRequest().Uri().Regex(rule.Path))
+			matchRequest.Uri().Regex(pathRegex)
+		}
+		for name, match := range rule.Headers {
+			matchRequest.Header(name).From(match)
+		}
+		for name, match := range rule.QueryParams {
+			matchRequest.QueryParam(name).From(match)
+		}
+		if len(rule.Gateways) > 0 {
+			matchRequest.Gateways(rule.Gateways...)
+		}
+		if len(routeRule.MatchMethods) > 0 {
+			matchRequest.Method(routeRule.MatchMethods...)
 		}
+		httpRouteBuilder.Match(matchRequest)
+		corsConfig := processors.GetVirtualServiceCorsConfig(r.corsConfig, api.Spec, rule)
 		httpRouteBuilder.CorsPolicy(builders.CorsPolicy().
-			AllowOrigins(r.corsConfig.AllowOrigins...).
-			AllowMethods(r.corsConfig.AllowMethods...).
-			AllowHeaders(r.corsConfig.AllowHeaders...))
+			AllowOrigins(corsConfig.AllowOrigins...).
+			AllowMethods(corsConfig.AllowMethods...).
+			AllowHeaders(corsConfig.AllowHeaders...).
+			ExposeHeaders(corsConfig.ExposeHeaders...).
+			AllowCredentials(corsConfig.AllowCredentials).
+			MaxAge(corsConfig.MaxAge))
 		httpRouteBuilder.Timeout(processors.GetVirtualServiceHttpTimeout(api.Spec, rule))
 
+		if retries := processors.GetVirtualServiceHttpRetries(api.Spec, rule); retries != nil {
+			retryPolicy := builders.RetryPolicy().Attempts(retries.Attempts).RetryOn(retries.RetryOn)
+			if retries.PerTryTimeout != nil {
+				retryPolicy.PerTryTimeout(time.Duration(*retries.PerTryTimeout) * time.Second)
+			}
+			httpRouteBuilder.Retries(retryPolicy)
+		}
+
+		// Requests routed through Oathkeeper are rewritten by the access rule, since Oathkeeper matches the original URL
+		if routeDirectlyToService && rule.Rewrite != nil {
+			httpRouteBuilder.Rewrite(builders.Rewrite().
+				StripPrefix(rule.Rewrite.StripPrefix).
+				Uri(rule.Rewrite.URI).
+				Authority(rule.Rewrite.Authority))
+		}
+
 		headersBuilder := builders.NewHttpRouteHeadersBuilder().
-			SetHostHeader(helpers.GetHostWithDomain(*api.Spec.Host, r.defaultDomainName))
+			SetHostHeader(processing.GetForwardedHost(hosts))
 
-		// We need to add mutators only for JWT secured rules, since "noop" and "oauth2_introspection" access strategies
-		// create access rules and therefore use ory mutators. The "allow" access strategy does not support mutators at all.
-		if processing.IsJwtSecured(rule) {
+		// We need to add mutators only for rules secured by Istio, since "noop" and "oauth2_introspection" access strategies
+		// without introspection URL create access rules and therefore use ory mutators. The "allow" access strategy does not support mutators at all.
+		if processing.IsIstioSecured(rule) {
 			cookieMutator, err := rule.GetCookieMutator()
 			if err != nil {
 				return nil, err
@@ -105,6 +146,22 @@ func (r virtualServiceCreator) Create(api *gatewayv1beta1.APIRule) (*networkingv
 			}
 		}
 
+		// Requests without a JWT are allowed for rules with optional JWT, so the headers the claims are forwarded in are
+		// removed before the request reaches the workload. Otherwise, anonymous clients could set them on their own, since
+		// they are only overwritten if the request has a JWT.
+		if claimHeaders := processing.GetOptionalJwtClaimHeaders(rule); len(claimHeaders) > 0 {
+			headersBuilder.RemoveRequestHeaders(claimHeaders...)
+		}
+
+		// The introspection authorizer looks up the configuration of the rule referenced by this header, since only the
+		// route knows which rule matched the headers, query parameters and gateway of the request. It is set after the
+		// mutators, so that it can't be overwritten.
+		if processing.IsIntrospectionSecured(rule) {
+			headersBuilder.SetRequestHeaders(map[string]string{
+				introspection.RuleHeader: fmt.Sprintf("%s/%s/%d", api.ObjectMeta.Namespace, api.ObjectMeta.Name, routeRule.Index),
+			})
+		}
+
 		httpRouteBuilder.Headers(headersBuilder.Get())
 
 		vsSpecBuilder.HTTP(httpRouteBuilder)
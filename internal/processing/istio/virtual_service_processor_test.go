@@ -70,6 +70,162 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.ObjectMeta.Labels[TestLabelKey]).To(Equal(TestLabelValue))
 		})
 
+		It("should split the traffic between weighted services defined on spec level", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			canaryServiceName := "canary-service"
+			allowRule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{allowRule})
+			apiRule.Spec.Service = nil
+			apiRule.Spec.Services = []*gatewayv1beta1.WeightedService{
+				{Service: gatewayv1beta1.Service{Name: &ServiceName, Port: &ServicePort}, Weight: 80},
+				{Service: gatewayv1beta1.Service{Name: &canaryServiceName, Port: &ServicePort}, Weight: 20},
+			}
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Route).To(HaveLen(2))
+			Expect(vs.Spec.Http[0].Route[0].Destination.Host).To(Equal(ServiceName + "." + ApiNamespace + ".svc.cluster.local"))
+			Expect(vs.Spec.Http[0].Route[0].Weight).To(BeEquivalentTo(80))
+			Expect(vs.Spec.Http[0].Route[1].Destination.Host).To(Equal(canaryServiceName + "." + ApiNamespace + ".svc.cluster.local"))
+			Expect(vs.Spec.Http[0].Route[1].Weight).To(BeEquivalentTo(20))
+		})
+
+		It("should override CORS policy with rule level CORS policy", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			allowCredentials := true
+			var maxAge uint64 = 60
+			allowRule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			allowRule.Cors = &gatewayv1beta1.CorsPolicy{
+				AllowOrigins:     []*gatewayv1beta1.StringMatch{{Exact: "https://example.com"}},
+				ExposeHeaders:    []string{"X-Request-Id"},
+				AllowCredentials: &allowCredentials,
+				MaxAge:           &maxAge,
+			}
+			rules := []gatewayv1beta1.Rule{allowRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http[0].CorsPolicy.AllowOrigins).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].CorsPolicy.AllowOrigins[0].GetExact()).To(Equal("https://example.com"))
+			Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
+			Expect(vs.Spec.Http[0].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
+			Expect(vs.Spec.Http[0].CorsPolicy.ExposeHeaders).To(Equal([]string{"X-Request-Id"}))
+			Expect(vs.Spec.Http[0].CorsPolicy.AllowCredentials.GetValue()).To(BeTrue())
+			Expect(vs.Spec.Http[0].CorsPolicy.MaxAge.AsDuration()).To(Equal(time.Minute))
+		})
+
+		It("should set rule level retry policy over spec level retry policy", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			var perTryTimeout gatewayv1beta1.Timeout = 2
+			allowRule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			allowRule.Retries = &gatewayv1beta1.Retries{Attempts: 3, PerTryTimeout: &perTryTimeout, RetryOn: "5xx"}
+			otherRule := GetRuleFor("/other", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			rules := []gatewayv1beta1.Rule{allowRule, otherRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.Retries = &gatewayv1beta1.Retries{Attempts: 0}
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(2))
+			Expect(vs.Spec.Http[0].Retries.Attempts).To(BeEquivalentTo(3))
+			Expect(vs.Spec.Http[0].Retries.PerTryTimeout.AsDuration()).To(Equal(2 * time.Second))
+			Expect(vs.Spec.Http[0].Retries.RetryOn).To(Equal("5xx"))
+			Expect(vs.Spec.Http[1].Retries.Attempts).To(BeEquivalentTo(0))
+			Expect(vs.Spec.Http[1].Retries.PerTryTimeout).To(BeNil())
+		})
+
+		It("should rewrite the URI and authority of the route", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			allowRule := GetRuleFor("/orders/v1/.*", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			allowRule.Rewrite = &gatewayv1beta1.Rewrite{StripPrefix: "/orders/v1", Authority: "orders.internal"}
+			otherRule := GetRuleFor("/other", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			otherRule.Rewrite = &gatewayv1beta1.Rewrite{URI: "/"}
+			rules := []gatewayv1beta1.Rule{allowRule, otherRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(2))
+			Expect(vs.Spec.Http[0].Rewrite.UriRegexRewrite.Match).To(Equal(`^/orders/v1(/|$)`))
+			Expect(vs.Spec.Http[0].Rewrite.UriRegexRewrite.Rewrite).To(Equal("/"))
+			Expect(vs.Spec.Http[0].Rewrite.Authority).To(Equal("orders.internal"))
+			Expect(vs.Spec.Http[1].Rewrite.Uri).To(Equal("/"))
+			Expect(vs.Spec.Http[1].Rewrite.UriRegexRewrite).To(BeNil())
+		})
+
 		It("should override destination host for specified spec level service namespace", func() {
 			// given
 			strategies := []*gatewayv1beta1.Authenticator{
@@ -186,6 +342,531 @@ var _ = Describe("Virtual Service Processor", func() {
 		})
 	})
 
+	When("handler is extAuth", func() {
+		It("should route the requests directly to the service and set the header mutator", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name:   "extAuth",
+						Config: &runtime.RawExtension{Raw: []byte(`{"provider": "opa"}`)},
+					},
+				},
+			}
+			mutators := []*gatewayv1beta1.Mutator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name:   gatewayv1beta1.HeaderMutator,
+						Config: &runtime.RawExtension{Raw: []byte(`{"headers": {"X-Some-Data": "some-data"}}`)},
+					},
+				},
+			}
+
+			extAuthRule := GetRuleFor(ApiPath, ApiMethods, mutators, strategies)
+			rules := []gatewayv1beta1.Rule{extAuthRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Route).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Route[0].Destination.Host).To(Equal(ServiceName + "." + ApiNamespace + ".svc.cluster.local"))
+			Expect(vs.Spec.Http[0].Headers.Request.Set).To(HaveKeyWithValue("X-Some-Data", "some-data"))
+		})
+	})
+
+	When("handler is jwt with optional JWT", func() {
+		It("should remove the headers the claims are forwarded in", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "jwt",
+						Config: &runtime.RawExtension{Raw: []byte(`{"optional": true, "authentications": [{"issuer": "https://issuer", "jwksUri": "https://issuer/jwks",
+							"outputClaimToHeaders": [{"header": "x-user-id", "claim": "sub"}, {"header": "x-tenant", "claim": "tenant"}]}]}`)},
+					},
+				},
+			}
+			mutators := []*gatewayv1beta1.Mutator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name:   gatewayv1beta1.HeaderMutator,
+						Config: &runtime.RawExtension{Raw: []byte(`{"headers": {"X-Some-Data": "some-data"}}`)},
+					},
+				},
+			}
+
+			optionalJwtRule := GetRuleFor(ApiPath, ApiMethods, mutators, strategies)
+			rules := []gatewayv1beta1.Rule{optionalJwtRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Headers.Request.Remove).To(Equal([]string{"x-tenant", "x-user-id"}))
+			Expect(vs.Spec.Http[0].Headers.Request.Set).To(HaveKeyWithValue("X-Some-Data", "some-data"))
+		})
+
+		It("should not remove the claim headers if the JWT is required", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "jwt",
+						Config: &runtime.RawExtension{Raw: []byte(`{"authentications": [{"issuer": "https://issuer", "jwksUri": "https://issuer/jwks",
+							"outputClaimToHeaders": [{"header": "x-user-id", "claim": "sub"}]}]}`)},
+					},
+				},
+			}
+
+			jwtRule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			rules := []gatewayv1beta1.Rule{jwtRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Headers.Request.Remove).To(BeEmpty())
+		})
+	})
+
+	When("handler is oauth2_introspection", func() {
+		It("should route the requests directly to the service and reference the APIRule if the introspection URL is configured", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name:   "oauth2_introspection",
+						Config: &runtime.RawExtension{Raw: []byte(`{"introspectionUrl": "https://auth.example.com/introspect"}`)},
+					},
+				},
+			}
+			mutators := []*gatewayv1beta1.Mutator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name:   gatewayv1beta1.HeaderMutator,
+						Config: &runtime.RawExtension{Raw: []byte(`{"headers": {"x-api-gateway-introspection": "other/rule"}}`)},
+					},
+				},
+			}
+
+			rule := GetRuleFor(ApiPath, ApiMethods, mutators, strategies)
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Route[0].Destination.Host).To(Equal(ServiceName + "." + ApiNamespace + ".svc.cluster.local"))
+			Expect(vs.Spec.Http[0].Headers.Request.Set).To(HaveKeyWithValue("x-api-gateway-introspection", ApiNamespace+"/"+ApiName+"/0"))
+		})
+
+		It("should reference the index of the rule of the route if rules match the same path with different headers", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name:   "oauth2_introspection",
+						Config: &runtime.RawExtension{Raw: []byte(`{"introspectionUrl": "https://auth.example.com/introspect"}`)},
+					},
+				},
+			}
+
+			rule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			versionedRule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			versionedRule.Headers = map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule, versionedRule})
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(2))
+			Expect(vs.Spec.Http[0].Match[0].Headers).To(HaveKey("x-api-version"))
+			Expect(vs.Spec.Http[0].Headers.Request.Set).To(HaveKeyWithValue("x-api-gateway-introspection", ApiNamespace+"/"+ApiName+"/1"))
+			Expect(vs.Spec.Http[1].Match[0].Headers).To(BeEmpty())
+			Expect(vs.Spec.Http[1].Headers.Request.Set).To(HaveKeyWithValue("x-api-gateway-introspection", ApiNamespace+"/"+ApiName+"/0"))
+		})
+
+		It("should route the requests through Oathkeeper if no introspection URL is configured", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name:   "oauth2_introspection",
+						Config: &runtime.RawExtension{Raw: []byte(`{"required_scope": ["read"]}`)},
+					},
+				},
+			}
+
+			rule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http[0].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
+			Expect(vs.Spec.Http[0].Headers.Request.Set).NotTo(HaveKey("x-api-gateway-introspection"))
+		})
+	})
+
+	When("rule returns a redirect or a direct response", func() {
+		It("should create routes without destination", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			redirectRule := GetRuleFor("/old/.*", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			redirectRule.Redirect = &gatewayv1beta1.Redirect{URI: "/new", Authority: "example.com", Scheme: "https", Code: 308}
+			directResponseRule := GetRuleFor("/robots.txt", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			directResponseRule.DirectResponse = &gatewayv1beta1.DirectResponse{Status: 200, Body: "User-agent: *"}
+			rules := []gatewayv1beta1.Rule{redirectRule, directResponseRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(2))
+			Expect(vs.Spec.Http[0].Route).To(BeEmpty())
+			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal("/old/.*"))
+			Expect(vs.Spec.Http[0].Redirect.Uri).To(Equal("/new"))
+			Expect(vs.Spec.Http[0].Redirect.Authority).To(Equal("example.com"))
+			Expect(vs.Spec.Http[0].Redirect.Scheme).To(Equal("https"))
+			Expect(vs.Spec.Http[0].Redirect.RedirectCode).To(BeEquivalentTo(308))
+			Expect(vs.Spec.Http[1].Route).To(BeEmpty())
+			Expect(vs.Spec.Http[1].DirectResponse.Status).To(BeEquivalentTo(200))
+			Expect(vs.Spec.Http[1].DirectResponse.Body.GetString_()).To(Equal("User-agent: *"))
+		})
+	})
+
+	When("rules share a path", func() {
+		It("should route the methods of the rules to their services", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			port := uint32(8080)
+			readerName, writerName := "reader", "writer"
+			readerRule := GetRuleWithServiceFor("/items", []string{"GET"}, []*gatewayv1beta1.Mutator{}, strategies, &gatewayv1beta1.Service{Name: &readerName, Port: &port})
+			writerRule := GetRuleWithServiceFor("/items", []string{"POST", "PUT"}, []*gatewayv1beta1.Mutator{}, strategies, &gatewayv1beta1.Service{Name: &writerName, Port: &port})
+			rules := []gatewayv1beta1.Rule{readerRule, writerRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(2))
+			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal("/items"))
+			Expect(vs.Spec.Http[0].Match[0].Method.GetRegex()).To(Equal("POST|PUT"))
+			Expect(vs.Spec.Http[0].Route[0].Destination.Host).To(Equal("writer." + ApiNamespace + ".svc.cluster.local"))
+			Expect(vs.Spec.Http[1].Match[0].Uri.GetRegex()).To(Equal("/items"))
+			Expect(vs.Spec.Http[1].Match[0].Method).To(BeNil())
+			Expect(vs.Spec.Http[1].Route[0].Destination.Host).To(Equal("reader." + ApiNamespace + ".svc.cluster.local"))
+		})
+	})
+
+	When("rules use path types", func() {
+		It("should match the paths with regular expressions", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			exactRule := GetRuleFor("/users/{id}", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			exactRule.PathType = gatewayv1beta1.PathTypeExact
+			prefixRule := GetRuleFor("/static.v1/", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			prefixRule.PathType = gatewayv1beta1.PathTypePrefix
+			templatedPrefixRule := GetRuleFor("/items/{id}/", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			templatedPrefixRule.PathType = gatewayv1beta1.PathTypePrefix
+			rules := []gatewayv1beta1.Rule{exactRule, prefixRule, templatedPrefixRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(3))
+			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal("/users/[^/]+"))
+			Expect(vs.Spec.Http[1].Match[0].Uri.GetRegex()).To(Equal(`/static\.v1/.*`))
+			Expect(vs.Spec.Http[2].Match[0].Uri.GetRegex()).To(Equal("/items/[^/]+(/.*)?"))
+		})
+	})
+
+	When("rules match on headers and query parameters", func() {
+		It("should create a route for each match and order routes with conditions first", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			defaultRule := GetRuleFor("/orders", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			headerRule := GetRuleFor("/orders", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			headerRule.Headers = map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Prefix: "2"}}
+			headerRule.QueryParams = map[string]*gatewayv1beta1.StringMatch{"beta": {Exact: "true"}}
+			rules := []gatewayv1beta1.Rule{defaultRule, headerRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(2))
+			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal("/orders"))
+			Expect(vs.Spec.Http[0].Match[0].Headers["x-api-version"].GetPrefix()).To(Equal("2"))
+			Expect(vs.Spec.Http[0].Match[0].QueryParams["beta"].GetExact()).To(Equal("true"))
+			Expect(vs.Spec.Http[1].Match[0].Uri.GetRegex()).To(Equal("/orders"))
+			Expect(vs.Spec.Http[1].Match[0].Headers).To(BeEmpty())
+			Expect(vs.Spec.Http[1].Match[0].QueryParams).To(BeEmpty())
+		})
+	})
+
+	When("multiple hosts are defined", func() {
+		It("should create VS with all hosts and forward the request authority as host", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			allowRule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			rules := []gatewayv1beta1.Rule{allowRule}
+
+			additionalHost := gatewayv1beta1.Host("myService.otherDomain.com")
+			additionalHostWithNoDomain := gatewayv1beta1.Host("otherService")
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.Hosts = []*gatewayv1beta1.Host{&additionalHost, &additionalHostWithNoDomain}
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Hosts).To(Equal([]string{ServiceHost, "myService.otherDomain.com", "otherService." + DefaultDomain}))
+			Expect(vs.Spec.Http[0].Headers.Request.Set).To(HaveKeyWithValue("x-forwarded-host", "%REQ(:authority)%"))
+		})
+	})
+
+	When("the service is exposed to the mesh", func() {
+		It("should create VS for the mesh gateway with the hosts not completed with the default domain", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			allowRule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			rules := []gatewayv1beta1.Rule{allowRule}
+
+			meshHost := "orders"
+			meshGateway := gatewayv1beta1.MeshGateway
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.Host = &meshHost
+			apiRule.Spec.Gateway = &meshGateway
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Gateways).To(Equal([]string{"mesh"}))
+			Expect(vs.Spec.Hosts).To(Equal([]string{"orders"}))
+			Expect(vs.Spec.Http[0].Headers.Request.Set).To(HaveKeyWithValue("x-forwarded-host", "orders"))
+		})
+
+		It("should add the mesh gateway and the mesh hosts to the VS if mesh hosts are defined", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			allowRule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			rules := []gatewayv1beta1.Rule{allowRule}
+
+			meshHost := gatewayv1beta1.Host("orders.internal")
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.MeshHosts = []*gatewayv1beta1.Host{&meshHost}
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Gateways).To(Equal([]string{ApiGateway, "mesh"}))
+			Expect(vs.Spec.Hosts).To(Equal([]string{ServiceHost, "orders.internal"}))
+			Expect(vs.Spec.Http[0].Headers.Request.Set).To(HaveKeyWithValue("x-forwarded-host", "%REQ(:authority)%"))
+		})
+	})
+
+	When("rules are restricted to gateways", func() {
+		It("should create VS with all gateways and match the routes of the restricted rules on their gateways", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			publicRule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			partnerRule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			partnerRule.Gateways = []string{"partners/partner-gateway"}
+			rules := []gatewayv1beta1.Rule{publicRule, partnerRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.Gateways = []string{"partners/partner-gateway"}
+			client := GetFakeClient()
+			processor := istio.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Gateways).To(Equal([]string{ApiGateway, "partners/partner-gateway"}))
+			Expect(vs.Spec.Http).To(HaveLen(2))
+			Expect(vs.Spec.Http[0].Match[0].Gateways).To(Equal([]string{"partners/partner-gateway"}))
+			Expect(vs.Spec.Http[1].Match[0].Gateways).To(BeEmpty())
+		})
+	})
+
 	When("handler is noop", func() {
 		It("should not override Oathkeeper service destination host with spec level service", func() {
 			// given
@@ -454,18 +1135,26 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(len(vs.Spec.Gateways)).To(Equal(1))
 			Expect(len(vs.Spec.Hosts)).To(Equal(1))
 			Expect(vs.Spec.Hosts[0]).To(Equal(ServiceHost))
-			Expect(len(vs.Spec.Http)).To(Equal(1))
+			Expect(len(vs.Spec.Http)).To(Equal(2))
 
 			Expect(len(vs.Spec.Http[0].Route)).To(Equal(1))
-			Expect(vs.Spec.Http[0].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
-			Expect(vs.Spec.Http[0].Route[0].Destination.Port.Number).To(Equal(OathkeeperSvcPort))
+			Expect(vs.Spec.Http[0].Route[0].Destination.Host).To(Equal(ServiceName + "." + ApiNamespace + ".svc.cluster.local"))
+			Expect(vs.Spec.Http[0].Route[0].Destination.Port.Number).To(Equal(ServicePort))
 			Expect(len(vs.Spec.Http[0].Match)).To(Equal(1))
-			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[0].Path))
+			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[1].Path))
+			Expect(vs.Spec.Http[0].Match[0].Method.GetExact()).To(Equal("POST"))
 
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowOrigins).To(Equal(TestCors.AllowOrigins))
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
+			Expect(len(vs.Spec.Http[1].Route)).To(Equal(1))
+			Expect(vs.Spec.Http[1].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
+			Expect(vs.Spec.Http[1].Route[0].Destination.Port.Number).To(Equal(OathkeeperSvcPort))
+			Expect(len(vs.Spec.Http[1].Match)).To(Equal(1))
+			Expect(vs.Spec.Http[1].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[0].Path))
+			Expect(vs.Spec.Http[1].Match[0].Method).To(BeNil())
+
 			Expect(vs.ObjectMeta.Name).To(BeEmpty())
 			Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
 			Expect(vs.ObjectMeta.Namespace).To(Equal(ApiNamespace))
@@ -536,27 +1225,33 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(len(vs.Spec.Gateways)).To(Equal(1))
 			Expect(len(vs.Spec.Hosts)).To(Equal(1))
 			Expect(vs.Spec.Hosts[0]).To(Equal(ServiceHost))
-			Expect(len(vs.Spec.Http)).To(Equal(2))
+			Expect(len(vs.Spec.Http)).To(Equal(3))
 
 			Expect(len(vs.Spec.Http[0].Route)).To(Equal(1))
 			Expect(vs.Spec.Http[0].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
 			Expect(vs.Spec.Http[0].Route[0].Destination.Port.Number).To(Equal(OathkeeperSvcPort))
 			Expect(len(vs.Spec.Http[0].Match)).To(Equal(1))
-			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[0].Path))
+			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[1].Path))
+			Expect(vs.Spec.Http[0].Match[0].Method.GetExact()).To(Equal("POST"))
+
+			Expect(len(vs.Spec.Http[1].Route)).To(Equal(1))
+			Expect(vs.Spec.Http[1].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
+			Expect(vs.Spec.Http[1].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[0].Path))
+			Expect(vs.Spec.Http[1].Match[0].Method).To(BeNil())
 
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowOrigins).To(Equal(TestCors.AllowOrigins))
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-			Expect(len(vs.Spec.Http[1].Route)).To(Equal(1))
-			Expect(vs.Spec.Http[1].Route[0].Destination.Host).To(Equal(ServiceName + "." + ApiNamespace + ".svc.cluster.local"))
-			Expect(vs.Spec.Http[1].Route[0].Destination.Port.Number).To(Equal(ServicePort))
-			Expect(len(vs.Spec.Http[1].Match)).To(Equal(1))
-			Expect(vs.Spec.Http[1].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[2].Path))
+			Expect(len(vs.Spec.Http[2].Route)).To(Equal(1))
+			Expect(vs.Spec.Http[2].Route[0].Destination.Host).To(Equal(ServiceName + "." + ApiNamespace + ".svc.cluster.local"))
+			Expect(vs.Spec.Http[2].Route[0].Destination.Port.Number).To(Equal(ServicePort))
+			Expect(len(vs.Spec.Http[2].Match)).To(Equal(1))
+			Expect(vs.Spec.Http[2].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[2].Path))
 
-			Expect(vs.Spec.Http[1].CorsPolicy.AllowOrigins).To(Equal(TestCors.AllowOrigins))
-			Expect(vs.Spec.Http[1].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
-			Expect(vs.Spec.Http[1].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
+			Expect(vs.Spec.Http[2].CorsPolicy.AllowOrigins).To(Equal(TestCors.AllowOrigins))
+			Expect(vs.Spec.Http[2].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
+			Expect(vs.Spec.Http[2].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
 			Expect(vs.ObjectMeta.Name).To(BeEmpty())
 			Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
@@ -92,6 +92,36 @@ var _ = Describe("Access Rule Processor", func() {
 			Expect(accessRule.ObjectMeta.Labels[TestLabelKey]).To(Equal(TestLabelValue))
 		})
 
+		It("should match all hosts when multiple hosts are defined", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "noop",
+					},
+				},
+			}
+
+			noopRule := GetRuleWithServiceFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies, nil)
+			rules := []gatewayv1beta1.Rule{noopRule}
+
+			additionalHost := gatewayv1beta1.Host("otherService")
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.Hosts = []*gatewayv1beta1.Host{&additionalHost}
+			client := GetFakeClient()
+			processor := ory.NewAccessRuleProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			accessRule := result[0].Obj.(*rulev1alpha1.Rule)
+			Expect(accessRule.Spec.Match.URL).To(Equal(`<http|https>://<myService\.myDomain\.com|otherService\.myDomain\.com><` + ApiPath + ">"))
+		})
+
 		It("should override rule upstream with rule level service", func() {
 			// given
 			strategies := []*gatewayv1beta1.Authenticator{
@@ -129,6 +159,35 @@ var _ = Describe("Access Rule Processor", func() {
 			Expect(accessRule.Spec.Upstream.URL).To(Equal(expectedRuleUpstreamURL))
 		})
 
+		It("should strip the path prefix of the rule in the upstream", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "noop",
+					},
+				},
+			}
+
+			noopRule := GetRuleFor("/orders/v1/.*", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			noopRule.Rewrite = &gatewayv1beta1.Rewrite{StripPrefix: "/orders/v1"}
+			rules := []gatewayv1beta1.Rule{noopRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := ory.NewAccessRuleProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			accessRule := result[0].Obj.(*rulev1alpha1.Rule)
+			Expect(*accessRule.Spec.Upstream.StripPath).To(Equal("/orders/v1"))
+		})
+
 		It("should override rule upstream with rule level service for specified namespace", func() {
 			// given
 			strategies := []*gatewayv1beta1.Authenticator{
@@ -21,9 +21,10 @@ func NewOryReconciliation(config processing.ReconciliationConfig, log *logr.Logg
 	vsProcessor := NewVirtualServiceProcessor(config)
 	apProcessor := NewAuthorizationPolicyProcessor(config, log)
 	raProcessor := NewRequestAuthenticationProcessor(config)
+	efProcessor := NewEnvoyFilterProcessor(config)
 
 	return Reconciliation{
-		processors: []processing.ReconciliationProcessor{vsProcessor, raProcessor, apProcessor, acProcessor},
+		processors: []processing.ReconciliationProcessor{vsProcessor, raProcessor, apProcessor, acProcessor, efProcessor},
 		config:     config,
 	}
 }
@@ -37,6 +38,9 @@ func (r Reconciliation) Validate(ctx context.Context, client client.Client, apiR
 	validator := validation.APIRuleValidator{
 		HandlerValidator:          &handlerValidator{},
 		AccessStrategiesValidator: &asValidator{},
+		RulesValidator:            &rulesValidator{},
+		ServicesValidator:         &servicesValidator{},
+		RewriteValidator:          &rewriteValidator{},
 		ServiceBlockList:          r.config.ServiceBlockList,
 		DomainAllowList:           r.config.DomainAllowList,
 		HostBlockList:             r.config.HostBlockList,
@@ -0,0 +1,27 @@
+package ory
+
+import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+type rewriteValidator struct{}
+
+// Validate rejects rewriting the URI or the authority for rules routed through Oathkeeper, since Oathkeeper matches the
+// original URL of the request and supports stripping a path prefix only.
+func (v *rewriteValidator) Validate(attributePath string, rule gatewayv1beta1.Rule) []validation.Failure {
+	if rule.Rewrite == nil || !processing.IsSecured(rule) {
+		return nil
+	}
+
+	var problems []validation.Failure
+	if rule.Rewrite.URI != "" {
+		problems = append(problems, validation.Failure{AttributePath: attributePath + ".uri", Message: "Rewriting the URI is only supported for the allow access strategy"})
+	}
+	if rule.Rewrite.Authority != "" {
+		problems = append(problems, validation.Failure{AttributePath: attributePath + ".authority", Message: "Rewriting the authority is only supported for the allow access strategy"})
+	}
+
+	return problems
+}
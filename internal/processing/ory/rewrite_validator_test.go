@@ -0,0 +1,42 @@
+package ory
+
+import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rewrite Ory Validator", func() {
+
+	rewrite := &gatewayv1beta1.Rewrite{URI: "/items", Authority: "orders.internal"}
+
+	It("Should succeed for rule with allow access strategy", func() {
+		//given
+		rule := gatewayv1beta1.Rule{
+			AccessStrategies: []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}},
+			Rewrite:          rewrite,
+		}
+
+		//when
+		problems := (&rewriteValidator{}).Validate("some.attribute", rule)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for rewriting the URI in rule with jwt access strategy", func() {
+		//given
+		rule := gatewayv1beta1.Rule{
+			AccessStrategies: []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "jwt"}}},
+			Rewrite:          &gatewayv1beta1.Rewrite{StripPrefix: "/orders/v1", URI: "/items"},
+		}
+
+		//when
+		problems := (&rewriteValidator{}).Validate("some.attribute", rule)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.uri"))
+		Expect(problems[0].Message).To(Equal("Rewriting the URI is only supported for the allow access strategy"))
+	})
+})
@@ -0,0 +1,49 @@
+package ory
+
+import (
+	"fmt"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+type rulesValidator struct{}
+
+// Validate rejects rules routed through Oathkeeper that have the same path and method, since Oathkeeper matches the access
+// rules by URL and method only and can't distinguish them by header or query parameter match conditions. IP allow and deny
+// lists, workload sources and introspection URLs are rejected, since they are enforced by AuthorizationPolicies, which are
+// only created by the Istio jwt handler.
+func (v *rulesValidator) Validate(attrPath string, rules []gatewayv1beta1.Rule) []validation.Failure {
+	var failures []validation.Failure
+
+	for i, rule := range rules {
+		if processing.HasIpRestriction(rule) {
+			failures = append(failures, validation.Failure{
+				AttributePath: fmt.Sprintf("%s[%d]", attrPath, i),
+				Message:       "IP allow and deny lists are only supported with the Istio jwt handler",
+			})
+		}
+		if processing.HasSourceRestriction(rule) {
+			failures = append(failures, validation.Failure{
+				AttributePath: fmt.Sprintf("%s[%d].from", attrPath, i),
+				Message:       "Workload sources are only supported with the Istio jwt handler",
+			})
+		}
+		if processing.IsIntrospectionSecured(rule) {
+			failures = append(failures, validation.Failure{
+				AttributePath: fmt.Sprintf("%s[%d].accessStrategies", attrPath, i),
+				Message:       "Token introspection without Oathkeeper is only supported with the Istio jwt handler",
+			})
+		}
+	}
+
+	for _, i := range processing.FindPathAndMethodDuplicates(rules, processing.IsSecured) {
+		failures = append(failures, validation.Failure{
+			AttributePath: fmt.Sprintf("%s[%d]", attrPath, i),
+			Message:       "Rules with access strategies handled by Oathkeeper can't have the same path and method",
+		})
+	}
+
+	return failures
+}
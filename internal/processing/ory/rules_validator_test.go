@@ -0,0 +1,95 @@
+package ory
+
+import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Rules Ory Validator", func() {
+
+	allow := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+	jwt := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "jwt"}}}
+
+	It("Should succeed for rules with the same path and method if only one is handled by Oathkeeper", func() {
+		//given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: allow},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt, Headers: map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}},
+		}
+
+		//when
+		problems := (&rulesValidator{}).Validate(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for rules handled by Oathkeeper with the same path and method", func() {
+		//given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt, QueryParams: map[string]*gatewayv1beta1.StringMatch{"beta": {Exact: "true"}}},
+		}
+
+		//when
+		problems := (&rulesValidator{}).Validate(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1]"))
+		Expect(problems[0].Message).To(Equal("Rules with access strategies handled by Oathkeeper can't have the same path and method"))
+	})
+
+	It("Should fail for rules with IP allow or deny lists", func() {
+		//given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/admin", Methods: []string{"GET"}, AccessStrategies: allow, IPAllowList: []string{"10.0.0.0/8"}},
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: jwt, IPDenyList: []string{"10.1.0.0/16"}},
+		}
+
+		//when
+		problems := (&rulesValidator{}).Validate(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0]"))
+		Expect(problems[0].Message).To(Equal("IP allow and deny lists are only supported with the Istio jwt handler"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[1]"))
+	})
+
+	It("Should fail for rules with workload sources", func() {
+		//given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/admin", Methods: []string{"GET"}, AccessStrategies: allow, From: &gatewayv1beta1.RuleSource{Namespaces: []string{"orders"}}},
+		}
+
+		//when
+		problems := (&rulesValidator{}).Validate(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].from"))
+		Expect(problems[0].Message).To(Equal("Workload sources are only supported with the Istio jwt handler"))
+	})
+
+	It("Should fail for rules with an introspection URL", func() {
+		//given
+		introspection := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{
+			Name:   "oauth2_introspection",
+			Config: &runtime.RawExtension{Raw: []byte(`{"introspectionUrl": "https://auth.example.com/introspect"}`)},
+		}}}
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, AccessStrategies: introspection},
+		}
+
+		//when
+		problems := (&rulesValidator{}).Validate(".spec.rules", rules)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].accessStrategies"))
+		Expect(problems[0].Message).To(Equal("Token introspection without Oathkeeper is only supported with the Istio jwt handler"))
+	})
+})
@@ -0,0 +1,19 @@
+package ory
+
+import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+type servicesValidator struct{}
+
+// Validate rejects splitting the traffic between several services for rules routed through Oathkeeper, since Oathkeeper
+// supports a single upstream only.
+func (v *servicesValidator) Validate(attributePath string, rule gatewayv1beta1.Rule, _ []*gatewayv1beta1.WeightedService) []validation.Failure {
+	if processing.IsSecured(rule) {
+		return []validation.Failure{{AttributePath: attributePath + ".services", Message: "Splitting the traffic between several services is only supported for the allow access strategy"}}
+	}
+
+	return nil
+}
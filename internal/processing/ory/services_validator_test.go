@@ -0,0 +1,39 @@
+package ory
+
+import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Services Ory Validator", func() {
+
+	services := []*gatewayv1beta1.WeightedService{{Weight: 50}, {Weight: 50}}
+
+	It("Should succeed for rule with allow access strategy", func() {
+		//given
+		rule := gatewayv1beta1.Rule{
+			AccessStrategies: []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}},
+		}
+
+		//when
+		problems := (&servicesValidator{}).Validate("some.attribute", rule, services)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for rule with jwt access strategy", func() {
+		//given
+		rule := gatewayv1beta1.Rule{
+			AccessStrategies: []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "jwt"}}},
+		}
+
+		//when
+		problems := (&servicesValidator{}).Validate("some.attribute", rule, services)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal("some.attribute.services"))
+	})
+})
@@ -20,5 +20,8 @@ func StatusBase(statusCode gatewayv1beta1.StatusCode) processing.ReconciliationS
 		AccessRuleStatus: &gatewayv1beta1.APIRuleResourceStatus{
 			Code: statusCode,
 		},
+		EnvoyFilterStatus: &gatewayv1beta1.APIRuleResourceStatus{
+			Code: statusCode,
+		},
 	}
 }
@@ -2,6 +2,7 @@ package ory
 
 import (
 	"fmt"
+	"time"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	"github.com/kyma-project/api-gateway/internal/builders"
@@ -36,37 +37,80 @@ type virtualServiceCreator struct {
 func (r virtualServiceCreator) Create(api *gatewayv1beta1.APIRule) (*networkingv1beta1.VirtualService, error) {
 	virtualServiceNamePrefix := fmt.Sprintf("%s-", api.ObjectMeta.Name)
 
-	vsSpecBuilder := builders.VirtualServiceSpec()
-	vsSpecBuilder.Host(helpers.GetHostWithDomain(*api.Spec.Host, r.defaultDomainName))
-	vsSpecBuilder.Gateway(*api.Spec.Gateway)
-	filteredRules := processing.FilterDuplicatePaths(api.Spec.Rules)
+	hosts := helpers.GetAPIRuleHosts(api.Spec, r.defaultDomainName)
 
-	for _, rule := range filteredRules {
+	vsSpecBuilder := builders.VirtualServiceSpec()
+	for _, host := range hosts {
+		vsSpecBuilder.Host(host)
+	}
+	for _, gateway := range api.Spec.GetGateways() {
+		vsSpecBuilder.Gateway(gateway)
+	}
+	for _, routeRule := range processing.GetHttpRouteRules(api.Spec.Rules) {
+		rule := routeRule.Rule
 		httpRouteBuilder := builders.HTTPRoute()
-		host, port := r.oathkeeperSvc, r.oathkeeperSvcPort
-		serviceNamespace := helpers.FindServiceNamespace(api, &rule)
 
-		if !processing.IsSecured(rule) {
-			// Use rule level service if it exists
-			if rule.Service != nil {
-				host = fmt.Sprintf("%s.%s.svc.cluster.local", *rule.Service.Name, serviceNamespace)
-				port = *rule.Service.Port
-			} else {
-				// Otherwise use service defined on APIRule spec level
-				host = fmt.Sprintf("%s.%s.svc.cluster.local", *api.Spec.Service.Name, serviceNamespace)
-				port = *api.Spec.Service.Port
+		switch {
+		case rule.Redirect != nil:
+			httpRouteBuilder.Redirect(builders.Redirect().
+				Uri(rule.Redirect.URI).
+				Authority(rule.Redirect.Authority).
+				Scheme(rule.Redirect.Scheme).
+				Code(rule.Redirect.Code))
+		case rule.DirectResponse != nil:
+			httpRouteBuilder.DirectResponse(builders.DirectResponse().
+				Status(rule.DirectResponse.Status).
+				Body(rule.DirectResponse.Body))
+		case !processing.IsSecured(rule):
+			// Rule level services take precedence over the services defined on APIRule spec level
+			for _, service := range helpers.GetRuleServices(api, &rule) {
+				host := fmt.Sprintf("%s.%s.svc.cluster.local", *service.Name, *service.Namespace)
+				httpRouteBuilder.Route(builders.RouteDestination().Host(host).Port(*service.Port).Weight(int32(service.Weight)))
 			}
+		default:
+			httpRouteBuilder.Route(builders.RouteDestination().Host(r.oathkeeperSvc).Port(r.oathkeeperSvcPort))
 		}
-
-		httpRouteBuilder.Route(builders.RouteDestination().Host(host).Port(port))
-		httpRouteBuilder.Match(builders.MatchRequest().Uri().Regex(rule.Path))
+		matchRequest := builders.MatchRequest().Uri().Regex(helpers.GetPathRegex(rule))
+		for name, match := range rule.Headers {
+			matchRequest.Header(name).From(match)
+		}
+		for name, match := range rule.QueryParams {
+			matchRequest.QueryParam(name).From(match)
+		}
+		if len(rule.Gateways) > 0 {
+			matchRequest.Gateways(rule.Gateways...)
+		}
+		if len(routeRule.MatchMethods) > 0 {
+			matchRequest.Method(routeRule.MatchMethods...)
+		}
+		httpRouteBuilder.Match(matchRequest)
+		corsConfig := processors.GetVirtualServiceCorsConfig(r.corsConfig, api.Spec, rule)
 		httpRouteBuilder.CorsPolicy(builders.CorsPolicy().
-			AllowOrigins(r.corsConfig.AllowOrigins...).
-			AllowMethods(r.corsConfig.AllowMethods...).
-			AllowHeaders(r.corsConfig.AllowHeaders...))
+			AllowOrigins(corsConfig.AllowOrigins...).
+			AllowMethods(corsConfig.AllowMethods...).
+			AllowHeaders(corsConfig.AllowHeaders...).
+			ExposeHeaders(corsConfig.ExposeHeaders...).
+			AllowCredentials(corsConfig.AllowCredentials).
+			MaxAge(corsConfig.MaxAge))
 		httpRouteBuilder.Headers(builders.NewHttpRouteHeadersBuilder().
-			SetHostHeader(helpers.GetHostWithDomain(*api.Spec.Host, r.defaultDomainName)).Get())
+			SetHostHeader(processing.GetForwardedHost(hosts)).Get())
 		httpRouteBuilder.Timeout(processors.GetVirtualServiceHttpTimeout(api.Spec, rule))
+
+		if retries := processors.GetVirtualServiceHttpRetries(api.Spec, rule); retries != nil {
+			retryPolicy := builders.RetryPolicy().Attempts(retries.Attempts).RetryOn(retries.RetryOn)
+			if retries.PerTryTimeout != nil {
+				retryPolicy.PerTryTimeout(time.Duration(*retries.PerTryTimeout) * time.Second)
+			}
+			httpRouteBuilder.Retries(retryPolicy)
+		}
+
+		// Requests routed through Oathkeeper are rewritten by the access rule, since Oathkeeper matches the original URL
+		if !processing.IsSecured(rule) && rule.Rewrite != nil {
+			httpRouteBuilder.Rewrite(builders.Rewrite().
+				StripPrefix(rule.Rewrite.StripPrefix).
+				Uri(rule.Rewrite.URI).
+				Authority(rule.Rewrite.Authority))
+		}
 		vsSpecBuilder.HTTP(httpRouteBuilder)
 
 	}
@@ -183,6 +183,156 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.Spec.Hosts[0]).To(Equal(ServiceHost))
 
 		})
+
+		It("should rewrite only the routes not forwarded to Oathkeeper", func() {
+			// given
+			allowRule := GetRuleFor("/orders/v1/.*", ApiMethods, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			})
+			allowRule.Rewrite = &gatewayv1beta1.Rewrite{StripPrefix: "/orders/v1", Authority: "orders.internal"}
+			noopRule := GetRuleFor("/secured/v1/.*", ApiMethods, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "noop",
+					},
+				},
+			})
+			noopRule.Rewrite = &gatewayv1beta1.Rewrite{StripPrefix: "/secured/v1"}
+			rules := []gatewayv1beta1.Rule{allowRule, noopRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := ory.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(2))
+			Expect(vs.Spec.Http[0].Rewrite.UriRegexRewrite.Match).To(Equal(`^/orders/v1(/|$)`))
+			Expect(vs.Spec.Http[0].Rewrite.Authority).To(Equal("orders.internal"))
+			Expect(vs.Spec.Http[1].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
+			Expect(vs.Spec.Http[1].Rewrite).To(BeNil())
+		})
+
+		It("should match on headers and query parameters for routes forwarded to Oathkeeper", func() {
+			// given
+			noopRule := GetRuleFor("/orders", ApiMethods, []*gatewayv1beta1.Mutator{}, []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "noop",
+					},
+				},
+			})
+			noopRule.Headers = map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}
+			noopRule.QueryParams = map[string]*gatewayv1beta1.StringMatch{"beta": {Regex: "true|yes"}}
+			rules := []gatewayv1beta1.Rule{noopRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := ory.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(1))
+			Expect(vs.Spec.Http[0].Match[0].Headers["x-api-version"].GetExact()).To(Equal("2"))
+			Expect(vs.Spec.Http[0].Match[0].QueryParams["beta"].GetRegex()).To(Equal("true|yes"))
+			Expect(vs.Spec.Http[0].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
+		})
+	})
+
+	When("rule returns a redirect or a direct response", func() {
+		It("should create routes without destination", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			redirectRule := GetRuleFor("/old/.*", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			redirectRule.Redirect = &gatewayv1beta1.Redirect{URI: "/new", Authority: "example.com", Scheme: "https", Code: 308}
+			directResponseRule := GetRuleFor("/robots.txt", ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			directResponseRule.DirectResponse = &gatewayv1beta1.DirectResponse{Status: 200, Body: "User-agent: *"}
+			rules := []gatewayv1beta1.Rule{redirectRule, directResponseRule}
+
+			apiRule := GetAPIRuleFor(rules)
+			client := GetFakeClient()
+			processor := ory.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Http).To(HaveLen(2))
+			Expect(vs.Spec.Http[0].Route).To(BeEmpty())
+			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal("/old/.*"))
+			Expect(vs.Spec.Http[0].Redirect.Uri).To(Equal("/new"))
+			Expect(vs.Spec.Http[0].Redirect.Authority).To(Equal("example.com"))
+			Expect(vs.Spec.Http[0].Redirect.Scheme).To(Equal("https"))
+			Expect(vs.Spec.Http[0].Redirect.RedirectCode).To(BeEquivalentTo(308))
+			Expect(vs.Spec.Http[1].Route).To(BeEmpty())
+			Expect(vs.Spec.Http[1].DirectResponse.Status).To(BeEquivalentTo(200))
+			Expect(vs.Spec.Http[1].DirectResponse.Body.GetString_()).To(Equal("User-agent: *"))
+		})
+	})
+
+	When("multiple hosts are defined", func() {
+		It("should create VS with all hosts and forward the request authority as host", func() {
+			// given
+			strategies := []*gatewayv1beta1.Authenticator{
+				{
+					Handler: &gatewayv1beta1.Handler{
+						Name: "allow",
+					},
+				},
+			}
+
+			allowRule := GetRuleFor(ApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, strategies)
+			rules := []gatewayv1beta1.Rule{allowRule}
+
+			additionalHost := gatewayv1beta1.Host("myService.otherDomain.com")
+			additionalHostWithNoDomain := gatewayv1beta1.Host("otherService")
+			apiRule := GetAPIRuleFor(rules)
+			apiRule.Spec.Hosts = []*gatewayv1beta1.Host{&additionalHost, &additionalHostWithNoDomain}
+			client := GetFakeClient()
+			processor := ory.NewVirtualServiceProcessor(GetTestConfig())
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.TODO(), client, apiRule)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+
+			vs := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			Expect(vs.Spec.Hosts).To(Equal([]string{ServiceHost, "myService.otherDomain.com", "otherService." + DefaultDomain}))
+			Expect(vs.Spec.Http[0].Headers.Request.Set).To(HaveKeyWithValue("x-forwarded-host", "%REQ(:authority)%"))
+		})
 	})
 
 	When("handler is noop", func() {
@@ -452,13 +602,19 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(len(vs.Spec.Gateways)).To(Equal(1))
 			Expect(len(vs.Spec.Hosts)).To(Equal(1))
 			Expect(vs.Spec.Hosts[0]).To(Equal(ServiceHost))
-			Expect(len(vs.Spec.Http)).To(Equal(1))
+			Expect(len(vs.Spec.Http)).To(Equal(2))
 
 			Expect(len(vs.Spec.Http[0].Route)).To(Equal(1))
 			Expect(vs.Spec.Http[0].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
 			Expect(vs.Spec.Http[0].Route[0].Destination.Port.Number).To(Equal(OathkeeperSvcPort))
 			Expect(len(vs.Spec.Http[0].Match)).To(Equal(1))
-			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[0].Path))
+			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[1].Path))
+			Expect(vs.Spec.Http[0].Match[0].Method.GetExact()).To(Equal("POST"))
+
+			Expect(len(vs.Spec.Http[1].Route)).To(Equal(1))
+			Expect(vs.Spec.Http[1].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
+			Expect(vs.Spec.Http[1].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[0].Path))
+			Expect(vs.Spec.Http[1].Match[0].Method).To(BeNil())
 
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowOrigins).To(Equal(TestCors.AllowOrigins))
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
@@ -534,27 +690,33 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(len(vs.Spec.Gateways)).To(Equal(1))
 			Expect(len(vs.Spec.Hosts)).To(Equal(1))
 			Expect(vs.Spec.Hosts[0]).To(Equal(ServiceHost))
-			Expect(len(vs.Spec.Http)).To(Equal(2))
+			Expect(len(vs.Spec.Http)).To(Equal(3))
 
 			Expect(len(vs.Spec.Http[0].Route)).To(Equal(1))
 			Expect(vs.Spec.Http[0].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
 			Expect(vs.Spec.Http[0].Route[0].Destination.Port.Number).To(Equal(OathkeeperSvcPort))
 			Expect(len(vs.Spec.Http[0].Match)).To(Equal(1))
-			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[0].Path))
+			Expect(vs.Spec.Http[0].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[1].Path))
+			Expect(vs.Spec.Http[0].Match[0].Method.GetExact()).To(Equal("POST"))
+
+			Expect(len(vs.Spec.Http[1].Route)).To(Equal(1))
+			Expect(vs.Spec.Http[1].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
+			Expect(vs.Spec.Http[1].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[0].Path))
+			Expect(vs.Spec.Http[1].Match[0].Method).To(BeNil())
 
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowOrigins).To(Equal(TestCors.AllowOrigins))
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-			Expect(len(vs.Spec.Http[1].Route)).To(Equal(1))
-			Expect(vs.Spec.Http[1].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
-			Expect(vs.Spec.Http[1].Route[0].Destination.Port.Number).To(Equal(OathkeeperSvcPort))
-			Expect(len(vs.Spec.Http[1].Match)).To(Equal(1))
-			Expect(vs.Spec.Http[1].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[2].Path))
+			Expect(len(vs.Spec.Http[2].Route)).To(Equal(1))
+			Expect(vs.Spec.Http[2].Route[0].Destination.Host).To(Equal(OathkeeperSvc))
+			Expect(vs.Spec.Http[2].Route[0].Destination.Port.Number).To(Equal(OathkeeperSvcPort))
+			Expect(len(vs.Spec.Http[2].Match)).To(Equal(1))
+			Expect(vs.Spec.Http[2].Match[0].Uri.GetRegex()).To(Equal(apiRule.Spec.Rules[2].Path))
 
-			Expect(vs.Spec.Http[1].CorsPolicy.AllowOrigins).To(Equal(TestCors.AllowOrigins))
-			Expect(vs.Spec.Http[1].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
-			Expect(vs.Spec.Http[1].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
+			Expect(vs.Spec.Http[2].CorsPolicy.AllowOrigins).To(Equal(TestCors.AllowOrigins))
+			Expect(vs.Spec.Http[2].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
+			Expect(vs.Spec.Http[2].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
 			Expect(vs.ObjectMeta.Name).To(BeEmpty())
 			Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
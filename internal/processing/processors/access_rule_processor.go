@@ -3,6 +3,8 @@ package processors
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	"github.com/kyma-project/api-gateway/internal/builders"
@@ -98,10 +100,11 @@ func SetAccessRuleKey(hasPathDuplicates bool, rule rulev1alpha1.Rule) string {
 func HasPathDuplicates(rules []gatewayv1beta1.Rule) bool {
 	duplicates := map[string]bool{}
 	for _, rule := range rules {
-		if duplicates[rule.Path] {
+		pathRegex := helpers.GetPathRegex(rule)
+		if duplicates[pathRegex] {
 			return true
 		}
-		duplicates[rule.Path] = true
+		duplicates[pathRegex] = true
 	}
 
 	return false
@@ -127,20 +130,39 @@ func GenerateAccessRule(api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, a
 func GenerateAccessRuleSpec(api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, accessStrategies []*gatewayv1beta1.Authenticator, defaultDomainName string) *rulev1alpha1.RuleSpec {
 	accessRuleSpec := builders.AccessRuleSpec().
 		Match(builders.Match().
-			URL(fmt.Sprintf("<http|https>://%s<%s>", helpers.GetHostWithDomain(*api.Spec.Host, defaultDomainName), rule.Path)).
+			URL(fmt.Sprintf("<http|https>://%s<%s>", getAccessRuleHost(api, defaultDomainName), helpers.GetPathRegex(rule))).
 			Methods(rule.Methods)).
 		Authorizer(builders.Authorizer().Handler(builders.Handler().
 			Name("allow"))).
 		Authenticators(builders.Authenticators().From(accessStrategies)).
 		Mutators(builders.Mutators().From(rule.Mutators))
 
-	serviceNamespace := helpers.FindServiceNamespace(api, &rule)
+	// Oathkeeper supports a single upstream only, therefore splitting the traffic between several services is rejected by the
+	// validation for rules handled by Oathkeeper.
+	service := helpers.GetRuleServices(api, &rule)[0]
 
-	if rule.Service != nil {
-		return accessRuleSpec.Upstream(builders.Upstream().
-			URL(fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", *rule.Service.Name, serviceNamespace, int(*rule.Service.Port)))).Get()
-	} else {
-		return accessRuleSpec.Upstream(builders.Upstream().
-			URL(fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", *api.Spec.Service.Name, serviceNamespace, int(*api.Spec.Service.Port)))).Get()
+	upstream := builders.Upstream().
+		URL(fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", *service.Name, *service.Namespace, int(*service.Port)))
+	if rule.Rewrite != nil && rule.Rewrite.StripPrefix != "" {
+		stripPath := rule.Rewrite.StripPrefix
+		upstream.StripPath(&stripPath)
 	}
+
+	return accessRuleSpec.Upstream(upstream).Get()
+}
+
+// getAccessRuleHost returns the host part of the access rule match URL. If there is more than one host, a regex matching
+// any of the hosts is returned.
+func getAccessRuleHost(api *gatewayv1beta1.APIRule, defaultDomainName string) string {
+	hosts := helpers.GetAPIRuleHosts(api.Spec, defaultDomainName)
+	if len(hosts) == 1 {
+		return hosts[0]
+	}
+
+	var quotedHosts []string
+	for _, host := range hosts {
+		quotedHosts = append(quotedHosts, regexp.QuoteMeta(host))
+	}
+
+	return fmt.Sprintf("<%s>", strings.Join(quotedHosts, "|"))
 }
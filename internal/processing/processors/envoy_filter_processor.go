@@ -0,0 +1,237 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/builders"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClientIPHeader is the header the Istio Ingress Gateway sets to the address of the client the request originates from.
+const ClientIPHeader = "x-envoy-external-address"
+
+// EnvoyFilterProcessor is the generic processor that handles the Istio Envoy Filters in the reconciliation of API Rule.
+type EnvoyFilterProcessor struct {
+	Creator EnvoyFilterCreator
+}
+
+// EnvoyFilterCreator provides the creation of EnvoyFilters using the configuration in the given APIRule.
+// The key of the map is expected to be unique and comparable with the key of the EnvoyFilters existing in the cluster.
+type EnvoyFilterCreator interface {
+	Create(ctx context.Context, client ctrlclient.Client, api *gatewayv1beta1.APIRule) (map[string]*networkingv1alpha3.EnvoyFilter, error)
+}
+
+func (r EnvoyFilterProcessor) EvaluateReconciliation(ctx context.Context, client ctrlclient.Client, apiRule *gatewayv1beta1.APIRule) ([]*processing.ObjectChange, error) {
+	desired, err := r.getDesiredState(ctx, client, apiRule)
+	if err != nil {
+		return make([]*processing.ObjectChange, 0), err
+	}
+	actual, err := r.getActualState(ctx, client, apiRule)
+	if err != nil {
+		return make([]*processing.ObjectChange, 0), err
+	}
+
+	changes := r.getObjectChanges(desired, actual)
+
+	return changes, nil
+}
+
+func (r EnvoyFilterProcessor) getDesiredState(ctx context.Context, client ctrlclient.Client, api *gatewayv1beta1.APIRule) (map[string]*networkingv1alpha3.EnvoyFilter, error) {
+	return r.Creator.Create(ctx, client, api)
+}
+
+func (r EnvoyFilterProcessor) getActualState(ctx context.Context, client ctrlclient.Client, api *gatewayv1beta1.APIRule) (map[string]*networkingv1alpha3.EnvoyFilter, error) {
+	labels := processing.GetOwnerLabels(api)
+
+	var efList networkingv1alpha3.EnvoyFilterList
+	if err := client.List(ctx, &efList, ctrlclient.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+
+	envoyFilters := make(map[string]*networkingv1alpha3.EnvoyFilter)
+
+	for i := range efList.Items {
+		obj := efList.Items[i]
+		envoyFilters[GetEnvoyFilterKey(obj)] = obj
+	}
+
+	return envoyFilters, nil
+}
+
+func (r EnvoyFilterProcessor) getObjectChanges(desiredEfs map[string]*networkingv1alpha3.EnvoyFilter, actualEfs map[string]*networkingv1alpha3.EnvoyFilter) []*processing.ObjectChange {
+	var efChanges []*processing.ObjectChange
+
+	for key, ef := range desiredEfs {
+		if actualEfs[key] != nil {
+			actualEfs[key].Spec = *ef.Spec.DeepCopy()
+			efChanges = append(efChanges, processing.NewObjectUpdateAction(actualEfs[key]))
+		} else {
+			efChanges = append(efChanges, processing.NewObjectCreateAction(ef))
+		}
+	}
+
+	for key, ef := range actualEfs {
+		if desiredEfs[key] == nil {
+			efChanges = append(efChanges, processing.NewObjectDeleteAction(ef))
+		}
+	}
+
+	return efChanges
+}
+
+// GetEnvoyFilterKey returns the key of the EnvoyFilter, which is built from the namespace and the workload selector,
+// since there is one EnvoyFilter per workload.
+func GetEnvoyFilterKey(ef *networkingv1alpha3.EnvoyFilter) string {
+	var labels map[string]string
+	if ef.Spec.WorkloadSelector != nil {
+		labels = ef.Spec.WorkloadSelector.Labels
+	}
+
+	return helpers.GetWorkloadSelectorKey(ef.Namespace, labels)
+}
+
+// GenerateRateLimitEnvoyFilters returns the EnvoyFilters enforcing the rate limits of the APIRule rules. There is one
+// EnvoyFilter for every workload the traffic of a rate limited rule is routed to. The EnvoyFilters of different APIRules
+// for the same workload would conflict, so the validation rejects rate limits of services already rate limited by
+// another APIRule.
+func GenerateRateLimitEnvoyFilters(ctx context.Context, client ctrlclient.Client, api *gatewayv1beta1.APIRule, additionalLabels map[string]string) (map[string]*networkingv1alpha3.EnvoyFilter, error) {
+	envoyFilters := make(map[string]*networkingv1alpha3.EnvoyFilter)
+	routePatches := make(map[string]*builders.LocalRateLimitRoutePatchBuilder)
+
+	for i, rule := range api.Spec.Rules {
+		if rule.RateLimit == nil {
+			continue
+		}
+
+		for _, service := range helpers.GetRuleServices(api, &rule) {
+			selector, err := helpers.GetLabelSelectorFromService(ctx, client, &service.Service, api, &rule)
+			if err != nil {
+				return envoyFilters, err
+			}
+			if selector == nil {
+				return envoyFilters, fmt.Errorf("rate limit of the rule with path %s requires service %s to have a selector", rule.Path, *service.Name)
+			}
+
+			specBuilder := builders.NewEnvoyFilterSpecBuilder().WithWorkloadSelector(selector.MatchLabels)
+			efBuilder := builders.NewEnvoyFilterBuilder().
+				WithGenerateName(fmt.Sprintf("%s-", api.Name)).
+				WithNamespace(*service.Namespace).
+				WithSpec(specBuilder.Get())
+			key := GetEnvoyFilterKey(efBuilder.Get())
+
+			if _, ok := envoyFilters[key]; !ok {
+				efBuilder.WithLabel(processing.OwnerLabel, fmt.Sprintf("%s.%s", api.Name, api.Namespace))
+				for k, v := range additionalLabels {
+					efBuilder.WithLabel(k, v)
+				}
+				envoyFilters[key] = efBuilder.Get()
+				routePatches[key] = builders.NewLocalRateLimitRoutePatchBuilder()
+			}
+
+			addRateLimitDescriptors(routePatches[key], fmt.Sprintf("%s-rule-%d", api.Name, i), rule)
+		}
+	}
+
+	for key, ef := range envoyFilters {
+		filterPatch, err := builders.NewLocalRateLimitFilterPatch()
+		if err != nil {
+			return envoyFilters, err
+		}
+		routePatch, err := routePatches[key].Get()
+		if err != nil {
+			return envoyFilters, err
+		}
+		ef.Spec.ConfigPatches = append(ef.Spec.ConfigPatches, filterPatch, routePatch)
+	}
+
+	return envoyFilters, nil
+}
+
+// addRateLimitDescriptors adds a descriptor for every bucket of the rule's rate limit and a descriptor for the
+// remaining requests to the rule's path.
+func addRateLimitDescriptors(routePatch *builders.LocalRateLimitRoutePatchBuilder, name string, rule gatewayv1beta1.Rule) {
+	fillInterval := getRateLimitFillInterval(rule.RateLimit.Unit)
+	ruleMatchers := getRuleHeaderMatchers(rule)
+
+	var remainingMatchers []builders.HeaderMatcher
+	remainingMatchers = append(remainingMatchers, ruleMatchers...)
+
+	for j, bucket := range rule.RateLimit.Buckets {
+		if bucket == nil {
+			continue
+		}
+
+		var bucketMatcher builders.HeaderMatcher
+		if bucket.Header != nil {
+			bucketMatcher = builders.ExactHeaderMatcher(bucket.Header.Name, bucket.Header.Value)
+		} else {
+			bucketMatcher = builders.ExactHeaderMatcher(ClientIPHeader, bucket.StaticClientIP)
+		}
+
+		bucketMatchers := append(append([]builders.HeaderMatcher{}, ruleMatchers...), bucketMatcher)
+		routePatch.WithDescriptor(fmt.Sprintf("%s-bucket-%d", name, j), bucketMatchers, bucket.Requests, fillInterval)
+
+		// The requests counted in a bucket are not counted in the limit of the rule
+		invertedMatcher := builders.HeaderMatcher{"invert_match": true}
+		for k, v := range bucketMatcher {
+			invertedMatcher[k] = v
+		}
+		remainingMatchers = append(remainingMatchers, invertedMatcher)
+	}
+
+	routePatch.WithDescriptor(name, remainingMatchers, rule.RateLimit.Requests, fillInterval)
+}
+
+func getRuleHeaderMatchers(rule gatewayv1beta1.Rule) []builders.HeaderMatcher {
+	var matchers []builders.HeaderMatcher
+	if pathRegex := helpers.GetPathRegex(rule); pathRegex == "/*" {
+		matchers = append(matchers, builders.PrefixHeaderMatcher(":path", "/"))
+	} else {
+		// The :path header contains the query string, which is not part of the path matched by the VirtualService
+		matchers = append(matchers, builders.RegexHeaderMatcher(":path", fmt.Sprintf(`(%s)(\?.*)?`, pathRegex)))
+	}
+
+	if len(rule.Methods) > 0 {
+		matchers = append(matchers, builders.RegexHeaderMatcher(":method", strings.Join(rule.Methods, "|")))
+	}
+
+	var names []string
+	for name := range rule.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		switch match := rule.Headers[name]; {
+		case match == nil:
+			continue
+		case match.Exact != "":
+			matchers = append(matchers, builders.ExactHeaderMatcher(name, match.Exact))
+		case match.Prefix != "":
+			matchers = append(matchers, builders.PrefixHeaderMatcher(name, match.Prefix))
+		default:
+			matchers = append(matchers, builders.RegexHeaderMatcher(name, match.Regex))
+		}
+	}
+
+	return matchers
+}
+
+func getRateLimitFillInterval(unit gatewayv1beta1.RateLimitUnit) time.Duration {
+	switch unit {
+	case gatewayv1beta1.RateLimitUnitMinute:
+		return time.Minute
+	case gatewayv1beta1.RateLimitUnitHour:
+		return time.Hour
+	default:
+		return time.Second
+	}
+}
@@ -0,0 +1,249 @@
+package processors_test
+
+import (
+	"context"
+	"fmt"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/builders"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	. "github.com/kyma-project/api-gateway/internal/processing/internal/test"
+	"github.com/kyma-project/api-gateway/internal/processing/processors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"istio.io/api/networking/v1alpha3"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Envoy Filter Processor", func() {
+	allowStrategies := []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "allow"}}}
+
+	getDescriptors := func(ef *networkingv1alpha3.EnvoyFilter) []interface{} {
+		routePatch := ef.Spec.ConfigPatches[1].Patch.Value.AsMap()
+		config := routePatch["typed_per_filter_config"].(map[string]interface{})["envoy.filters.http.local_ratelimit"].(map[string]interface{})
+		return config["value"].(map[string]interface{})["descriptors"].([]interface{})
+	}
+
+	getRateLimitHeaders := func(ef *networkingv1alpha3.EnvoyFilter, index int) []interface{} {
+		routePatch := ef.Spec.ConfigPatches[1].Patch.Value.AsMap()
+		rateLimits := routePatch["route"].(map[string]interface{})["rate_limits"].([]interface{})
+		action := rateLimits[index].(map[string]interface{})["actions"].([]interface{})[0].(map[string]interface{})
+		return action["header_value_match"].(map[string]interface{})["headers"].([]interface{})
+	}
+
+	It("should create one EnvoyFilter for all rate limited rules of a workload", func() {
+		// given
+		rule := GetRuleFor(HeadersApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, allowStrategies)
+		rule.RateLimit = &gatewayv1beta1.RateLimit{Requests: 10, Unit: gatewayv1beta1.RateLimitUnitMinute}
+		otherRule := GetRuleFor(ImgApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, allowStrategies)
+		otherRule.RateLimit = &gatewayv1beta1.RateLimit{Requests: 5, Unit: gatewayv1beta1.RateLimitUnitSecond}
+		notLimitedRule := GetRuleFor("/status", ApiMethods, []*gatewayv1beta1.Mutator{}, allowStrategies)
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule, notLimitedRule, otherRule})
+
+		fakeClient := GetFakeClient(GetService(ServiceName))
+		processor := processors.EnvoyFilterProcessor{Creator: rateLimitEnvoyFilterCreator{}}
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), fakeClient, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("create"))
+
+		ef := result[0].Obj.(*networkingv1alpha3.EnvoyFilter)
+		Expect(ef.GenerateName).To(Equal(ApiName + "-"))
+		Expect(ef.Namespace).To(Equal(ApiNamespace))
+		Expect(ef.Labels[processing.OwnerLabel]).To(Equal(fmt.Sprintf("%s.%s", ApiName, ApiNamespace)))
+		Expect(ef.Spec.WorkloadSelector.Labels).To(Equal(map[string]string{"app": ServiceName}))
+		Expect(ef.Spec.ConfigPatches).To(HaveLen(2))
+		Expect(ef.Spec.ConfigPatches[0].ApplyTo).To(Equal(v1alpha3.EnvoyFilter_HTTP_FILTER))
+		Expect(ef.Spec.ConfigPatches[1].ApplyTo).To(Equal(v1alpha3.EnvoyFilter_HTTP_ROUTE))
+
+		descriptors := getDescriptors(ef)
+		Expect(descriptors).To(HaveLen(2))
+		Expect(descriptors[0]).To(HaveKeyWithValue("entries", ContainElement(HaveKeyWithValue("value", ApiName+"-rule-0"))))
+		Expect(descriptors[0]).To(HaveKeyWithValue("token_bucket", HaveKeyWithValue("fill_interval", "60s")))
+		Expect(descriptors[0]).To(HaveKeyWithValue("token_bucket", HaveKeyWithValue("max_tokens", BeEquivalentTo(10))))
+		Expect(descriptors[1]).To(HaveKeyWithValue("entries", ContainElement(HaveKeyWithValue("value", ApiName+"-rule-2"))))
+		Expect(descriptors[1]).To(HaveKeyWithValue("token_bucket", HaveKeyWithValue("fill_interval", "1s")))
+
+		headers := getRateLimitHeaders(ef, 0)
+		Expect(headers).To(HaveLen(2))
+		Expect(headers[0]).To(HaveKeyWithValue("name", ":path"))
+		Expect(headers[1]).To(HaveKeyWithValue("name", ":method"))
+	})
+
+	It("should count the requests of a bucket separately from the requests of the rule", func() {
+		// given
+		rule := GetRuleFor(HeadersApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, allowStrategies)
+		rule.RateLimit = &gatewayv1beta1.RateLimit{
+			Requests: 10,
+			Unit:     gatewayv1beta1.RateLimitUnitHour,
+			Buckets: []*gatewayv1beta1.RateLimitBucket{
+				{Header: &gatewayv1beta1.HeaderMatch{Name: "x-tier", Value: "premium"}, Requests: 100},
+				{StaticClientIP: "10.0.0.1", Requests: 1},
+			},
+		}
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+
+		fakeClient := GetFakeClient(GetService(ServiceName))
+		processor := processors.EnvoyFilterProcessor{Creator: rateLimitEnvoyFilterCreator{}}
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), fakeClient, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+
+		ef := result[0].Obj.(*networkingv1alpha3.EnvoyFilter)
+		descriptors := getDescriptors(ef)
+		Expect(descriptors).To(HaveLen(3))
+		Expect(descriptors[0]).To(HaveKeyWithValue("entries", ContainElement(HaveKeyWithValue("value", ApiName+"-rule-0-bucket-0"))))
+		Expect(descriptors[0]).To(HaveKeyWithValue("token_bucket", HaveKeyWithValue("max_tokens", BeEquivalentTo(100))))
+		Expect(descriptors[1]).To(HaveKeyWithValue("entries", ContainElement(HaveKeyWithValue("value", ApiName+"-rule-0-bucket-1"))))
+		Expect(descriptors[2]).To(HaveKeyWithValue("entries", ContainElement(HaveKeyWithValue("value", ApiName+"-rule-0"))))
+		Expect(descriptors[2]).To(HaveKeyWithValue("token_bucket", HaveKeyWithValue("fill_interval", "3600s")))
+
+		Expect(getRateLimitHeaders(ef, 0)).To(ContainElement(HaveKeyWithValue("name", "x-tier")))
+		Expect(getRateLimitHeaders(ef, 1)).To(ContainElement(HaveKeyWithValue("name", processors.ClientIPHeader)))
+
+		ruleHeaders := getRateLimitHeaders(ef, 2)
+		Expect(ruleHeaders).To(HaveLen(4))
+		Expect(ruleHeaders[2]).To(And(HaveKeyWithValue("name", "x-tier"), HaveKeyWithValue("invert_match", true)))
+		Expect(ruleHeaders[3]).To(And(HaveKeyWithValue("name", processors.ClientIPHeader), HaveKeyWithValue("invert_match", true)))
+	})
+
+	It("should match the header conditions of the rule", func() {
+		// given
+		rule := GetRuleFor(HeadersApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, allowStrategies)
+		rule.Headers = map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: "2"}, "X-Tenant": {Prefix: "acme-"}}
+		rule.RateLimit = &gatewayv1beta1.RateLimit{Requests: 10, Unit: gatewayv1beta1.RateLimitUnitSecond}
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+
+		fakeClient := GetFakeClient(GetService(ServiceName))
+		processor := processors.EnvoyFilterProcessor{Creator: rateLimitEnvoyFilterCreator{}}
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), fakeClient, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+
+		headers := getRateLimitHeaders(result[0].Obj.(*networkingv1alpha3.EnvoyFilter), 0)
+		Expect(headers).To(HaveLen(4))
+		Expect(headers[2]).To(And(HaveKeyWithValue("name", "X-Api-Version"), HaveKeyWithValue("string_match", HaveKeyWithValue("exact", "2"))))
+		Expect(headers[3]).To(And(HaveKeyWithValue("name", "X-Tenant"), HaveKeyWithValue("string_match", HaveKeyWithValue("prefix", "acme-"))))
+	})
+
+	It("should create one EnvoyFilter for every weighted service of a rule", func() {
+		// given
+		canaryServiceName := "canary-service"
+		rule := GetRuleFor(HeadersApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, allowStrategies)
+		rule.Services = []*gatewayv1beta1.WeightedService{
+			{Service: gatewayv1beta1.Service{Name: &ServiceName, Port: &ServicePort}, Weight: 90},
+			{Service: gatewayv1beta1.Service{Name: &canaryServiceName, Port: &ServicePort}, Weight: 10},
+		}
+		rule.RateLimit = &gatewayv1beta1.RateLimit{Requests: 10, Unit: gatewayv1beta1.RateLimitUnitSecond}
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+
+		fakeClient := GetFakeClient(GetService(ServiceName), GetService(canaryServiceName))
+		processor := processors.EnvoyFilterProcessor{Creator: rateLimitEnvoyFilterCreator{}}
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), fakeClient, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(2))
+
+		var selectedApps []string
+		for _, change := range result {
+			selectedApps = append(selectedApps, change.Obj.(*networkingv1alpha3.EnvoyFilter).Spec.WorkloadSelector.Labels["app"])
+		}
+		Expect(selectedApps).To(ConsistOf(ServiceName, canaryServiceName))
+	})
+
+	It("should update the existing EnvoyFilter of the workload", func() {
+		// given
+		rule := GetRuleFor(HeadersApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, allowStrategies)
+		rule.RateLimit = &gatewayv1beta1.RateLimit{Requests: 10, Unit: gatewayv1beta1.RateLimitUnitSecond}
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+
+		existing := builders.NewEnvoyFilterBuilder().
+			WithName("existing").
+			WithNamespace(ApiNamespace).
+			WithLabel(processing.OwnerLabel, fmt.Sprintf("%s.%s", ApiName, ApiNamespace)).
+			WithSpec(builders.NewEnvoyFilterSpecBuilder().WithWorkloadSelector(map[string]string{"app": ServiceName}).Get()).
+			Get()
+
+		fakeClient := GetFakeClient(GetService(ServiceName), existing)
+		processor := processors.EnvoyFilterProcessor{Creator: rateLimitEnvoyFilterCreator{}}
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), fakeClient, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("update"))
+
+		ef := result[0].Obj.(*networkingv1alpha3.EnvoyFilter)
+		Expect(ef.Name).To(Equal("existing"))
+		Expect(ef.Spec.ConfigPatches).To(HaveLen(2))
+	})
+
+	It("should delete the EnvoyFilter when the rate limit is removed", func() {
+		// given
+		rule := GetRuleFor(HeadersApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, allowStrategies)
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+
+		existing := builders.NewEnvoyFilterBuilder().
+			WithName("existing").
+			WithNamespace(ApiNamespace).
+			WithLabel(processing.OwnerLabel, fmt.Sprintf("%s.%s", ApiName, ApiNamespace)).
+			WithSpec(builders.NewEnvoyFilterSpecBuilder().WithWorkloadSelector(map[string]string{"app": ServiceName}).Get()).
+			Get()
+
+		fakeClient := GetFakeClient(GetService(ServiceName), existing)
+		processor := processors.EnvoyFilterProcessor{Creator: rateLimitEnvoyFilterCreator{}}
+
+		// when
+		result, err := processor.EvaluateReconciliation(context.TODO(), fakeClient, apiRule)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("delete"))
+		Expect(result[0].Obj.GetName()).To(Equal("existing"))
+	})
+
+	It("should return an error when the service has no selector", func() {
+		// given
+		rule := GetRuleFor(HeadersApiPath, ApiMethods, []*gatewayv1beta1.Mutator{}, allowStrategies)
+		rule.RateLimit = &gatewayv1beta1.RateLimit{Requests: 10, Unit: gatewayv1beta1.RateLimitUnitSecond}
+		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{rule})
+
+		svc := GetService(ServiceName)
+		svc.Spec = corev1.ServiceSpec{}
+		fakeClient := GetFakeClient(svc)
+		processor := processors.EnvoyFilterProcessor{Creator: rateLimitEnvoyFilterCreator{}}
+
+		// when
+		_, err := processor.EvaluateReconciliation(context.TODO(), fakeClient, apiRule)
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(fmt.Sprintf("rate limit of the rule with path %s requires service %s to have a selector", HeadersApiPath, ServiceName)))
+	})
+})
+
+type rateLimitEnvoyFilterCreator struct{}
+
+func (r rateLimitEnvoyFilterCreator) Create(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule) (map[string]*networkingv1alpha3.EnvoyFilter, error) {
+	return processors.GenerateRateLimitEnvoyFilters(ctx, client, api, nil)
+}
@@ -5,13 +5,13 @@ import (
 	"time"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
 	"github.com/kyma-project/api-gateway/internal/processing"
+	"istio.io/api/networking/v1beta1"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const defaultHttpTimeout = time.Second * 180
-
 // VirtualServiceProcessor is the generic processor that handles the Virtual Service in the reconciliation of API Rule.
 type VirtualServiceProcessor struct {
 	Creator VirtualServiceCreator
@@ -75,5 +75,68 @@ func GetVirtualServiceHttpTimeout(apiRuleSpec gatewayv1beta1.APIRuleSpec, rule g
 		return time.Duration(*apiRuleSpec.Timeout) * time.Second
 	}
 
-	return defaultHttpTimeout
+	return helpers.DefaultHttpTimeout
+}
+
+// GetVirtualServiceHttpRetries returns the retry policy of the rule, which is taken from the rule if set,
+// otherwise from the APIRule spec. Returns nil if no retry policy is defined.
+func GetVirtualServiceHttpRetries(apiRuleSpec gatewayv1beta1.APIRuleSpec, rule gatewayv1beta1.Rule) *gatewayv1beta1.Retries {
+	if rule.Retries != nil {
+		return rule.Retries
+	}
+
+	return apiRuleSpec.Retries
+}
+
+// GetVirtualServiceCorsConfig returns the CORS configuration of the rule. Each field is taken from the rule if set,
+// otherwise from the APIRule spec and finally from the global configuration.
+func GetVirtualServiceCorsConfig(globalConfig *processing.CorsConfig, apiRuleSpec gatewayv1beta1.APIRuleSpec, rule gatewayv1beta1.Rule) *processing.CorsConfig {
+	config := &processing.CorsConfig{}
+	if globalConfig != nil {
+		*config = *globalConfig
+	}
+
+	for _, cors := range []*gatewayv1beta1.CorsPolicy{apiRuleSpec.Cors, rule.Cors} {
+		if cors == nil {
+			continue
+		}
+		if len(cors.AllowOrigins) > 0 {
+			config.AllowOrigins = toIstioStringMatches(cors.AllowOrigins)
+		}
+		if len(cors.AllowMethods) > 0 {
+			config.AllowMethods = cors.AllowMethods
+		}
+		if len(cors.AllowHeaders) > 0 {
+			config.AllowHeaders = cors.AllowHeaders
+		}
+		if len(cors.ExposeHeaders) > 0 {
+			config.ExposeHeaders = cors.ExposeHeaders
+		}
+		if cors.AllowCredentials != nil {
+			config.AllowCredentials = cors.AllowCredentials
+		}
+		if cors.MaxAge != nil {
+			maxAge := time.Duration(*cors.MaxAge) * time.Second
+			config.MaxAge = &maxAge
+		}
+	}
+
+	return config
+}
+
+func toIstioStringMatches(matches []*gatewayv1beta1.StringMatch) []*v1beta1.StringMatch {
+	var result []*v1beta1.StringMatch
+	for _, m := range matches {
+		switch {
+		case m == nil:
+			continue
+		case m.Exact != "":
+			result = append(result, &v1beta1.StringMatch{MatchType: &v1beta1.StringMatch_Exact{Exact: m.Exact}})
+		case m.Prefix != "":
+			result = append(result, &v1beta1.StringMatch{MatchType: &v1beta1.StringMatch_Prefix{Prefix: m.Prefix}})
+		case m.Regex != "":
+			result = append(result, &v1beta1.StringMatch{MatchType: &v1beta1.StringMatch_Regex{Regex: m.Regex}})
+		}
+	}
+	return result
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/kyma-project/api-gateway/internal/processing/processors"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"istio.io/api/networking/v1beta1"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -143,6 +144,141 @@ var _ = Describe("GetVirtualServiceHttpTimeout", func() {
 
 })
 
+var _ = Describe("GetVirtualServiceHttpRetries", func() {
+	It("should return nil when no retries are set", func() {
+		// when
+		retries := processors.GetVirtualServiceHttpRetries(gatewayv1beta1.APIRuleSpec{}, gatewayv1beta1.Rule{})
+
+		// then
+		Expect(retries).To(BeNil())
+	})
+
+	It("should return retries from apiRule when retries are set on apiRule only", func() {
+		// given
+		apiRuleSpec := gatewayv1beta1.APIRuleSpec{
+			Retries: &gatewayv1beta1.Retries{Attempts: 3, RetryOn: "5xx"},
+		}
+		rule := gatewayv1beta1.Rule{}
+
+		// when
+		retries := processors.GetVirtualServiceHttpRetries(apiRuleSpec, rule)
+
+		// then
+		Expect(retries.Attempts).To(Equal(int32(3)))
+		Expect(retries.RetryOn).To(Equal("5xx"))
+	})
+
+	It("should return retries from rule when retries are set on both apiRule and rule", func() {
+		// given
+		apiRuleSpec := gatewayv1beta1.APIRuleSpec{
+			Retries: &gatewayv1beta1.Retries{Attempts: 3, RetryOn: "5xx"},
+		}
+		rule := gatewayv1beta1.Rule{
+			Retries: &gatewayv1beta1.Retries{Attempts: 2, PerTryTimeout: &timeout10s},
+		}
+
+		// when
+		retries := processors.GetVirtualServiceHttpRetries(apiRuleSpec, rule)
+
+		// then
+		Expect(retries.Attempts).To(Equal(int32(2)))
+		Expect(*retries.PerTryTimeout).To(Equal(timeout10s))
+		Expect(retries.RetryOn).To(BeEmpty())
+	})
+})
+
+var _ = Describe("GetVirtualServiceCorsConfig", func() {
+	globalConfig := &processing.CorsConfig{
+		AllowOrigins: []*v1beta1.StringMatch{{MatchType: &v1beta1.StringMatch_Regex{Regex: ".*"}}},
+		AllowMethods: []string{"GET", "POST"},
+		AllowHeaders: []string{"Authorization"},
+	}
+
+	It("should return global config when no CORS policy is set", func() {
+		// given
+		apiRuleSpec := gatewayv1beta1.APIRuleSpec{}
+		rule := gatewayv1beta1.Rule{}
+
+		// when
+		config := processors.GetVirtualServiceCorsConfig(globalConfig, apiRuleSpec, rule)
+
+		// then
+		Expect(config).To(Equal(globalConfig))
+	})
+
+	It("should override global config with CORS policy from apiRule", func() {
+		// given
+		allowCredentials := true
+		var maxAge uint64 = 600
+		apiRuleSpec := gatewayv1beta1.APIRuleSpec{
+			Cors: &gatewayv1beta1.CorsPolicy{
+				AllowOrigins:     []*gatewayv1beta1.StringMatch{{Exact: "https://example.com"}, {Prefix: "https://app."}},
+				ExposeHeaders:    []string{"X-Request-Id"},
+				AllowCredentials: &allowCredentials,
+				MaxAge:           &maxAge,
+			},
+		}
+		rule := gatewayv1beta1.Rule{}
+
+		// when
+		config := processors.GetVirtualServiceCorsConfig(globalConfig, apiRuleSpec, rule)
+
+		// then
+		Expect(config.AllowOrigins).To(Equal([]*v1beta1.StringMatch{
+			{MatchType: &v1beta1.StringMatch_Exact{Exact: "https://example.com"}},
+			{MatchType: &v1beta1.StringMatch_Prefix{Prefix: "https://app."}},
+		}))
+		Expect(config.AllowMethods).To(Equal(globalConfig.AllowMethods))
+		Expect(config.AllowHeaders).To(Equal(globalConfig.AllowHeaders))
+		Expect(config.ExposeHeaders).To(Equal([]string{"X-Request-Id"}))
+		Expect(*config.AllowCredentials).To(BeTrue())
+		Expect(*config.MaxAge).To(Equal(time.Minute * 10))
+	})
+
+	It("should override apiRule CORS policy with CORS policy from rule", func() {
+		// given
+		allowCredentials := true
+		disallowCredentials := false
+		apiRuleSpec := gatewayv1beta1.APIRuleSpec{
+			Cors: &gatewayv1beta1.CorsPolicy{
+				AllowOrigins:     []*gatewayv1beta1.StringMatch{{Exact: "https://example.com"}},
+				AllowMethods:     []string{"GET"},
+				AllowCredentials: &allowCredentials,
+			},
+		}
+		rule := gatewayv1beta1.Rule{
+			Cors: &gatewayv1beta1.CorsPolicy{
+				AllowMethods:     []string{"PUT"},
+				AllowCredentials: &disallowCredentials,
+			},
+		}
+
+		// when
+		config := processors.GetVirtualServiceCorsConfig(globalConfig, apiRuleSpec, rule)
+
+		// then
+		Expect(config.AllowOrigins).To(Equal([]*v1beta1.StringMatch{{MatchType: &v1beta1.StringMatch_Exact{Exact: "https://example.com"}}}))
+		Expect(config.AllowMethods).To(Equal([]string{"PUT"}))
+		Expect(*config.AllowCredentials).To(BeFalse())
+		Expect(config.MaxAge).To(BeNil())
+	})
+
+	It("should not modify global config", func() {
+		// given
+		apiRuleSpec := gatewayv1beta1.APIRuleSpec{
+			Cors: &gatewayv1beta1.CorsPolicy{
+				AllowMethods: []string{"DELETE"},
+			},
+		}
+
+		// when
+		processors.GetVirtualServiceCorsConfig(globalConfig, apiRuleSpec, gatewayv1beta1.Rule{})
+
+		// then
+		Expect(globalConfig.AllowMethods).To(Equal([]string{"GET", "POST"}))
+	})
+})
+
 type mockVirtualServiceCreator struct {
 }
 
@@ -45,9 +45,13 @@ func Reconcile(ctx context.Context, client client.Client, log *logr.Logger, cmd
 		failuresJson, _ := json.Marshal(validationFailures)
 		log.Info(fmt.Sprintf(`Validation failure {"controller": "Api", "request": "%s/%s", "failures": %s}`, apiRule.Namespace, apiRule.Name, string(failuresJson)))
 		statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusSkipped)
-		return GenerateStatusFromFailures(validationFailures, statusBase)
+		status := GenerateStatusFromFailures(validationFailures, statusBase)
+		status.ValidationStatus = generateValidationStatus(validationFailures)
+		return status
 	}
 
+	validationStatus := toStatus(gatewayv1beta1.StatusOK, "")
+
 	for _, processor := range cmd.GetProcessors() {
 
 		objectChanges, err := processor.EvaluateReconciliation(ctx, client, apiRule)
@@ -55,19 +59,25 @@ func Reconcile(ctx context.Context, client client.Client, log *logr.Logger, cmd
 			log.Error(err, "Error during reconciliation")
 			statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusSkipped)
 			errorMap := map[ResourceSelector][]error{OnApiRule: {err}}
-			return GetStatusForErrorMap(errorMap, statusBase)
+			status := GetStatusForErrorMap(errorMap, statusBase)
+			status.ValidationStatus = validationStatus
+			return status
 		}
 
 		errorMap := applyChanges(ctx, client, objectChanges...)
 		if len(errorMap) > 0 {
 			log.Error(err, "Error during applying reconciliation")
 			statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusOK)
-			return GetStatusForErrorMap(errorMap, statusBase)
+			status := GetStatusForErrorMap(errorMap, statusBase)
+			status.ValidationStatus = validationStatus
+			return status
 		}
 	}
 
 	statusBase := cmd.GetStatusBase(gatewayv1beta1.StatusOK)
-	return GenerateStatusFromFailures([]validation.Failure{}, statusBase)
+	status := GenerateStatusFromFailures([]validation.Failure{}, statusBase)
+	status.ValidationStatus = validationStatus
+	return status
 }
 
 // applyChanges applies the given commands on the cluster
@@ -117,6 +127,8 @@ func objectToSelector(obj client.Object) ResourceSelector {
 		return OnRequestAuthentication
 	case OnAuthorizationPolicy.String():
 		return OnAuthorizationPolicy
+	case OnEnvoyFilter.String():
+		return OnEnvoyFilter
 	default:
 		return OnApiRule
 	}
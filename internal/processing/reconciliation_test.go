@@ -36,6 +36,7 @@ var _ = Describe("Reconcile", func() {
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
 		Expect(status.ApiRuleStatus.Description).To(Equal("error during validation"))
+		Expect(status.ValidationStatus).To(BeNil())
 		Expect(status.AccessRuleStatus.Code).To(Equal(gatewayv1beta1.StatusSkipped))
 		Expect(status.VirtualServiceStatus.Code).To(Equal(gatewayv1beta1.StatusSkipped))
 		Expect(status.AuthorizationPolicyStatus).To(BeNil())
@@ -62,6 +63,7 @@ var _ = Describe("Reconcile", func() {
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
 		Expect(status.ApiRuleStatus.Description).To(Equal("Validation error: Attribute \"some.path\": The value is not allowed"))
+		Expect(status.ValidationStatus.Code).To(Equal(gatewayv1beta1.StatusError))
 		Expect(status.AccessRuleStatus.Code).To(Equal(gatewayv1beta1.StatusSkipped))
 		Expect(status.VirtualServiceStatus.Code).To(Equal(gatewayv1beta1.StatusSkipped))
 		Expect(status.AuthorizationPolicyStatus).To(BeNil())
@@ -93,6 +95,7 @@ var _ = Describe("Reconcile", func() {
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
 		Expect(status.ApiRuleStatus.Description).To(Equal("error during processor execution"))
+		Expect(status.ValidationStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
 		Expect(status.AccessRuleStatus.Code).To(Equal(gatewayv1beta1.StatusSkipped))
 		Expect(status.VirtualServiceStatus.Code).To(Equal(gatewayv1beta1.StatusSkipped))
 		Expect(status.AuthorizationPolicyStatus).To(BeNil())
@@ -166,6 +169,7 @@ var _ = Describe("Reconcile", func() {
 
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+		Expect(status.ValidationStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
 		Expect(status.AccessRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
 		Expect(status.VirtualServiceStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
 		Expect(status.AuthorizationPolicyStatus).To(BeNil())
@@ -13,6 +13,9 @@ type ReconciliationStatus struct {
 	AccessRuleStatus            *gatewayv1beta1.APIRuleResourceStatus
 	RequestAuthenticationStatus *gatewayv1beta1.APIRuleResourceStatus
 	AuthorizationPolicyStatus   *gatewayv1beta1.APIRuleResourceStatus
+	EnvoyFilterStatus           *gatewayv1beta1.APIRuleResourceStatus
+	// ValidationStatus is the result of the APIRule validation. It is nil if the validation was not executed.
+	ValidationStatus *gatewayv1beta1.APIRuleResourceStatus
 }
 
 func (status ReconciliationStatus) HasError() bool {
@@ -31,6 +34,9 @@ func (status ReconciliationStatus) HasError() bool {
 	if status.RequestAuthenticationStatus != nil && status.RequestAuthenticationStatus.Code == gatewayv1beta1.StatusError {
 		return true
 	}
+	if status.EnvoyFilterStatus != nil && status.EnvoyFilterStatus.Code == gatewayv1beta1.StatusError {
+		return true
+	}
 	return false
 }
 
@@ -42,6 +48,7 @@ const (
 	OnAccessRule
 	OnAuthorizationPolicy
 	OnRequestAuthentication
+	OnEnvoyFilter
 )
 
 func (r ResourceSelector) String() string {
@@ -54,6 +61,8 @@ func (r ResourceSelector) String() string {
 		return "RequestAuthentication"
 	case OnAuthorizationPolicy:
 		return "AuthorizationPolicy"
+	case OnEnvoyFilter:
+		return "EnvoyFilter"
 	default:
 		// If no Kind is resolved from the resource (e.g. subresource CRD is missing)
 		return "APIRule"
@@ -87,6 +96,8 @@ func GetStatusForErrorMap(errorMap map[ResourceSelector][]error, statusBase Reco
 			statusBase.AuthorizationPolicyStatus = generateStatusFromErrors(val)
 		case OnRequestAuthentication:
 			statusBase.RequestAuthenticationStatus = generateStatusFromErrors(val)
+		case OnEnvoyFilter:
+			statusBase.EnvoyFilterStatus = generateStatusFromErrors(val)
 		}
 
 		if key != OnApiRule {
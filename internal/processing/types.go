@@ -1,6 +1,8 @@
 package processing
 
 import (
+	"time"
+
 	v1beta1 "istio.io/api/networking/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -56,6 +58,10 @@ type CorsConfig struct {
 	AllowOrigins []*v1beta1.StringMatch
 	AllowMethods []string
 	AllowHeaders []string
+	// ExposeHeaders, AllowCredentials and MaxAge are not configurable globally and are only set by APIRule overrides.
+	ExposeHeaders    []string
+	AllowCredentials *bool
+	MaxAge           *time.Duration
 }
 
 type ReconciliationConfig struct {
@@ -67,4 +73,12 @@ type ReconciliationConfig struct {
 	ServiceBlockList  map[string][]string
 	DomainAllowList   []string
 	HostBlockList     []string
+	// IntrospectionProvider is the name of the extension provider in the Istio mesh config referencing the bundled
+	// token introspection authorizer. If empty, the tokens can only be introspected by Oathkeeper.
+	IntrospectionProvider string
+	// ScopeClaimKeys are the names of the JWT claims containing the scopes. If empty, the scp, scope and scopes claims are used.
+	ScopeClaimKeys []string
+	// GatewayPrincipals are the principals of the ingress gateways by gateway in the format namespace/name. If a gateway
+	// has no principal, the principal of the default Istio Ingress Gateway is used, and rules can't be restricted to it.
+	GatewayPrincipals map[string]string
 }
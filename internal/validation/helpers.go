@@ -2,6 +2,7 @@ package validation
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -10,26 +11,30 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
 )
 
+// hasPathAndMethodDuplicates returns true if several rules match the same requests, which are the requests with the same
+// path, method, and header and query parameter match conditions.
 func hasPathAndMethodDuplicates(rules []gatewayv1beta1.Rule) bool {
 	duplicates := map[string]bool{}
 
 	if len(rules) > 1 {
 		for _, rule := range rules {
+			matchKey := helpers.GetRuleMatchKey(rule)
 			if len(rule.Methods) > 0 {
 				for _, method := range rule.Methods {
-					tmp := fmt.Sprintf("%s:%s", rule.Path, method)
+					tmp := fmt.Sprintf("%s:%s", matchKey, method)
 					if duplicates[tmp] {
 						return true
 					}
 					duplicates[tmp] = true
 				}
 			} else {
-				if duplicates[rule.Path] {
+				if duplicates[matchKey] {
 					return true
 				}
-				duplicates[rule.Path] = true
+				duplicates[matchKey] = true
 			}
 		}
 	}
@@ -84,3 +89,14 @@ func configEmpty(config *runtime.RawExtension) bool {
 func ConfigNotEmpty(config *runtime.RawExtension) bool {
 	return !configEmpty(config)
 }
+
+// hasIntrospectionUrl returns true if the handler configures an introspection URL, so that the tokens are introspected
+// without Oathkeeper
+func hasIntrospectionUrl(handler *gatewayv1beta1.Handler) bool {
+	if !ConfigNotEmpty(handler.Config) {
+		return false
+	}
+
+	var config gatewayv1beta1.IntrospectionConfig
+	return json.Unmarshal(handler.Config.Raw, &config) == nil && config.IntrospectionUrl != ""
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -258,3 +259,49 @@ var _ = Describe("Validate Gateway Name", func() {
 		Expect(valid).To(BeFalse())
 	})
 })
+
+var _ = Describe("hasPathAndMethodDuplicates function", func() {
+
+	It("Should return true for rules with the same path and method", func() {
+		//given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET", "POST"}},
+			{Path: "/orders", Methods: []string{"GET"}},
+		}
+
+		//when
+		duplicates := hasPathAndMethodDuplicates(rules)
+
+		//then
+		Expect(duplicates).To(BeTrue())
+	})
+
+	It("Should return false for rules with the same path and method, but different header matches", func() {
+		//given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}},
+			{Path: "/orders", Methods: []string{"GET"}, Headers: map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}},
+			{Path: "/orders", Methods: []string{"GET"}, QueryParams: map[string]*gatewayv1beta1.StringMatch{"beta": {Exact: "true"}}},
+		}
+
+		//when
+		duplicates := hasPathAndMethodDuplicates(rules)
+
+		//then
+		Expect(duplicates).To(BeFalse())
+	})
+
+	It("Should return true for rules with header matches that differ only in the case of the header name", func() {
+		//given
+		rules := []gatewayv1beta1.Rule{
+			{Path: "/orders", Methods: []string{"GET"}, Headers: map[string]*gatewayv1beta1.StringMatch{"X-Api-Version": {Exact: "2"}}},
+			{Path: "/orders", Methods: []string{"GET"}, Headers: map[string]*gatewayv1beta1.StringMatch{"x-api-version": {Exact: "2"}}},
+		}
+
+		//when
+		duplicates := hasPathAndMethodDuplicates(rules)
+
+		//then
+		Expect(duplicates).To(BeTrue())
+	})
+})
@@ -3,7 +3,11 @@ package validation
 import (
 	"context"
 	"fmt"
+	"net"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"google.golang.org/appengine/log"
@@ -13,10 +17,22 @@ import (
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
 	apiv1beta1 "istio.io/api/type/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// gatewayRegex matches the Istio Gateway references, which is the same pattern as the one of the gateway field in the CRD
+var gatewayRegex = regexp.MustCompile(`^[0-9a-z-_]+(\/[0-9a-z-_]+|(\.[0-9a-z-_]+)*)$`)
+
+// principalRegex matches the principals of workloads in the format <trust domain>/ns/<namespace>/sa/<service account>. The
+// trust domain and the service account can be *, since AuthorizationPolicies support suffix and prefix matches.
+var principalRegex = regexp.MustCompile(`^(\*|[a-zA-Z0-9]([-a-zA-Z0-9.]*[a-zA-Z0-9])?)/ns/[a-z0-9]([-a-z0-9]*[a-z0-9])?/sa/(\*|[a-z0-9]([-a-z0-9.]*[a-z0-9])?)$`)
+
+var corsMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "CONNECT", "OPTIONS", "TRACE"}
+
 // Validators for AccessStrategies
 var vldNoConfig = &noConfigAccStrValidator{}
 var vldDummy = &dummyHandlerValidator{}
@@ -41,13 +57,30 @@ type rulesValidator interface {
 	Validate(attrPath string, rules []gatewayv1beta1.Rule) []Failure
 }
 
+type servicesValidator interface {
+	Validate(attrPath string, rule gatewayv1beta1.Rule, services []*gatewayv1beta1.WeightedService) []Failure
+}
+
+type rewriteValidator interface {
+	Validate(attrPath string, rule gatewayv1beta1.Rule) []Failure
+}
+
+type gatewaysValidator interface {
+	Validate(attrPath string, api *gatewayv1beta1.APIRule) []Failure
+}
+
 // APIRuleValidator is used to validate github.com/kyma-project/api-gateway/api/v1beta1/APIRule instances
 type APIRuleValidator struct {
 	HandlerValidator          handlerValidator
+	ExtAuthValidator          handlerValidator
+	IntrospectionValidator    handlerValidator
 	AccessStrategiesValidator accessStrategyValidator
 	MutatorsValidator         mutatorValidator
 	InjectionValidator        injectionValidator
 	RulesValidator            rulesValidator
+	ServicesValidator         servicesValidator
+	RewriteValidator          rewriteValidator
+	GatewaysValidator         gatewaysValidator
 	ServiceBlockList          map[string][]string
 	DomainAllowList           []string
 	HostBlockList             []string
@@ -68,9 +101,18 @@ func (v *APIRuleValidator) Validate(ctx context.Context, client client.Client, a
 	if api.Spec.Service != nil {
 		failures = append(failures, v.validateService(".spec.service", api)...)
 	}
-	failures = append(failures, v.validateHost(".spec.host", vsList, api)...)
+	if len(api.Spec.Services) > 0 {
+		failures = append(failures, v.validateWeightedServices(".spec", api.Spec.Service, api.Spec.Services, api)...)
+	}
+	failures = append(failures, v.validateHosts(vsList, api)...)
 	failures = append(failures, v.validateGateway(".spec.gateway", api.Spec.Gateway)...)
-	failures = append(failures, v.validateRules(ctx, client, ".spec.rules", api.Spec.Service == nil, api)...)
+	failures = append(failures, validateGateways(".spec.gateways", api.Spec)...)
+	if v.GatewaysValidator != nil {
+		failures = append(failures, v.GatewaysValidator.Validate(".spec.rules", api)...)
+	}
+	failures = append(failures, v.validateRules(ctx, client, ".spec.rules", api.Spec.Service == nil && len(api.Spec.Services) == 0, api)...)
+	failures = append(failures, v.validateCors(".spec.cors", api.Spec.Cors)...)
+	failures = append(failures, validateRateLimitConflicts(ctx, client, ".spec.rules", api)...)
 
 	return failures
 }
@@ -88,30 +130,153 @@ func (v *APIRuleValidator) ValidateConfig(config *helpers.Config) []Failure {
 				Message: fmt.Sprintf("Unsupported JWT Handler: %s", config.JWTHandler),
 			})
 		}
+		for _, key := range config.ScopeClaimKeys {
+			if !helpers.IsValidClaimPath(key) {
+				problems = append(problems, Failure{
+					Message: fmt.Sprintf("Invalid scope claim key: %s", key),
+				})
+			}
+		}
+		var gateways []string
+		for gateway := range config.GatewayPrincipals {
+			gateways = append(gateways, gateway)
+		}
+		sort.Strings(gateways)
+		for _, gateway := range gateways {
+			if len(strings.Split(gateway, "/")) != 2 {
+				problems = append(problems, Failure{
+					Message: fmt.Sprintf("Invalid gateway %s, must have the format namespace/name", gateway),
+				})
+			}
+			if principal := config.GatewayPrincipals[gateway]; !principalRegex.MatchString(principal) || strings.Contains(principal, "*") {
+				problems = append(problems, Failure{
+					Message: fmt.Sprintf("Invalid principal of gateway %s: %s", gateway, principal),
+				})
+			}
+		}
 	}
 
 	return problems
 }
 
-func (v *APIRuleValidator) validateHost(attributePath string, vsList networkingv1beta1.VirtualServiceList, api *gatewayv1beta1.APIRule) []Failure {
+func (v *APIRuleValidator) validateHosts(vsList networkingv1beta1.VirtualServiceList, api *gatewayv1beta1.APIRule) []Failure {
 	var problems []Failure
-	if api.Spec.Host == nil {
+	if api.Spec.Host == nil && len(api.Spec.Hosts) == 0 {
 		problems = append(problems, Failure{
-			AttributePath: attributePath,
+			AttributePath: ".spec.host",
 			Message:       "Host was nil",
 		})
 		return problems
 	}
 
-	host := *api.Spec.Host
-	if !helpers.HostIncludesDomain(*api.Spec.Host) {
+	// The hosts of APIRules exposed only to the workloads in the service mesh are internal aliases, that are neither
+	// completed with the default domain nor restricted by the domain allowlist and host blocklist
+	meshOnly := api.Spec.IsMeshOnly()
+	hostKey := func(host string) string {
+		if meshOnly {
+			return host
+		}
+		return helpers.GetHostWithDomain(host, v.DefaultDomainName)
+	}
+
+	definedHosts := make(map[string]bool)
+	if api.Spec.Host != nil {
+		definedHosts[hostKey(*api.Spec.Host)] = true
+		if meshOnly {
+			problems = append(problems, validateMeshHost(".spec.host", *api.Spec.Host, vsList, api)...)
+		} else {
+			problems = append(problems, v.validateHost(".spec.host", *api.Spec.Host, vsList, api)...)
+		}
+	}
+
+	for i, host := range api.Spec.Hosts {
+		attributePath := fmt.Sprintf(".spec.hosts[%d]", i)
+		if host == nil {
+			problems = append(problems, Failure{
+				AttributePath: attributePath,
+				Message:       "Host was nil",
+			})
+			continue
+		}
+
+		if definedHosts[hostKey(string(*host))] {
+			problems = append(problems, Failure{
+				AttributePath: attributePath,
+				Message:       "Host is defined more than once",
+			})
+			continue
+		}
+		definedHosts[hostKey(string(*host))] = true
+
+		if meshOnly {
+			problems = append(problems, validateMeshHost(attributePath, string(*host), vsList, api)...)
+		} else {
+			problems = append(problems, v.validateHost(attributePath, string(*host), vsList, api)...)
+		}
+	}
+
+	if len(api.Spec.MeshHosts) > 0 && meshOnly {
+		problems = append(problems, Failure{
+			AttributePath: ".spec.meshHosts",
+			Message:       fmt.Sprintf("Mesh hosts can't be used together with the %s gateway, use the hosts instead", gatewayv1beta1.MeshGateway),
+		})
+		return problems
+	}
+
+	for i, host := range api.Spec.MeshHosts {
+		attributePath := fmt.Sprintf(".spec.meshHosts[%d]", i)
+		if host == nil {
+			problems = append(problems, Failure{
+				AttributePath: attributePath,
+				Message:       "Host was nil",
+			})
+			continue
+		}
+
+		if definedHosts[string(*host)] {
+			problems = append(problems, Failure{
+				AttributePath: attributePath,
+				Message:       "Host is defined more than once",
+			})
+			continue
+		}
+		definedHosts[string(*host)] = true
+
+		problems = append(problems, validateMeshHost(attributePath, string(*host), vsList, api)...)
+	}
+
+	return problems
+}
+
+// validateMeshHost validates an internal host the service is exposed under to the workloads in the service mesh. Since
+// the host isn't exposed through an ingress gateway, only conflicts with other Virtual Services are checked.
+func validateMeshHost(attributePath string, host string, vsList networkingv1beta1.VirtualServiceList, api *gatewayv1beta1.APIRule) []Failure {
+	var problems []Failure
+
+	for _, vs := range vsList.Items {
+		if occupiesHost(vs, host) && !ownedBy(vs, api) {
+			problems = append(problems, Failure{
+				AttributePath: attributePath,
+				Message:       "This host is occupied by another Virtual Service",
+			})
+		}
+	}
+
+	return problems
+}
+
+func (v *APIRuleValidator) validateHost(attributePath string, host string, vsList networkingv1beta1.VirtualServiceList, api *gatewayv1beta1.APIRule) []Failure {
+	var problems []Failure
+
+	hostWithDomain := host
+	if !helpers.HostIncludesDomain(host) {
 		if v.DefaultDomainName == "" {
 			problems = append(problems, Failure{
 				AttributePath: attributePath,
 				Message:       "Host does not contain a domain name and no default domain name is configured",
 			})
 		}
-		host = helpers.GetHostWithDefaultDomain(host, v.DefaultDomainName)
+		hostWithDomain = helpers.GetHostWithDefaultDomain(host, v.DefaultDomainName)
 	} else if len(v.DomainAllowList) > 0 {
 		// Do the allowList check only if the list is actually provided AND the default domain name is not used.
 		domainFound := false
@@ -133,7 +298,6 @@ func (v *APIRuleValidator) validateHost(attributePath string, vsList networkingv
 	}
 
 	for _, blockedHost := range v.HostBlockList {
-		host := *api.Spec.Host
 		if blockedHost == host {
 			subdomain := strings.Split(host, ".")[0]
 			problems = append(problems, Failure{
@@ -144,7 +308,7 @@ func (v *APIRuleValidator) validateHost(attributePath string, vsList networkingv
 	}
 
 	for _, vs := range vsList.Items {
-		if occupiesHost(vs, host) && !ownedBy(vs, api) {
+		if occupiesHost(vs, hostWithDomain) && !ownedBy(vs, api) {
 			problems = append(problems, Failure{
 				AttributePath: attributePath,
 				Message:       "This host is occupied by another Virtual Service",
@@ -177,6 +341,59 @@ func (v *APIRuleValidator) validateGateway(attributePath string, gateway *string
 	return nil
 }
 
+// validateGateways rejects additional gateways that are invalid or already defined
+func validateGateways(attributePath string, spec gatewayv1beta1.APIRuleSpec) []Failure {
+	var problems []Failure
+
+	definedGateways := make(map[string]bool)
+	if spec.Gateway != nil {
+		definedGateways[*spec.Gateway] = true
+	}
+
+	for i, gateway := range spec.Gateways {
+		if !gatewayRegex.MatchString(gateway) {
+			problems = append(problems, Failure{
+				AttributePath: fmt.Sprintf("%s[%d]", attributePath, i),
+				Message:       fmt.Sprintf("Invalid gateway %q", gateway),
+			})
+		} else if definedGateways[gateway] {
+			problems = append(problems, Failure{
+				AttributePath: fmt.Sprintf("%s[%d]", attributePath, i),
+				Message:       "Gateway is defined more than once",
+			})
+		}
+		definedGateways[gateway] = true
+	}
+
+	return problems
+}
+
+// validateRuleGateways validates that a rule is only restricted to gateways the APIRule is exposed through
+func validateRuleGateways(attributePath string, spec gatewayv1beta1.APIRuleSpec, gateways []string) []Failure {
+	var problems []Failure
+
+	definedGateways := make(map[string]bool)
+	for i, gateway := range gateways {
+		if definedGateways[gateway] {
+			problems = append(problems, Failure{
+				AttributePath: fmt.Sprintf("%s[%d]", attributePath, i),
+				Message:       "Gateway is defined more than once",
+			})
+			continue
+		}
+		definedGateways[gateway] = true
+
+		if !slices.Contains(spec.GetGateways(), gateway) {
+			problems = append(problems, Failure{
+				AttributePath: fmt.Sprintf("%s[%d]", attributePath, i),
+				Message:       fmt.Sprintf("Gateway %s is not a gateway of the APIRule", gateway),
+			})
+		}
+	}
+
+	return problems
+}
+
 // Validates whether all rules are defined correctly
 // Checks whether all rules have service defined for them if checkForService is true
 func (v *APIRuleValidator) validateRules(ctx context.Context, client client.Client, attributePath string, checkForService bool, api *gatewayv1beta1.APIRule) []Failure {
@@ -191,13 +408,18 @@ func (v *APIRuleValidator) validateRules(ctx context.Context, client client.Clie
 	if hasPathAndMethodDuplicates(rules) {
 		problems = append(problems, Failure{AttributePath: attributePath, Message: "multiple rules defined for the same path and method"})
 	}
+	problems = append(problems, validateSharedPathCors(attributePath, rules)...)
 
 	for i, r := range rules {
 		attributePathWithRuleIndex := fmt.Sprintf("%s[%d]", attributePath, i)
+		problems = append(problems, v.validatePath(attributePathWithRuleIndex+".path", r)...)
 		problems = append(problems, v.validateMethods(attributePathWithRuleIndex+".methods", r.Methods)...)
-		if checkForService && r.Service == nil {
+		if checkForService && r.Service == nil && len(r.Services) == 0 && r.Redirect == nil && r.DirectResponse == nil {
 			problems = append(problems, Failure{AttributePath: attributePathWithRuleIndex + ".service", Message: "No service defined with no main service on spec level"})
 		}
+		if len(r.Services) > 0 {
+			problems = append(problems, v.validateWeightedServices(attributePathWithRuleIndex, r.Service, r.Services, api)...)
+		}
 		if r.Service != nil {
 			labelSelector, err := helpers.GetLabelSelectorFromService(ctx, client, r.Service, api, &r)
 			if err != nil {
@@ -220,6 +442,8 @@ func (v *APIRuleValidator) validateRules(ctx context.Context, client client.Clie
 					}
 				}
 			}
+		} else if len(r.Services) > 0 || len(api.Spec.Services) > 0 {
+			problems = append(problems, v.validateWeightedServicesAccessStrategies(ctx, client, attributePathWithRuleIndex+".accessStrategies", r, api)...)
 		} else if api.Spec.Service != nil {
 			labelSelector, err := helpers.GetLabelSelectorFromService(ctx, client, api.Spec.Service, api, nil)
 			if err != nil {
@@ -233,6 +457,25 @@ func (v *APIRuleValidator) validateRules(ctx context.Context, client client.Clie
 			problems = append(problems, v.validateAccessStrategies(attributePathWithRuleIndex+".accessStrategies", r.AccessStrategies, labelSelector, helpers.FindServiceNamespace(api, &r))...)
 		}
 
+		if services := helpers.GetRuleServices(api, &r); v.ServicesValidator != nil && len(services) > 1 {
+			problems = append(problems, v.ServicesValidator.Validate(attributePathWithRuleIndex, r, services)...)
+		}
+
+		problems = append(problems, v.validateCors(attributePathWithRuleIndex+".cors", r.Cors)...)
+		problems = append(problems, v.validateCorsCredentials(attributePathWithRuleIndex, api.Spec.Cors, r.Cors)...)
+		problems = append(problems, v.validateRetries(attributePathWithRuleIndex, api.Spec, r)...)
+		problems = append(problems, v.validateRateLimit(attributePathWithRuleIndex+".rateLimit", r.RateLimit, api)...)
+		problems = append(problems, v.validateIpBlocks(attributePathWithRuleIndex+".ipAllowList", r.IPAllowList)...)
+		problems = append(problems, v.validateIpBlocks(attributePathWithRuleIndex+".ipDenyList", r.IPDenyList)...)
+		problems = append(problems, v.validateRuleSource(attributePathWithRuleIndex+".from", r.From)...)
+		problems = append(problems, validateRuleGateways(attributePathWithRuleIndex+".gateways", api.Spec, r.Gateways)...)
+		problems = append(problems, v.validateRewrite(attributePathWithRuleIndex+".rewrite", r)...)
+		problems = append(problems, v.validateResponse(attributePathWithRuleIndex, r)...)
+		problems = append(problems, v.validateMatchConditions(attributePathWithRuleIndex, r)...)
+		if v.RewriteValidator != nil && r.Rewrite != nil {
+			problems = append(problems, v.RewriteValidator.Validate(attributePathWithRuleIndex+".rewrite", r)...)
+		}
+
 		if v.MutatorsValidator != nil {
 			mutatorFailures := v.MutatorsValidator.Validate(attributePathWithRuleIndex, r)
 			problems = append(problems, mutatorFailures...)
@@ -248,6 +491,515 @@ func (v *APIRuleValidator) validateRules(ctx context.Context, client client.Clie
 	return problems
 }
 
+// validateSharedPathCors rejects rules that match the same requests apart from the method, but define different CORS policies.
+// Their VirtualService routes are matched by method, but CORS preflight requests are always sent with the OPTIONS method and
+// are therefore handled by the route of the first of these rules.
+func validateSharedPathCors(attributePath string, rules []gatewayv1beta1.Rule) []Failure {
+	var problems []Failure
+
+	firstRules := make(map[string]gatewayv1beta1.Rule)
+	for i, rule := range rules {
+		matchKey := helpers.GetRuleMatchKey(rule)
+		first, exists := firstRules[matchKey]
+		if !exists {
+			firstRules[matchKey] = rule
+			continue
+		}
+		if !equality.Semantic.DeepEqual(first.Cors, rule.Cors) {
+			problems = append(problems, Failure{
+				AttributePath: fmt.Sprintf("%s[%d].cors", attributePath, i),
+				Message:       "Rules with the same path and match conditions must have the same CORS policy",
+			})
+		}
+	}
+
+	return problems
+}
+
+// Validates the services the traffic is split between on spec or rule level
+func (v *APIRuleValidator) validateWeightedServices(attributePath string, service *gatewayv1beta1.Service, services []*gatewayv1beta1.WeightedService, api *gatewayv1beta1.APIRule) []Failure {
+	var problems []Failure
+
+	if service != nil {
+		problems = append(problems, Failure{AttributePath: attributePath + ".services", Message: "service and services cannot be defined at the same time"})
+	}
+
+	var weights uint32
+	for i, s := range services {
+		if s == nil {
+			continue
+		}
+		weights += s.Weight
+
+		serviceNamespace := api.Namespace
+		if s.Namespace != nil {
+			serviceNamespace = *s.Namespace
+		}
+		for namespace, blockedServices := range v.ServiceBlockList {
+			for _, svc := range blockedServices {
+				if s.Name != nil && svc == *s.Name && namespace == serviceNamespace {
+					problems = append(problems, Failure{
+						AttributePath: fmt.Sprintf("%s.services[%d].name", attributePath, i),
+						Message:       fmt.Sprintf("Service %s in namespace %s is blocklisted", svc, namespace),
+					})
+				}
+			}
+		}
+	}
+
+	if weights != 100 {
+		problems = append(problems, Failure{
+			AttributePath: attributePath + ".services",
+			Message:       fmt.Sprintf("The weights of the services must sum up to 100, but sum up to %d", weights),
+		})
+	}
+
+	return problems
+}
+
+// Validates the access strategies of a rule that splits the traffic between several services for the workload of every service
+func (v *APIRuleValidator) validateWeightedServicesAccessStrategies(ctx context.Context, client client.Client, attributePath string, rule gatewayv1beta1.Rule, api *gatewayv1beta1.APIRule) []Failure {
+	var problems []Failure
+
+	for _, service := range helpers.GetRuleServices(api, &rule) {
+		labelSelector, err := helpers.GetLabelSelectorFromService(ctx, client, &service.Service, api, &rule)
+		if err != nil {
+			l, errorCtx := logr.FromContext(ctx)
+			if errorCtx != nil {
+				log.Errorf(ctx, "No logger in context: %s", errorCtx)
+			} else {
+				l.Info("Couldn't get label selectors for service", "error", err)
+			}
+		}
+
+		// The access strategies are the same for all services, so only failures specific to the workload are added again
+		for _, failure := range v.validateAccessStrategies(attributePath, rule.AccessStrategies, labelSelector, *service.Namespace) {
+			if !slices.Contains(failureMessages(problems), failure.AttributePath+failure.Message) {
+				problems = append(problems, failure)
+			}
+		}
+	}
+
+	return problems
+}
+
+func failureMessages(failures []Failure) []string {
+	var messages []string
+	for _, f := range failures {
+		messages = append(messages, f.AttributePath+f.Message)
+	}
+	return messages
+}
+
+// Validates the CORS policy defined on spec or rule level
+func (v *APIRuleValidator) validateCors(attributePath string, cors *gatewayv1beta1.CorsPolicy) []Failure {
+	var problems []Failure
+
+	if cors == nil {
+		return problems
+	}
+
+	for i, origin := range cors.AllowOrigins {
+		problems = append(problems, validateStringMatch(fmt.Sprintf("%s.allowOrigins[%d]", attributePath, i), origin)...)
+	}
+
+	for i, method := range cors.AllowMethods {
+		if !slices.Contains(corsMethods, method) {
+			problems = append(problems, Failure{
+				AttributePath: fmt.Sprintf("%s.allowMethods[%d]", attributePath, i),
+				Message:       fmt.Sprintf("Unsupported method: %s", method),
+			})
+		}
+	}
+
+	return problems
+}
+
+// Validates that credentials are only allowed for origins explicitly defined in the APIRule, since the global default
+// origins may allow any origin
+func (v *APIRuleValidator) validateCorsCredentials(attributePath string, specCors *gatewayv1beta1.CorsPolicy, ruleCors *gatewayv1beta1.CorsPolicy) []Failure {
+	var allowCredentials *bool
+	var allowOrigins []*gatewayv1beta1.StringMatch
+	for _, cors := range []*gatewayv1beta1.CorsPolicy{specCors, ruleCors} {
+		if cors == nil {
+			continue
+		}
+		if cors.AllowCredentials != nil {
+			allowCredentials = cors.AllowCredentials
+		}
+		if len(cors.AllowOrigins) > 0 {
+			allowOrigins = cors.AllowOrigins
+		}
+	}
+
+	if allowCredentials == nil || !*allowCredentials {
+		return nil
+	}
+
+	if len(allowOrigins) == 0 {
+		return []Failure{{AttributePath: attributePath + ".cors.allowCredentials", Message: "allowCredentials requires allowOrigins to be defined in the APIRule"}}
+	}
+
+	for _, origin := range allowOrigins {
+		if origin != nil && (origin.Exact == "*" || origin.Regex == ".*" || origin.Regex == "*") {
+			return []Failure{{AttributePath: attributePath + ".cors.allowCredentials", Message: "allowCredentials cannot be used with a wildcard origin"}}
+		}
+	}
+
+	return nil
+}
+
+// Validates that all attempts of the retry policy effective for the rule fit into the timeout of the rule
+func (v *APIRuleValidator) validateRetries(attributePath string, spec gatewayv1beta1.APIRuleSpec, rule gatewayv1beta1.Rule) []Failure {
+	retries := rule.Retries
+	if retries == nil {
+		retries = spec.Retries
+	}
+
+	if retries == nil || retries.PerTryTimeout == nil {
+		return nil
+	}
+
+	timeout := helpers.DefaultHttpTimeout
+	if rule.Timeout != nil {
+		timeout = time.Duration(*rule.Timeout) * time.Second
+	} else if spec.Timeout != nil {
+		timeout = time.Duration(*spec.Timeout) * time.Second
+	}
+
+	// The initial call is an attempt too, so the number of attempts is one more than the number of retries
+	retriesTimeout := time.Duration(retries.Attempts+1) * time.Duration(*retries.PerTryTimeout) * time.Second
+	if retriesTimeout > timeout {
+		return []Failure{{
+			AttributePath: attributePath + ".retries",
+			Message:       fmt.Sprintf("The retry attempts take up to %s, which exceeds the timeout of %s", retriesTimeout, timeout),
+		}}
+	}
+
+	return nil
+}
+
+// Validates the buckets of the rate limit defined on rule level. The static client IP is read from a header set by the
+// ingress gateway, which workloads in the mesh can set on their own, so it's not supported if the service is exposed to
+// the mesh.
+func (v *APIRuleValidator) validateRateLimit(attributePath string, rateLimit *gatewayv1beta1.RateLimit, api *gatewayv1beta1.APIRule) []Failure {
+	var problems []Failure
+
+	if rateLimit == nil {
+		return problems
+	}
+
+	for i, bucket := range rateLimit.Buckets {
+		bucketAttributePath := fmt.Sprintf("%s.buckets[%d]", attributePath, i)
+		switch {
+		case bucket == nil || (bucket.Header == nil) == (bucket.StaticClientIP == ""):
+			problems = append(problems, Failure{AttributePath: bucketAttributePath, Message: "Exactly one of header or staticClientIP must be defined"})
+		case bucket.StaticClientIP != "" && net.ParseIP(bucket.StaticClientIP) == nil:
+			problems = append(problems, Failure{AttributePath: bucketAttributePath + ".staticClientIP", Message: fmt.Sprintf("Invalid IP address: %s", bucket.StaticClientIP)})
+		case bucket.StaticClientIP != "" && api.Spec.IsExposedToMesh():
+			problems = append(problems, Failure{AttributePath: bucketAttributePath + ".staticClientIP", Message: "Static client IPs are not supported if the service is exposed to the mesh"})
+		}
+	}
+
+	return problems
+}
+
+// Validates that the workloads rate limited by the rules are not rate limited by another APIRule. The local rate limit of
+// a workload is configured by one EnvoyFilter, so the EnvoyFilters of several APIRules would conflict. The APIRule that
+// was created first keeps the rate limit. The workloads are compared by the selectors of the services, since different
+// services can select the same workloads.
+func validateRateLimitConflicts(ctx context.Context, client client.Client, attributePath string, api *gatewayv1beta1.APIRule) []Failure {
+	var problems []Failure
+	limited := make(map[string]bool)
+	for i := range api.Spec.Rules {
+		workloads, err := rateLimitedWorkloads(ctx, client, api, &api.Spec.Rules[i])
+		if err != nil {
+			problems = append(problems, Failure{
+				AttributePath: fmt.Sprintf("%s[%d].rateLimit", attributePath, i),
+				Message:       fmt.Sprintf("Couldn't get the workloads of the rate limited services: %s", err),
+			})
+		}
+		for _, workload := range workloads {
+			limited[workload.selectorKey] = true
+		}
+	}
+	if len(limited) == 0 {
+		return problems
+	}
+
+	var apiRules gatewayv1beta1.APIRuleList
+	if err := client.List(ctx, &apiRules); err != nil {
+		return append(problems, Failure{AttributePath: attributePath, Message: fmt.Sprintf("Couldn't list APIRules to check rate limit conflicts: %s", err)})
+	}
+
+	// The workloads rate limited by APIRules created before this one, mapped to one of these APIRules
+	limitedBy := make(map[string]string)
+	for i := range apiRules.Items {
+		other := &apiRules.Items[i]
+		if (other.Namespace == api.Namespace && other.Name == api.Name) || !createdBefore(other, api) {
+			continue
+		}
+		for j := range other.Spec.Rules {
+			workloads, err := rateLimitedWorkloads(ctx, client, other, &other.Spec.Rules[j])
+			if err != nil {
+				return append(problems, Failure{
+					AttributePath: attributePath,
+					Message:       fmt.Sprintf("Couldn't get the workloads rate limited by APIRule %s/%s: %s", other.Namespace, other.Name, err),
+				})
+			}
+			for _, workload := range workloads {
+				if _, ok := limitedBy[workload.selectorKey]; limited[workload.selectorKey] && !ok {
+					limitedBy[workload.selectorKey] = other.Namespace + "/" + other.Name
+				}
+			}
+		}
+	}
+
+	for i := range api.Spec.Rules {
+		workloads, _ := rateLimitedWorkloads(ctx, client, api, &api.Spec.Rules[i])
+		for _, workload := range workloads {
+			if other, ok := limitedBy[workload.selectorKey]; ok {
+				problems = append(problems, Failure{
+					AttributePath: fmt.Sprintf("%s[%d].rateLimit", attributePath, i),
+					Message:       fmt.Sprintf("Service %s is already rate limited by APIRule %s", workload.service, other),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+type rateLimitedWorkload struct {
+	// service is the service selecting the workload as <name>.<namespace>
+	service     string
+	selectorKey string
+}
+
+// rateLimitedWorkloads returns the workloads rate limited by the rule. Services that don't exist or have no selector are
+// skipped, since they don't select any workload.
+func rateLimitedWorkloads(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule *gatewayv1beta1.Rule) ([]rateLimitedWorkload, error) {
+	if rule.RateLimit == nil {
+		return nil, nil
+	}
+	var workloads []rateLimitedWorkload
+	for _, service := range helpers.GetRuleServices(api, rule) {
+		if service.Name == nil || service.Namespace == nil {
+			continue
+		}
+		selector, err := helpers.GetLabelSelectorFromService(ctx, client, &service.Service, api, rule)
+		if apierrors.IsNotFound(err) || (err == nil && selector == nil) {
+			continue
+		}
+		if err != nil {
+			return workloads, err
+		}
+		workloads = append(workloads, rateLimitedWorkload{
+			service:     fmt.Sprintf("%s.%s", *service.Name, *service.Namespace),
+			selectorKey: helpers.GetWorkloadSelectorKey(*service.Namespace, selector.MatchLabels),
+		})
+	}
+	return workloads, nil
+}
+
+// createdBefore returns whether the APIRule a was created before b. APIRules created at the same time are ordered by
+// namespace and name, so that exactly one of two conflicting APIRules is rejected. An APIRule that is not created yet
+// is created after all others.
+func createdBefore(a *gatewayv1beta1.APIRule, b *gatewayv1beta1.APIRule) bool {
+	if b.CreationTimestamp.IsZero() {
+		return true
+	}
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+}
+
+// Validates that the IP allow and deny lists contain IP addresses or CIDR ranges without host bits
+func (v *APIRuleValidator) validateIpBlocks(attributePath string, ipBlocks []string) []Failure {
+	var problems []Failure
+
+	for i, ipBlock := range ipBlocks {
+		if net.ParseIP(ipBlock) != nil {
+			continue
+		}
+		ip, ipNet, err := net.ParseCIDR(ipBlock)
+		switch {
+		case err != nil:
+			problems = append(problems, Failure{AttributePath: fmt.Sprintf("%s[%d]", attributePath, i), Message: fmt.Sprintf("Invalid IP address or CIDR range: %s", ipBlock)})
+		case !ip.Equal(ipNet.IP):
+			problems = append(problems, Failure{AttributePath: fmt.Sprintf("%s[%d]", attributePath, i), Message: fmt.Sprintf("CIDR range %s has host bits set, use %s instead", ipBlock, ipNet)})
+		}
+	}
+
+	return problems
+}
+
+// Validates the principals and namespaces of the workloads allowed to access the rule
+func (v *APIRuleValidator) validateRuleSource(attributePath string, source *gatewayv1beta1.RuleSource) []Failure {
+	if source == nil {
+		return nil
+	}
+	if len(source.Principals) == 0 && len(source.Namespaces) == 0 {
+		return []Failure{{AttributePath: attributePath, Message: "At least one principal or namespace must be defined"}}
+	}
+
+	var problems []Failure
+	for i, principal := range source.Principals {
+		// AuthorizationPolicies support only one wildcard, either as prefix or as suffix
+		if !principalRegex.MatchString(principal) || (strings.HasPrefix(principal, "*") && strings.HasSuffix(principal, "*")) {
+			problems = append(problems, Failure{
+				AttributePath: fmt.Sprintf("%s.principals[%d]", attributePath, i),
+				Message:       fmt.Sprintf("Invalid principal %q, must have the format <trust domain>/ns/<namespace>/sa/<service account>", principal),
+			})
+		}
+	}
+	for i, namespace := range source.Namespaces {
+		if errs := k8svalidation.IsDNS1123Label(namespace); len(errs) > 0 {
+			problems = append(problems, Failure{AttributePath: fmt.Sprintf("%s.namespaces[%d]", attributePath, i), Message: fmt.Sprintf("Invalid namespace %q", namespace)})
+		}
+	}
+
+	return problems
+}
+
+// Validates that the rewrite defined on rule level doesn't replace the URI twice. Rewriting the URI is rejected for rules
+// with a rate limit, since the rate limit is enforced by the sidecar matching the original path of the rule.
+func (v *APIRuleValidator) validateRewrite(attributePath string, rule gatewayv1beta1.Rule) []Failure {
+	var problems []Failure
+
+	rewrite := rule.Rewrite
+	if rewrite == nil {
+		return problems
+	}
+
+	if rewrite.StripPrefix != "" && rewrite.URI != "" {
+		problems = append(problems, Failure{AttributePath: attributePath, Message: "Only one of stripPrefix or uri can be defined"})
+	}
+	if rule.RateLimit != nil && (rewrite.StripPrefix != "" || rewrite.URI != "") {
+		problems = append(problems, Failure{AttributePath: attributePath, Message: "Rewriting the URI is not supported for rules with a rate limit"})
+	}
+
+	return problems
+}
+
+// Validates that a rule returning a redirect or a direct response defines nothing that applies to the forwarded requests only
+func (v *APIRuleValidator) validateResponse(attributePath string, rule gatewayv1beta1.Rule) []Failure {
+	var problems []Failure
+
+	if rule.Redirect == nil && rule.DirectResponse == nil {
+		return problems
+	}
+
+	if rule.Redirect != nil && rule.DirectResponse != nil {
+		problems = append(problems, Failure{AttributePath: attributePath, Message: "Only one of redirect or directResponse can be defined"})
+	}
+	if rule.Redirect != nil && rule.Redirect.URI == "" && rule.Redirect.Authority == "" && rule.Redirect.Scheme == "" {
+		problems = append(problems, Failure{AttributePath: attributePath + ".redirect", Message: "At least one of uri, authority or scheme must be defined"})
+	}
+	if rule.Service != nil || len(rule.Services) > 0 {
+		problems = append(problems, Failure{AttributePath: attributePath + ".service", Message: "Rules with a redirect or direct response can't define a service"})
+	}
+	for i, accessStrategy := range rule.AccessStrategies {
+		if accessStrategy != nil && accessStrategy.Handler != nil && accessStrategy.Name != "allow" {
+			problems = append(problems, Failure{
+				AttributePath: fmt.Sprintf("%s.accessStrategies[%d].handler", attributePath, i),
+				Message:       "Rules with a redirect or direct response only support the allow access strategy",
+			})
+		}
+	}
+	if len(rule.Mutators) > 0 {
+		problems = append(problems, Failure{AttributePath: attributePath + ".mutators", Message: "Rules with a redirect or direct response can't define mutators"})
+	}
+	if rule.Rewrite != nil {
+		problems = append(problems, Failure{AttributePath: attributePath + ".rewrite", Message: "Rules with a redirect or direct response can't define a rewrite"})
+	}
+	if rule.RateLimit != nil {
+		problems = append(problems, Failure{AttributePath: attributePath + ".rateLimit", Message: "Rules with a redirect or direct response can't define a rate limit"})
+	}
+	if len(rule.IPAllowList) > 0 || len(rule.IPDenyList) > 0 {
+		problems = append(problems, Failure{AttributePath: attributePath, Message: "Rules with a redirect or direct response can't define IP allow or deny lists"})
+	}
+	if rule.From != nil {
+		problems = append(problems, Failure{AttributePath: attributePath + ".from", Message: "Rules with a redirect or direct response can't define workload sources"})
+	}
+
+	return problems
+}
+
+// Validates the templated segments of Exact and Prefix paths and the regular expression of Regex paths
+func (v *APIRuleValidator) validatePath(attributePath string, rule gatewayv1beta1.Rule) []Failure {
+	switch rule.PathType {
+	case gatewayv1beta1.PathTypeExact, gatewayv1beta1.PathTypePrefix:
+		for _, segment := range strings.Split(rule.Path, "/") {
+			if !helpers.IsPathTemplate(segment) && strings.ContainsAny(segment, "{}") {
+				return []Failure{{AttributePath: attributePath, Message: "Templated path segments must span a whole segment and have the format {name}"}}
+			}
+		}
+	default:
+		if helpers.HasPathTemplate(rule.Path) {
+			return []Failure{{AttributePath: attributePath, Message: "Templated path segments are only supported for the Exact and Prefix path types"}}
+		}
+		if _, err := regexp.Compile(rule.Path); err != nil {
+			return []Failure{{AttributePath: attributePath, Message: fmt.Sprintf("Invalid regular expression: %s", err)}}
+		}
+	}
+
+	return nil
+}
+
+// Validates the header and query parameter match conditions of the rule
+func (v *APIRuleValidator) validateMatchConditions(attributePath string, rule gatewayv1beta1.Rule) []Failure {
+	var problems []Failure
+
+	for _, name := range sortedKeys(rule.Headers) {
+		problems = append(problems, validateStringMatch(fmt.Sprintf("%s.headers[%s]", attributePath, name), rule.Headers[name])...)
+	}
+	for _, name := range sortedKeys(rule.QueryParams) {
+		problems = append(problems, validateStringMatch(fmt.Sprintf("%s.queryParams[%s]", attributePath, name), rule.QueryParams[name])...)
+	}
+
+	// The local rate limit matches the requests by headers, and the query string is part of the :path header only
+	if len(rule.QueryParams) > 0 && rule.RateLimit != nil {
+		problems = append(problems, Failure{AttributePath: attributePath + ".queryParams", Message: "Query parameter matches are not supported for rules with a rate limit"})
+	}
+
+	return problems
+}
+
+func sortedKeys(matches map[string]*gatewayv1beta1.StringMatch) []string {
+	var keys []string
+	for key := range matches {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func validateStringMatch(attributePath string, match *gatewayv1beta1.StringMatch) []Failure {
+	if match == nil {
+		return []Failure{{AttributePath: attributePath, Message: "String match is empty"}}
+	}
+
+	defined := 0
+	for _, value := range []string{match.Exact, match.Prefix, match.Regex} {
+		if value != "" {
+			defined++
+		}
+	}
+	if defined != 1 {
+		return []Failure{{AttributePath: attributePath, Message: "Exactly one of exact, prefix or regex must be defined"}}
+	}
+
+	if match.Regex != "" {
+		if _, err := regexp.Compile(match.Regex); err != nil {
+			return []Failure{{AttributePath: attributePath + ".regex", Message: fmt.Sprintf("Invalid regular expression: %s", err)}}
+		}
+	}
+
+	return nil
+}
+
 func (v *APIRuleValidator) validateMethods(attributePath string, methods []string) []Failure {
 	return nil
 }
@@ -289,16 +1041,23 @@ func (v *APIRuleValidator) validateAccessStrategy(attributePath string, accessSt
 		vld = vldDummy
 	case "oauth2_introspection":
 		vld = vldDummy
+		if v.IntrospectionValidator != nil {
+			vld = v.IntrospectionValidator
+			// Tokens introspected without Oathkeeper are enforced by an AuthorizationPolicy
+			if hasIntrospectionUrl(accessStrategy.Handler) {
+				problems = append(problems, v.validateInjection(attributePath, selector, namespace)...)
+			}
+		}
 	case "jwt":
 		vld = v.HandlerValidator
-		if v.InjectionValidator != nil {
-			injectionProblems, err := v.InjectionValidator.Validate(attributePath+".injection", selector, namespace)
-			if err != nil {
-				problems = append(problems, Failure{AttributePath: attributePath + ".handler", Message: fmt.Sprintf("Could not find pod for selected service, err: %s", err)})
-			} else {
-				problems = append(problems, injectionProblems...)
-			}
+		problems = append(problems, v.validateInjection(attributePath, selector, namespace)...)
+	case "extAuth":
+		// The external authorizer is enforced by an AuthorizationPolicy, so it is only supported if a validator is configured
+		if v.ExtAuthValidator == nil {
+			return []Failure{{AttributePath: attributePath + ".handler", Message: fmt.Sprintf("Unsupported accessStrategy: %s", accessStrategy.Handler.Name)}}
 		}
+		vld = v.ExtAuthValidator
+		problems = append(problems, v.validateInjection(attributePath, selector, namespace)...)
 	default:
 		return []Failure{{AttributePath: attributePath + ".handler", Message: fmt.Sprintf("Unsupported accessStrategy: %s", accessStrategy.Handler.Name)}}
 	}
@@ -306,6 +1065,20 @@ func (v *APIRuleValidator) validateAccessStrategy(attributePath string, accessSt
 	return append(problems, vld.Validate(attributePath, accessStrategy.Handler)...)
 }
 
+// Validates that the workload of the service has an Istio sidecar, which is required for access strategies enforced by Istio
+func (v *APIRuleValidator) validateInjection(attributePath string, selector *apiv1beta1.WorkloadSelector, namespace string) []Failure {
+	if v.InjectionValidator == nil {
+		return nil
+	}
+
+	injectionProblems, err := v.InjectionValidator.Validate(attributePath+".injection", selector, namespace)
+	if err != nil {
+		return []Failure{{AttributePath: attributePath + ".handler", Message: fmt.Sprintf("Could not find pod for selected service, err: %s", err)}}
+	}
+
+	return injectionProblems
+}
+
 func occupiesHost(vs *networkingv1beta1.VirtualService, host string) bool {
 	for _, h := range vs.Spec.Hosts {
 		if h == host {
@@ -3,8 +3,10 @@ package validation
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -67,6 +69,35 @@ var _ = Describe("ValidateConfig function", func() {
 		Expect(problems).To(HaveLen(1))
 		Expect(problems[0].Message).To(Equal("Unsupported JWT Handler: foo"))
 	})
+
+	It("Should fail for invalid scope claim keys", func() {
+		//given
+		input := &helpers.Config{JWTHandler: helpers.JWT_HANDLER_ISTIO, ScopeClaimKeys: []string{"permissions", "realm_access..roles"}}
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateConfig(input)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].Message).To(Equal("Invalid scope claim key: realm_access..roles"))
+	})
+
+	It("Should fail for invalid gateway principals", func() {
+		//given
+		input := &helpers.Config{JWTHandler: helpers.JWT_HANDLER_ISTIO, GatewayPrincipals: map[string]string{
+			"partners/partner-gateway": "cluster.local/ns/partners/sa/partner-gateway",
+			"partner-gateway":          "cluster.local/ns/partners/sa/partner-gateway",
+			"partners/other-gateway":   "cluster.local/ns/partners/sa/*",
+		}}
+
+		//when
+		problems := (&APIRuleValidator{}).ValidateConfig(input)
+
+		//then
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].Message).To(Equal("Invalid gateway partner-gateway, must have the format namespace/name"))
+		Expect(problems[1].Message).To(Equal("Invalid principal of gateway partners/other-gateway: cluster.local/ns/partners/sa/*"))
+	})
 })
 
 var _ = Describe("Validate function", func() {
@@ -571,11 +602,18 @@ var _ = Describe("Validate function", func() {
 		Expect(problems).To(HaveLen(0))
 	})
 
-	It("Should return an error when no service is defined for rule with no service on spec level", func() {
+	It("Should validate every host defined in hosts", func() {
 		//given
+		occupiedHost := gatewayv1beta1.Host("occupied-host." + allowlistedDomain)
+		notAllowlistedHost := gatewayv1beta1.Host(sampleServiceName + "." + notAllowlistedDomain)
+		existingVS := networkingv1beta1.VirtualService{}
+		existingVS.Spec.Hosts = []string{string(occupiedHost)}
+
 		input := &gatewayv1beta1.APIRule{
 			Spec: gatewayv1beta1.APIRuleSpec{
-				Host: getHost(sampleValidHost),
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Hosts:   []*gatewayv1beta1.Host{&occupiedHost, &notAllowlistedHost},
 				Rules: []gatewayv1beta1.Rule{
 					{
 						Path: "/abc",
@@ -587,151 +625,121 @@ var _ = Describe("Validate function", func() {
 			},
 		}
 
-		service := getService(sampleServiceName, "default")
+		service := getService(sampleServiceName)
 		fakeClient := buildFakeClient(service)
 
 		//when
 		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
 			AccessStrategiesValidator: asValidatorMock,
 			DomainAllowList:           testDomainAllowlist,
-		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{&existingVS}})
 
 		//then
-		Expect(problems).To(HaveLen(1))
-		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].service"))
-		Expect(problems[0].Message).To(Equal("No service defined with no main service on spec level"))
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].AttributePath).To(Equal(".spec.hosts[0]"))
+		Expect(problems[0].Message).To(Equal("This host is occupied by another Virtual Service"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.hosts[1]"))
+		Expect(problems[1].Message).To(Equal("Host is not allowlisted"))
 	})
 
-	It("Should return an error when rule is defined with blocklisted service", func() {
+	It("Should fail for a host defined more than once", func() {
 		//given
-		sampleBlocklistedService := "kubernetes"
-		validHost := sampleBlocklistedService + "." + allowlistedDomain
-		testBlockList := map[string][]string{
-			"default": {sampleBlocklistedService, "kube-dns"},
-			"example": {"service"}}
+		duplicatedHost := gatewayv1beta1.Host(sampleServiceName)
 
 		input := &gatewayv1beta1.APIRule{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: "default",
-			},
 			Spec: gatewayv1beta1.APIRuleSpec{
-				Host: getHost(validHost),
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Hosts:   []*gatewayv1beta1.Host{&duplicatedHost},
 				Rules: []gatewayv1beta1.Rule{
 					{
 						Path: "/abc",
 						AccessStrategies: []*gatewayv1beta1.Authenticator{
 							toAuthenticator("noop", emptyConfig()),
 						},
-						Service: getApiRuleService(sampleServiceName, uint32(8080)),
-					},
-					{
-						Path: "/abcd",
-						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("noop", emptyConfig()),
-						},
-						Service: getApiRuleService(sampleBlocklistedService, uint32(8080)),
 					},
 				},
 			},
 		}
 
-		service1 := getService(sampleServiceName)
-		service2 := getService(sampleBlocklistedService)
-		fakeClient := buildFakeClient(service1, service2)
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
 
 		//when
 		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
 			AccessStrategiesValidator: asValidatorMock,
-			ServiceBlockList:          testBlockList,
 			DomainAllowList:           testDomainAllowlist,
+			DefaultDomainName:         testDefaultDomain,
 		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
 
 		//then
 		Expect(problems).To(HaveLen(1))
-		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].service.name"))
-		Expect(problems[0].Message).To(Equal(fmt.Sprintf("Service %s in namespace default is blocklisted", sampleBlocklistedService)))
+		Expect(problems[0].AttributePath).To(Equal(".spec.hosts[0]"))
+		Expect(problems[0].Message).To(Equal("Host is defined more than once"))
 	})
 
-	It("Should return an error when rule is defined with blocklisted service in specific namespace", func() {
+	It("Should fail for invalid or duplicated gateways and rules restricted to unknown gateways", func() {
 		//given
-		sampleBlocklistedService := "service"
-		sampleBlocklistedNamespace := "service-namespace"
-		validHost := sampleBlocklistedService + "." + allowlistedDomain
-		testBlockList := map[string][]string{
-			"default":                  {"kube-dns"},
-			sampleBlocklistedNamespace: {sampleBlocklistedService}}
+		gateway := "kyma-system/kyma-gateway"
 
 		input := &gatewayv1beta1.APIRule{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: "default",
-			},
 			Spec: gatewayv1beta1.APIRuleSpec{
-				Host: getHost(validHost),
+				Service:  getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:     getHost(sampleValidHost),
+				Gateway:  &gateway,
+				Gateways: []string{"partners/partner-gateway", gateway, "Partners/Gateway"},
 				Rules: []gatewayv1beta1.Rule{
 					{
-						Path: "/abc",
-						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("noop", emptyConfig()),
-						},
-						Service: getApiRuleService(sampleServiceName, uint32(8080)),
-					},
-					{
-						Path: "/abcd",
+						Path:     "/abc",
+						Gateways: []string{"partners/partner-gateway", "partners/other-gateway"},
 						AccessStrategies: []*gatewayv1beta1.Authenticator{
 							toAuthenticator("noop", emptyConfig()),
 						},
-						Service: getApiRuleService(sampleBlocklistedService, uint32(8080), &sampleBlocklistedNamespace),
 					},
 				},
 			},
 		}
 
-		service1 := getService(sampleServiceName)
-		service2 := getService(sampleBlocklistedService, sampleBlocklistedNamespace)
-		fakeClient := buildFakeClient(service1, service2)
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
 
 		//when
 		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
 			AccessStrategiesValidator: asValidatorMock,
-			ServiceBlockList:          testBlockList,
 			DomainAllowList:           testDomainAllowlist,
 		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
 
 		//then
-		Expect(problems).To(HaveLen(1))
-		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].service.name"))
-		Expect(problems[0].Message).To(Equal(fmt.Sprintf("Service %s in namespace %s is blocklisted", sampleBlocklistedService, sampleBlocklistedNamespace)))
+		Expect(problems).To(HaveLen(3))
+		Expect(problems[0].AttributePath).To(Equal(".spec.gateways[1]"))
+		Expect(problems[0].Message).To(Equal("Gateway is defined more than once"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.gateways[2]"))
+		Expect(problems[1].Message).To(Equal(`Invalid gateway "Partners/Gateway"`))
+		Expect(problems[2].AttributePath).To(Equal(".spec.rules[0].gateways[1]"))
+		Expect(problems[2].Message).To(Equal("Gateway partners/other-gateway is not a gateway of the APIRule"))
 	})
 
-	It("Should detect several problems", func() {
+	It("Should not apply the domain checks to the hosts of an APIRule exposed only to the mesh", func() {
 		//given
+		meshGateway := gatewayv1beta1.MeshGateway
+		aliasHost := gatewayv1beta1.Host("orders")
+
 		input := &gatewayv1beta1.APIRule{
 			Spec: gatewayv1beta1.APIRuleSpec{
 				Service: getApiRuleService(sampleServiceName, uint32(8080)),
-				Host:    getHost(sampleValidHost),
+				Host:    getHost(sampleServiceName + "." + notAllowlistedDomain),
+				Hosts:   []*gatewayv1beta1.Host{&aliasHost},
+				Gateway: &meshGateway,
 				Rules: []gatewayv1beta1.Rule{
 					{
 						Path: "/abc",
 						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("noop", simpleJWTConfig()),
-						},
-					},
-					{
-						Path: "/abc",
-						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("anonymous", simpleJWTConfig()),
-						},
-					},
-					{
-						Path: "/def",
-						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("non-existing", nil),
+							toAuthenticator("noop", emptyConfig()),
 						},
 					},
-					{
-						Path:             "/ghi",
-						AccessStrategies: []*gatewayv1beta1.Authenticator{},
-					},
 				},
 			},
 		}
@@ -741,49 +749,33 @@ var _ = Describe("Validate function", func() {
 
 		//when
 		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
 			AccessStrategiesValidator: asValidatorMock,
 			DomainAllowList:           testDomainAllowlist,
+			HostBlockList:             []string{"orders"},
 		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
 
 		//then
-		Expect(problems).To(HaveLen(5))
-		Expect(problems[0].AttributePath).To(Equal(".spec.rules"))
-		Expect(problems[0].Message).To(Equal("multiple rules defined for the same path and method"))
-
-		Expect(problems[1].AttributePath).To(Equal(".spec.rules[0].accessStrategies[0].config"))
-		Expect(problems[1].Message).To(Equal("strategy: noop does not support configuration"))
-
-		Expect(problems[2].AttributePath).To(Equal(".spec.rules[1].accessStrategies[0].config"))
-		Expect(problems[2].Message).To(Equal("strategy: anonymous does not support configuration"))
-
-		Expect(problems[3].AttributePath).To(Equal(".spec.rules[2].accessStrategies[0].handler"))
-		Expect(problems[3].Message).To(Equal("Unsupported accessStrategy: non-existing"))
-
-		Expect(problems[4].AttributePath).To(Equal(".spec.rules[3].accessStrategies"))
-		Expect(problems[4].Message).To(Equal("No accessStrategies defined"))
-
+		Expect(problems).To(HaveLen(0))
 	})
 
-	It("Should fail for the same path and method", func() {
+	It("Should fail for a mesh host occupied by another Virtual Service", func() {
 		//given
+		meshHost := gatewayv1beta1.Host("orders.internal")
+		existingVS := networkingv1beta1.VirtualService{}
+		existingVS.Spec.Hosts = []string{string(meshHost)}
+
 		input := &gatewayv1beta1.APIRule{
 			Spec: gatewayv1beta1.APIRuleSpec{
-				Service: getApiRuleService(sampleServiceName, uint32(8080)),
-				Host:    getHost(sampleValidHost),
+				Service:   getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:      getHost(sampleValidHost),
+				MeshHosts: []*gatewayv1beta1.Host{&meshHost},
 				Rules: []gatewayv1beta1.Rule{
 					{
 						Path: "/abc",
 						AccessStrategies: []*gatewayv1beta1.Authenticator{
 							toAuthenticator("noop", emptyConfig()),
 						},
-						Methods: []string{"GET"},
-					},
-					{
-						Path: "/abc",
-						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("anonymous", emptyConfig()),
-						},
-						Methods: []string{"GET", "POST"},
 					},
 				},
 			},
@@ -794,58 +786,34 @@ var _ = Describe("Validate function", func() {
 
 		//when
 		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
 			AccessStrategiesValidator: asValidatorMock,
 			DomainAllowList:           testDomainAllowlist,
-		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{&existingVS}})
 
 		//then
 		Expect(problems).To(HaveLen(1))
-		Expect(problems[0].AttributePath).To(Equal(".spec.rules"))
-		Expect(problems[0].Message).To(Equal("multiple rules defined for the same path and method"))
+		Expect(problems[0].AttributePath).To(Equal(".spec.meshHosts[0]"))
+		Expect(problems[0].Message).To(Equal("This host is occupied by another Virtual Service"))
 	})
 
-	It("Should succeed for valid input", func() {
+	It("Should fail for mesh hosts used together with the mesh gateway", func() {
 		//given
-		occupiedHost := "occupied-host" + allowlistedDomain
-		notOccupiedHost := "not-occupied-host" + allowlistedDomain
-		existingVS := networkingv1beta1.VirtualService{}
-		existingVS.Spec.Hosts = []string{occupiedHost}
+		meshGateway := gatewayv1beta1.MeshGateway
+		meshHost := gatewayv1beta1.Host("orders.internal")
 
 		input := &gatewayv1beta1.APIRule{
-			ObjectMeta: metav1.ObjectMeta{
-				UID: "67890",
-			},
 			Spec: gatewayv1beta1.APIRuleSpec{
-				Service: getApiRuleService(sampleServiceName, uint32(8080)),
-				Host:    getHost(notOccupiedHost),
+				Service:   getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:      getHost("orders"),
+				MeshHosts: []*gatewayv1beta1.Host{&meshHost},
+				Gateway:   &meshGateway,
 				Rules: []gatewayv1beta1.Rule{
 					{
 						Path: "/abc",
 						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("jwt", simpleJWTConfig()),
-							toAuthenticator("noop", emptyConfig()),
-						},
-						Methods: []string{"POST"},
-					},
-					{
-						Path: "/abc",
-						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("jwt", simpleJWTConfig()),
 							toAuthenticator("noop", emptyConfig()),
 						},
-						Methods: []string{"GET"},
-					},
-					{
-						Path: "/bcd",
-						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("anonymous", emptyConfig()),
-						},
-					},
-					{
-						Path: "/def",
-						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("allow", nil),
-						},
 					},
 				},
 			},
@@ -858,43 +826,29 @@ var _ = Describe("Validate function", func() {
 		problems := (&APIRuleValidator{
 			HandlerValidator:          handlerValidatorMock,
 			AccessStrategiesValidator: asValidatorMock,
-			DomainAllowList:           testDomainAllowlist,
-		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{&existingVS}})
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
 
 		//then
-		Expect(problems).To(HaveLen(0))
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.meshHosts"))
+		Expect(problems[0].Message).To(Equal("Mesh hosts can't be used together with the mesh gateway, use the hosts instead"))
 	})
 
-	It("Should succeed for the same path but different methods", func() {
+	It("Should succeed when only hosts is defined", func() {
 		//given
-		occupiedHost := "occupied-host" + allowlistedDomain
-		notOccupiedHost := "not-occupied-host" + allowlistedDomain
-		existingVS := networkingv1beta1.VirtualService{}
-		existingVS.Spec.Hosts = []string{occupiedHost}
+		host := gatewayv1beta1.Host(sampleValidHost)
 
 		input := &gatewayv1beta1.APIRule{
-			ObjectMeta: metav1.ObjectMeta{
-				UID: "67890",
-			},
 			Spec: gatewayv1beta1.APIRuleSpec{
 				Service: getApiRuleService(sampleServiceName, uint32(8080)),
-				Host:    getHost(notOccupiedHost),
+				Hosts:   []*gatewayv1beta1.Host{&host},
 				Rules: []gatewayv1beta1.Rule{
 					{
-						Path: "/abc",
-						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("jwt", simpleJWTConfig()),
-							toAuthenticator("noop", emptyConfig()),
-						},
-						Methods: []string{"POST"},
-					},
-					{
-						Path: "/abc",
+						Path:    "/abc",
+						Methods: []string{"GET"},
 						AccessStrategies: []*gatewayv1beta1.Authenticator{
-							toAuthenticator("jwt", simpleJWTConfig()),
 							toAuthenticator("noop", emptyConfig()),
 						},
-						Methods: []string{"GET"},
 					},
 				},
 			},
@@ -908,92 +862,1427 @@ var _ = Describe("Validate function", func() {
 			HandlerValidator:          handlerValidatorMock,
 			AccessStrategiesValidator: asValidatorMock,
 			DomainAllowList:           testDomainAllowlist,
-		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{&existingVS}})
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
 
 		//then
 		Expect(problems).To(HaveLen(0))
 	})
 
-	It("Should not fail with service without labels selector by default", func() {
+	It("Should fail when neither host nor hosts is defined", func() {
 		//given
 		input := &gatewayv1beta1.APIRule{
 			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
 				Rules: []gatewayv1beta1.Rule{
 					{
-						Path: "/abc",
+						Path:    "/abc",
+						Methods: []string{"GET"},
 						AccessStrategies: []*gatewayv1beta1.Authenticator{
 							toAuthenticator("noop", emptyConfig()),
 						},
-						Methods: []string{"POST"},
 					},
 				},
-				Service: getApiRuleService(sampleServiceName, uint32(8080)),
-				Host:    getHost(sampleValidHost),
 			},
 		}
 
 		service := getService(sampleServiceName)
-		service.Spec.Selector = map[string]string{}
 		fakeClient := buildFakeClient(service)
 
 		//when
 		problems := (&APIRuleValidator{
 			HandlerValidator:          handlerValidatorMock,
 			AccessStrategiesValidator: asValidatorMock,
-			DomainAllowList:           testDomainAllowlist,
 		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
 
 		//then
-		Expect(problems).To(HaveLen(0))
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.host"))
+		Expect(problems[0].Message).To(Equal("Host was nil"))
 	})
 
-	It("Should not fail with service on path level by default", func() {
+	It("Should return an error when no service is defined for rule with no service on spec level", func() {
 		//given
 		input := &gatewayv1beta1.APIRule{
 			Spec: gatewayv1beta1.APIRuleSpec{
+				Host: getHost(sampleValidHost),
 				Rules: []gatewayv1beta1.Rule{
 					{
-						Path:    "/abc",
-						Service: getApiRuleService(sampleServiceName, uint32(8080)),
+						Path: "/abc",
 						AccessStrategies: []*gatewayv1beta1.Authenticator{
 							toAuthenticator("noop", emptyConfig()),
 						},
-						Methods: []string{"POST"},
 					},
 				},
-				Host: getHost(sampleValidHost),
 			},
 		}
 
-		service := getService(sampleServiceName)
-		service.Spec.Selector = map[string]string{}
+		service := getService(sampleServiceName, "default")
 		fakeClient := buildFakeClient(service)
 
 		//when
 		problems := (&APIRuleValidator{
-			HandlerValidator:          handlerValidatorMock,
 			AccessStrategiesValidator: asValidatorMock,
 			DomainAllowList:           testDomainAllowlist,
 		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
 
 		//then
-		Expect(problems).To(HaveLen(0))
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].service"))
+		Expect(problems[0].Message).To(Equal("No service defined with no main service on spec level"))
 	})
 
-	It("Should succeed with service without namespace", func() {
+	It("Should return an error when rule is defined with blocklisted service", func() {
 		//given
+		sampleBlocklistedService := "kubernetes"
+		validHost := sampleBlocklistedService + "." + allowlistedDomain
+		testBlockList := map[string][]string{
+			"default": {sampleBlocklistedService, "kube-dns"},
+			"example": {"service"}}
+
 		input := &gatewayv1beta1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+			},
 			Spec: gatewayv1beta1.APIRuleSpec{
+				Host: getHost(validHost),
 				Rules: []gatewayv1beta1.Rule{
 					{
 						Path: "/abc",
 						AccessStrategies: []*gatewayv1beta1.Authenticator{
 							toAuthenticator("noop", emptyConfig()),
 						},
-						Methods: []string{"POST"},
+						Service: getApiRuleService(sampleServiceName, uint32(8080)),
 					},
-				},
-				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+					{
+						Path: "/abcd",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Service: getApiRuleService(sampleBlocklistedService, uint32(8080)),
+					},
+				},
+			},
+		}
+
+		service1 := getService(sampleServiceName)
+		service2 := getService(sampleBlocklistedService)
+		fakeClient := buildFakeClient(service1, service2)
+
+		//when
+		problems := (&APIRuleValidator{
+			AccessStrategiesValidator: asValidatorMock,
+			ServiceBlockList:          testBlockList,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].service.name"))
+		Expect(problems[0].Message).To(Equal(fmt.Sprintf("Service %s in namespace default is blocklisted", sampleBlocklistedService)))
+	})
+
+	It("Should return an error when rule is defined with blocklisted service in specific namespace", func() {
+		//given
+		sampleBlocklistedService := "service"
+		sampleBlocklistedNamespace := "service-namespace"
+		validHost := sampleBlocklistedService + "." + allowlistedDomain
+		testBlockList := map[string][]string{
+			"default":                  {"kube-dns"},
+			sampleBlocklistedNamespace: {sampleBlocklistedService}}
+
+		input := &gatewayv1beta1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Host: getHost(validHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Service: getApiRuleService(sampleServiceName, uint32(8080)),
+					},
+					{
+						Path: "/abcd",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Service: getApiRuleService(sampleBlocklistedService, uint32(8080), &sampleBlocklistedNamespace),
+					},
+				},
+			},
+		}
+
+		service1 := getService(sampleServiceName)
+		service2 := getService(sampleBlocklistedService, sampleBlocklistedNamespace)
+		fakeClient := buildFakeClient(service1, service2)
+
+		//when
+		problems := (&APIRuleValidator{
+			AccessStrategiesValidator: asValidatorMock,
+			ServiceBlockList:          testBlockList,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].service.name"))
+		Expect(problems[0].Message).To(Equal(fmt.Sprintf("Service %s in namespace %s is blocklisted", sampleBlocklistedService, sampleBlocklistedNamespace)))
+	})
+
+	It("Should detect several problems", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", simpleJWTConfig()),
+						},
+					},
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("anonymous", simpleJWTConfig()),
+						},
+					},
+					{
+						Path: "/def",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("non-existing", nil),
+						},
+					},
+					{
+						Path:             "/ghi",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(5))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules"))
+		Expect(problems[0].Message).To(Equal("multiple rules defined for the same path and method"))
+
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[0].accessStrategies[0].config"))
+		Expect(problems[1].Message).To(Equal("strategy: noop does not support configuration"))
+
+		Expect(problems[2].AttributePath).To(Equal(".spec.rules[1].accessStrategies[0].config"))
+		Expect(problems[2].Message).To(Equal("strategy: anonymous does not support configuration"))
+
+		Expect(problems[3].AttributePath).To(Equal(".spec.rules[2].accessStrategies[0].handler"))
+		Expect(problems[3].Message).To(Equal("Unsupported accessStrategy: non-existing"))
+
+		Expect(problems[4].AttributePath).To(Equal(".spec.rules[3].accessStrategies"))
+		Expect(problems[4].Message).To(Equal("No accessStrategies defined"))
+
+	})
+
+	It("Should fail for the same path and method", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+					},
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("anonymous", emptyConfig()),
+						},
+						Methods: []string{"GET", "POST"},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules"))
+		Expect(problems[0].Message).To(Equal("multiple rules defined for the same path and method"))
+	})
+
+	It("Should succeed for valid input", func() {
+		//given
+		occupiedHost := "occupied-host" + allowlistedDomain
+		notOccupiedHost := "not-occupied-host" + allowlistedDomain
+		existingVS := networkingv1beta1.VirtualService{}
+		existingVS.Spec.Hosts = []string{occupiedHost}
+
+		input := &gatewayv1beta1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{
+				UID: "67890",
+			},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(notOccupiedHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("jwt", simpleJWTConfig()),
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"POST"},
+					},
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("jwt", simpleJWTConfig()),
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+					},
+					{
+						Path: "/bcd",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("anonymous", emptyConfig()),
+						},
+					},
+					{
+						Path: "/def",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", nil),
+						},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{&existingVS}})
+
+		//then
+		Expect(problems).To(HaveLen(0))
+	})
+
+	It("Should succeed for the same path but different methods", func() {
+		//given
+		occupiedHost := "occupied-host" + allowlistedDomain
+		notOccupiedHost := "not-occupied-host" + allowlistedDomain
+		existingVS := networkingv1beta1.VirtualService{}
+		existingVS.Spec.Hosts = []string{occupiedHost}
+
+		input := &gatewayv1beta1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{
+				UID: "67890",
+			},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(notOccupiedHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("jwt", simpleJWTConfig()),
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"POST"},
+					},
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("jwt", simpleJWTConfig()),
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{Items: []*networkingv1beta1.VirtualService{&existingVS}})
+
+		//then
+		Expect(problems).To(HaveLen(0))
+	})
+
+	It("Should fail for weighted services that don't sum up to 100", func() {
+		//given
+		canaryService := "canary-service"
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Host: getHost(sampleValidHost),
+				Services: []*gatewayv1beta1.WeightedService{
+					{Service: *getApiRuleService(sampleServiceName, uint32(8080)), Weight: 80},
+					{Service: *getApiRuleService(canaryService, uint32(8080)), Weight: 10},
+				},
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+					},
+				},
+			},
+		}
+
+		fakeClient := buildFakeClient(getService(sampleServiceName), getService(canaryService))
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.services"))
+		Expect(problems[0].Message).To(Equal("The weights of the services must sum up to 100, but sum up to 90"))
+	})
+
+	It("Should fail for rule with service and weighted services defined", func() {
+		//given
+		canaryService := "canary-service"
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Host: getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						Service: getApiRuleService(sampleServiceName, uint32(8080)),
+						Services: []*gatewayv1beta1.WeightedService{
+							{Service: *getApiRuleService(sampleServiceName, uint32(8080)), Weight: 50},
+							{Service: *getApiRuleService(canaryService, uint32(8080)), Weight: 50},
+						},
+					},
+				},
+			},
+		}
+
+		fakeClient := buildFakeClient(getService(sampleServiceName), getService(canaryService))
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].services"))
+		Expect(problems[0].Message).To(Equal("service and services cannot be defined at the same time"))
+	})
+
+	It("Should fail for blocklisted weighted service on rule level", func() {
+		//given
+		canaryService := "canary-service"
+		input := &gatewayv1beta1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Host: getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", nil),
+						},
+						Methods: []string{"GET"},
+						Services: []*gatewayv1beta1.WeightedService{
+							{Service: *getApiRuleService(sampleServiceName, uint32(8080)), Weight: 50},
+							{Service: *getApiRuleService(canaryService, uint32(8080)), Weight: 50},
+						},
+					},
+				},
+			},
+		}
+
+		fakeClient := buildFakeClient(getService(sampleServiceName), getService(canaryService))
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+			ServiceBlockList:          map[string][]string{"default": {canaryService}},
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].services[1].name"))
+		Expect(problems[0].Message).To(Equal("Service canary-service in namespace default is blocklisted"))
+	})
+
+	It("Should fail for invalid CORS policy", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Cors: &gatewayv1beta1.CorsPolicy{
+					AllowOrigins: []*gatewayv1beta1.StringMatch{{Exact: "https://example.com", Prefix: "https://"}},
+				},
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						Cors: &gatewayv1beta1.CorsPolicy{
+							AllowOrigins: []*gatewayv1beta1.StringMatch{{Regex: "https://(.*"}},
+							AllowMethods: []string{"GET", "FETCH"},
+						},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(3))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].cors.allowOrigins[0].regex"))
+		Expect(problems[0].Message).To(HavePrefix("Invalid regular expression"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[0].cors.allowMethods[1]"))
+		Expect(problems[1].Message).To(Equal("Unsupported method: FETCH"))
+		Expect(problems[2].AttributePath).To(Equal(".spec.cors.allowOrigins[0]"))
+		Expect(problems[2].Message).To(Equal("Exactly one of exact, prefix or regex must be defined"))
+	})
+
+	It("Should fail for retries exceeding the timeout of the rule", func() {
+		//given
+		var timeout gatewayv1beta1.Timeout = 30
+		var perTryTimeout gatewayv1beta1.Timeout = 10
+		var longPerTryTimeout gatewayv1beta1.Timeout = 70
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Retries: &gatewayv1beta1.Retries{Attempts: 3, PerTryTimeout: &longPerTryTimeout},
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+					},
+					{
+						Path: "/bcd",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						Timeout: &timeout,
+						// The initial call and 2 retries take exactly the timeout of the rule
+						Retries: &gatewayv1beta1.Retries{Attempts: 2, PerTryTimeout: &perTryTimeout},
+					},
+					{
+						Path: "/cde",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						Timeout: &timeout,
+						Retries: &gatewayv1beta1.Retries{Attempts: 3, PerTryTimeout: &perTryTimeout},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].retries"))
+		Expect(problems[0].Message).To(Equal("The retry attempts take up to 4m40s, which exceeds the timeout of 3m0s"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[2].retries"))
+		Expect(problems[1].Message).To(Equal("The retry attempts take up to 40s, which exceeds the timeout of 30s"))
+	})
+
+	It("Should fail for invalid rate limit buckets", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						RateLimit: &gatewayv1beta1.RateLimit{
+							Requests: 10,
+							Unit:     gatewayv1beta1.RateLimitUnitMinute,
+							Buckets: []*gatewayv1beta1.RateLimitBucket{
+								{Header: &gatewayv1beta1.HeaderMatch{Name: "x-tier", Value: "premium"}, Requests: 100},
+								{Header: &gatewayv1beta1.HeaderMatch{Name: "x-tier", Value: "free"}, StaticClientIP: "10.0.0.1", Requests: 1},
+								{Requests: 1},
+								{StaticClientIP: "10.0.0.256", Requests: 1},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(3))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].rateLimit.buckets[1]"))
+		Expect(problems[0].Message).To(Equal("Exactly one of header or staticClientIP must be defined"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[0].rateLimit.buckets[2]"))
+		Expect(problems[1].Message).To(Equal("Exactly one of header or staticClientIP must be defined"))
+		Expect(problems[2].AttributePath).To(Equal(".spec.rules[0].rateLimit.buckets[3].staticClientIP"))
+		Expect(problems[2].Message).To(Equal("Invalid IP address: 10.0.0.256"))
+	})
+
+	It("Should fail for static client IPs in rate limit buckets if the service is exposed to the mesh", func() {
+		//given
+		gateway := "kyma-system/kyma-gateway"
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service:  getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:     getHost(sampleValidHost),
+				Gateway:  &gateway,
+				Gateways: []string{gatewayv1beta1.MeshGateway},
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						RateLimit: &gatewayv1beta1.RateLimit{
+							Requests: 10,
+							Unit:     gatewayv1beta1.RateLimitUnitMinute,
+							Buckets: []*gatewayv1beta1.RateLimitBucket{
+								{Header: &gatewayv1beta1.HeaderMatch{Name: "x-tier", Value: "premium"}, Requests: 100},
+								{StaticClientIP: "10.0.0.1", Requests: 1},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].rateLimit.buckets[1].staticClientIP"))
+		Expect(problems[0].Message).To(Equal("Static client IPs are not supported if the service is exposed to the mesh"))
+	})
+
+	It("Should fail for rate limits of a service already rate limited by another APIRule", func() {
+		//given
+		rateLimitedRule := func(path string) gatewayv1beta1.Rule {
+			return gatewayv1beta1.Rule{
+				Path: path,
+				AccessStrategies: []*gatewayv1beta1.Authenticator{
+					toAuthenticator("noop", emptyConfig()),
+				},
+				Methods:   []string{"GET"},
+				RateLimit: &gatewayv1beta1.RateLimit{Requests: 10, Unit: gatewayv1beta1.RateLimitUnitMinute},
+			}
+		}
+		getApiRule := func(name string, created time.Time, rules ...gatewayv1beta1.Rule) *gatewayv1beta1.APIRule {
+			return &gatewayv1beta1.APIRule{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(created)},
+				Spec: gatewayv1beta1.APIRuleSpec{
+					Service: getApiRuleService(sampleServiceName, uint32(8080)),
+					Host:    getHost(sampleValidHost),
+					Rules:   rules,
+				},
+			}
+		}
+
+		now := time.Now().Truncate(time.Second)
+		older := getApiRule("older", now.Add(-time.Hour), rateLimitedRule("/abc"))
+		input := getApiRule("newer", now, rateLimitedRule("/bcd"), rateLimitedRule("/cde"))
+		input.Spec.Rules[1].Service = getApiRuleService("other-service", uint32(8080))
+
+		validator := &APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}
+		fakeClient := buildFakeClient(getService(sampleServiceName, "default"), getService("other-service", "default"), older, input)
+
+		//when
+		problems := validator.Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].rateLimit"))
+		Expect(problems[0].Message).To(Equal(fmt.Sprintf("Service %s.default is already rate limited by APIRule default/older", sampleServiceName)))
+
+		//when
+		problems = validator.Validate(context.TODO(), fakeClient, older, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for rate limits of workloads already rate limited by another APIRule through a different service", func() {
+		//given
+		getRateLimitedApiRule := func(name string, created time.Time, serviceName string) *gatewayv1beta1.APIRule {
+			return &gatewayv1beta1.APIRule{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(created)},
+				Spec: gatewayv1beta1.APIRuleSpec{
+					Service: getApiRuleService(serviceName, uint32(8080)),
+					Host:    getHost(sampleValidHost),
+					Rules: []gatewayv1beta1.Rule{
+						{
+							Path: "/abc",
+							AccessStrategies: []*gatewayv1beta1.Authenticator{
+								toAuthenticator("noop", emptyConfig()),
+							},
+							Methods:   []string{"GET"},
+							RateLimit: &gatewayv1beta1.RateLimit{Requests: 10, Unit: gatewayv1beta1.RateLimitUnitMinute},
+						},
+					},
+				},
+			}
+		}
+
+		now := time.Now().Truncate(time.Second)
+		older := getRateLimitedApiRule("older", now.Add(-time.Hour), "orders-v1")
+		input := getRateLimitedApiRule("newer", now, "orders")
+
+		olderService := getService("orders-v1")
+		olderService.Spec.Selector = map[string]string{"app": "orders"}
+
+		validator := &APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}
+		fakeClient := buildFakeClient(getService("orders"), olderService, older, input)
+
+		//when
+		problems := validator.Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].rateLimit"))
+		Expect(problems[0].Message).To(Equal("Service orders.default is already rate limited by APIRule default/older"))
+	})
+
+	It("Should fail for rate limits if the APIRules can't be listed", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "rule", Namespace: "default"},
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods:   []string{"GET"},
+						RateLimit: &gatewayv1beta1.RateLimit{Requests: 10, Unit: gatewayv1beta1.RateLimitUnitMinute},
+					},
+				},
+			},
+		}
+
+		validator := &APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}
+		fakeClient := failingListClient{buildFakeClient(getService(sampleServiceName))}
+
+		//when
+		problems := validator.Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules"))
+		Expect(problems[0].Message).To(Equal("Couldn't list APIRules to check rate limit conflicts: list failed"))
+	})
+
+	It("Should succeed for rules with the same path and different methods routed to different services", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Host: getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path:    "/items",
+						Service: getApiRuleService("reader", uint32(8080)),
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+					},
+					{
+						Path:    "/items",
+						Service: getApiRuleService("writer", uint32(8080)),
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"POST"},
+					},
+				},
+			},
+		}
+
+		fakeClient := buildFakeClient(getService("reader"), getService("writer"))
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for rules with the same path and different CORS policies", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/items",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+					},
+					{
+						Path: "/items",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"POST"},
+						Cors: &gatewayv1beta1.CorsPolicy{
+							AllowOrigins: []*gatewayv1beta1.StringMatch{{Exact: "https://example.com"}},
+						},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].cors"))
+		Expect(problems[0].Message).To(Equal("Rules with the same path and match conditions must have the same CORS policy"))
+	})
+
+	It("Should fail for rewrite with strip prefix and uri or with rate limit", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/orders/v1/.*",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						Rewrite: &gatewayv1beta1.Rewrite{StripPrefix: "/orders/v1", URI: "/items"},
+					},
+					{
+						Path: "/limited/v1/.*",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods:   []string{"GET"},
+						RateLimit: &gatewayv1beta1.RateLimit{Requests: 10, Unit: gatewayv1beta1.RateLimitUnitSecond},
+						Rewrite:   &gatewayv1beta1.Rewrite{StripPrefix: "/limited/v1"},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].rewrite"))
+		Expect(problems[0].Message).To(Equal("Only one of stripPrefix or uri can be defined"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[1].rewrite"))
+		Expect(problems[1].Message).To(Equal("Rewriting the URI is not supported for rules with a rate limit"))
+	})
+
+	It("Should validate the extAuth access strategy only if an extAuth validator is configured", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/admin",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("extAuth", &runtime.RawExtension{Raw: []byte(`{"provider": "opa"}`)}),
+						},
+						Methods: []string{"GET"},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		withoutExtAuth := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+		withExtAuth := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			ExtAuthValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(withoutExtAuth).To(HaveLen(1))
+		Expect(withoutExtAuth[0].AttributePath).To(Equal(".spec.rules[0].accessStrategies[0].handler"))
+		Expect(withoutExtAuth[0].Message).To(Equal("Unsupported accessStrategy: extAuth"))
+		Expect(withExtAuth).To(BeEmpty())
+	})
+
+	It("Should fail for invalid templated paths and regular expressions", func() {
+		//given
+		pathRule := func(path string, pathType gatewayv1beta1.PathType) gatewayv1beta1.Rule {
+			return gatewayv1beta1.Rule{
+				Path:     path,
+				PathType: pathType,
+				AccessStrategies: []*gatewayv1beta1.Authenticator{
+					toAuthenticator("noop", emptyConfig()),
+				},
+				Methods: []string{"GET"},
+			}
+		}
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					pathRule("/users/{id}/orders", gatewayv1beta1.PathTypeExact),
+					pathRule("/items/{id}", gatewayv1beta1.PathTypePrefix),
+					pathRule("/users/user-{id}", gatewayv1beta1.PathTypeExact),
+					pathRule("/orders/{id}", gatewayv1beta1.PathTypeRegex),
+					pathRule("/orders/(", ""),
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(3))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[2].path"))
+		Expect(problems[0].Message).To(Equal("Templated path segments must span a whole segment and have the format {name}"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[3].path"))
+		Expect(problems[1].Message).To(Equal("Templated path segments are only supported for the Exact and Prefix path types"))
+		Expect(problems[2].AttributePath).To(Equal(".spec.rules[4].path"))
+		Expect(problems[2].Message).To(HavePrefix("Invalid regular expression"))
+	})
+
+	It("Should fail for invalid header matches and query parameter matches with rate limit", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/orders/.*",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						Headers: map[string]*gatewayv1beta1.StringMatch{
+							"X-Api-Version": {Exact: "2", Prefix: "2"},
+							"X-Tenant":      {Regex: "("},
+						},
+					},
+					{
+						Path: "/limited/.*",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods:     []string{"GET"},
+						RateLimit:   &gatewayv1beta1.RateLimit{Requests: 10, Unit: gatewayv1beta1.RateLimitUnitSecond},
+						QueryParams: map[string]*gatewayv1beta1.StringMatch{"beta": {Exact: "true"}},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(3))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].headers[X-Api-Version]"))
+		Expect(problems[0].Message).To(Equal("Exactly one of exact, prefix or regex must be defined"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[0].headers[X-Tenant].regex"))
+		Expect(problems[1].Message).To(HavePrefix("Invalid regular expression"))
+		Expect(problems[2].AttributePath).To(Equal(".spec.rules[1].queryParams"))
+		Expect(problems[2].Message).To(Equal("Query parameter matches are not supported for rules with a rate limit"))
+	})
+
+	It("Should succeed for redirect and direct response rules without service", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Host: getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/old/.*",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", emptyConfig()),
+						},
+						Methods:  []string{"GET"},
+						Redirect: &gatewayv1beta1.Redirect{URI: "/new", Code: 302},
+					},
+					{
+						Path: "/robots.txt",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", emptyConfig()),
+						},
+						Methods:        []string{"GET"},
+						DirectResponse: &gatewayv1beta1.DirectResponse{Status: 200, Body: "User-agent: *"},
+					},
+				},
+			},
+		}
+
+		fakeClient := buildFakeClient()
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail for redirect and direct response rules with settings for forwarded requests", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Host: getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path:    "/old/.*",
+						Service: getApiRuleService(sampleServiceName, uint32(8080)),
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods:        []string{"GET"},
+						Redirect:       &gatewayv1beta1.Redirect{Code: 302},
+						DirectResponse: &gatewayv1beta1.DirectResponse{Status: 410},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(4))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0]"))
+		Expect(problems[0].Message).To(Equal("Only one of redirect or directResponse can be defined"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[0].redirect"))
+		Expect(problems[1].Message).To(Equal("At least one of uri, authority or scheme must be defined"))
+		Expect(problems[2].AttributePath).To(Equal(".spec.rules[0].service"))
+		Expect(problems[2].Message).To(Equal("Rules with a redirect or direct response can't define a service"))
+		Expect(problems[3].AttributePath).To(Equal(".spec.rules[0].accessStrategies[0].handler"))
+		Expect(problems[3].Message).To(Equal("Rules with a redirect or direct response only support the allow access strategy"))
+	})
+
+	It("Should fail for invalid IP allow and deny lists", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/admin",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", emptyConfig()),
+						},
+						Methods:     []string{"GET"},
+						IPAllowList: []string{"10.0.0.0/8", "192.168.0.1", "2001:db8::/32", "10.0.0.300"},
+						IPDenyList:  []string{"10.1.0.1/16"},
+					},
+					{
+						Path: "/old",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", emptyConfig()),
+						},
+						Methods:     []string{"GET"},
+						Redirect:    &gatewayv1beta1.Redirect{URI: "/new"},
+						IPAllowList: []string{"10.0.0.0/8"},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(3))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].ipAllowList[3]"))
+		Expect(problems[0].Message).To(Equal("Invalid IP address or CIDR range: 10.0.0.300"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[0].ipDenyList[0]"))
+		Expect(problems[1].Message).To(Equal("CIDR range 10.1.0.1/16 has host bits set, use 10.1.0.0/16 instead"))
+		Expect(problems[2].AttributePath).To(Equal(".spec.rules[1]"))
+		Expect(problems[2].Message).To(Equal("Rules with a redirect or direct response can't define IP allow or deny lists"))
+	})
+
+	It("Should fail for invalid workload sources", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/orders",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						From: &gatewayv1beta1.RuleSource{
+							Principals: []string{
+								"cluster.local/ns/orders/sa/checkout",
+								"*/ns/orders/sa/checkout",
+								"cluster.local/ns/orders/sa/*",
+								"spiffe://cluster.local/ns/orders/sa/checkout",
+								"*/ns/orders/sa/*",
+								"cluster.local/ns/*/sa/checkout",
+							},
+							Namespaces: []string{"orders", "Orders"},
+						},
+					},
+					{
+						Path: "/payments",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("allow", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						From:    &gatewayv1beta1.RuleSource{},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(5))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].from.principals[3]"))
+		Expect(problems[0].Message).To(Equal(`Invalid principal "spiffe://cluster.local/ns/orders/sa/checkout", must have the format <trust domain>/ns/<namespace>/sa/<service account>`))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[0].from.principals[4]"))
+		Expect(problems[2].AttributePath).To(Equal(".spec.rules[0].from.principals[5]"))
+		Expect(problems[3].AttributePath).To(Equal(".spec.rules[0].from.namespaces[1]"))
+		Expect(problems[3].Message).To(Equal(`Invalid namespace "Orders"`))
+		Expect(problems[4].AttributePath).To(Equal(".spec.rules[1].from"))
+		Expect(problems[4].Message).To(Equal("At least one principal or namespace must be defined"))
+	})
+
+	It("Should fail for CORS credentials without origins defined in the APIRule", func() {
+		//given
+		allowCredentials := true
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+				Cors: &gatewayv1beta1.CorsPolicy{
+					AllowCredentials: &allowCredentials,
+				},
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+					},
+					{
+						Path: "/bcd",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						Cors: &gatewayv1beta1.CorsPolicy{
+							AllowOrigins: []*gatewayv1beta1.StringMatch{{Regex: ".*"}},
+						},
+					},
+					{
+						Path: "/cde",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"GET"},
+						Cors: &gatewayv1beta1.CorsPolicy{
+							AllowOrigins: []*gatewayv1beta1.StringMatch{{Exact: "https://example.com"}},
+						},
+					},
+				},
+			},
+		}
+
+		service := getService(sampleServiceName)
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].cors.allowCredentials"))
+		Expect(problems[0].Message).To(Equal("allowCredentials requires allowOrigins to be defined in the APIRule"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[1].cors.allowCredentials"))
+		Expect(problems[1].Message).To(Equal("allowCredentials cannot be used with a wildcard origin"))
+	})
+
+	It("Should not fail with service without labels selector by default", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"POST"},
+					},
+				},
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
+				Host:    getHost(sampleValidHost),
+			},
+		}
+
+		service := getService(sampleServiceName)
+		service.Spec.Selector = map[string]string{}
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(0))
+	})
+
+	It("Should not fail with service on path level by default", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path:    "/abc",
+						Service: getApiRuleService(sampleServiceName, uint32(8080)),
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"POST"},
+					},
+				},
+				Host: getHost(sampleValidHost),
+			},
+		}
+
+		service := getService(sampleServiceName)
+		service.Spec.Selector = map[string]string{}
+		fakeClient := buildFakeClient(service)
+
+		//when
+		problems := (&APIRuleValidator{
+			HandlerValidator:          handlerValidatorMock,
+			AccessStrategiesValidator: asValidatorMock,
+			DomainAllowList:           testDomainAllowlist,
+		}).Validate(context.TODO(), fakeClient, input, networkingv1beta1.VirtualServiceList{})
+
+		//then
+		Expect(problems).To(HaveLen(0))
+	})
+
+	It("Should succeed with service without namespace", func() {
+		//given
+		input := &gatewayv1beta1.APIRule{
+			Spec: gatewayv1beta1.APIRuleSpec{
+				Rules: []gatewayv1beta1.Rule{
+					{
+						Path: "/abc",
+						AccessStrategies: []*gatewayv1beta1.Authenticator{
+							toAuthenticator("noop", emptyConfig()),
+						},
+						Methods: []string{"POST"},
+					},
+				},
+				Service: getApiRuleService(sampleServiceName, uint32(8080)),
 				Host:    getHost(sampleValidHost),
 			},
 		}
@@ -1086,6 +2375,15 @@ var _ = Describe("Validator for", func() {
 
 })
 
+// failingListClient is a client whose List calls fail
+type failingListClient struct {
+	client.Client
+}
+
+func (c failingListClient) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return errors.New("list failed")
+}
+
 func buildFakeClient(objs ...client.Object) client.Client {
 	scheme := runtime.NewScheme()
 	err := networkingv1beta1.AddToScheme(scheme)
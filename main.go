@@ -27,23 +27,29 @@ import (
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"istio.io/api/networking/v1beta1"
 
 	rulev1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
 	"github.com/vrischmann/envconfig"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/api/v1beta1"
+	gatewayv1beta2 "github.com/kyma-project/api-gateway/api/v1beta2"
 	"github.com/kyma-project/api-gateway/controllers"
+	"github.com/kyma-project/api-gateway/internal/introspection"
 	"github.com/kyma-project/api-gateway/internal/validation"
 	"github.com/pkg/errors"
 	//+kubebuilder:scaffold:imports
@@ -62,8 +68,10 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(gatewayv1beta1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1beta2.AddToScheme(scheme))
 
 	utilruntime.Must(networkingv1beta1.AddToScheme(scheme))
+	utilruntime.Must(networkingv1alpha3.AddToScheme(scheme))
 	utilruntime.Must(rulev1alpha1.AddToScheme(scheme))
 	utilruntime.Must(securityv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
@@ -82,6 +90,11 @@ func main() {
 	var generatedObjectsLabels string
 	var reconciliationPeriod uint
 	var errorReconciliationPeriod uint
+	var webhookPort int
+	var enableConversionWebhook bool
+	var introspectionAuthorizerAddr string
+	var introspectionProvider string
+	var introspectionAuthorizerOnly bool
 
 	const blockListedSubdomains string = "api"
 
@@ -100,16 +113,27 @@ func main() {
 	flag.StringVar(&generatedObjectsLabels, "generated-objects-labels", "", "Comma-separated list of key=value pairs used to label generated objects")
 	flag.UintVar(&reconciliationPeriod, "reconciliation-period", 0, "Default reconciliation period when no error happened in the previous run [s]")
 	flag.UintVar(&errorReconciliationPeriod, "error-reconciliation-period", 0, "Reconciliation period after an error happened in the previous run (e.g. VirtualService confict) [s]")
+	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the conversion webhook server binds to.")
+	flag.BoolVar(&enableConversionWebhook, "enable-conversion-webhook", false,
+		"Enable the conversion webhook of the APIRule versions. Requires the serving certificate in /tmp/k8s-webhook-server/serving-certs.")
+	flag.StringVar(&introspectionAuthorizerAddr, "introspection-authorizer-addr", "", "The address the token introspection authorizer binds to. Optional, the authorizer is disabled if empty.")
+	flag.StringVar(&introspectionProvider, "introspection-provider", "", "The name of the Istio extension provider referencing the token introspection authorizer. Optional.")
+	flag.BoolVar(&introspectionAuthorizerOnly, "introspection-authorizer-only", false,
+		"Run only the token introspection authorizer without the controller and the conversion webhook. Requires introspection-authorizer-addr.")
 
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 
-	if oathkeeperSvcAddr == "" {
+	if introspectionAuthorizerOnly && introspectionAuthorizerAddr == "" {
+		setupLog.Error(fmt.Errorf("introspection-authorizer-addr can't be empty"), "unable to set up token introspection authorizer")
+		os.Exit(1)
+	}
+	if oathkeeperSvcAddr == "" && !introspectionAuthorizerOnly {
 		setupLog.Error(fmt.Errorf("oathkeeper-svc-address can't be empty"), "unable to create controller", "controller", "Api")
 		os.Exit(1)
 	}
-	if oathkeeperSvcPort == 0 {
+	if oathkeeperSvcPort == 0 && !introspectionAuthorizerOnly {
 		setupLog.Error(fmt.Errorf("oathkeeper-svc-port can't be empty"), "unable to create controller", "controller", "Api")
 		os.Exit(1)
 	}
@@ -142,42 +166,69 @@ func main() {
 			BindAddress: metricsAddr,
 		},
 		HealthProbeBindAddress: healthProbeAddr,
-		LeaderElection:         enableLeaderElection,
+		LeaderElection:         enableLeaderElection && !introspectionAuthorizerOnly,
 		LeaderElectionID:       "69358922.kyma-project.io",
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Port: webhookPort,
+		}),
+		// The Secrets holding JWKS are read directly, since only the metadata of the Secrets is watched
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				DisableFor: []client.Object{&corev1.Secret{}},
+			},
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	additionalLabels, err := parseLabels(generatedObjectsLabels)
-	if err != nil {
-		setupLog.Error(err, "parsing labels failed")
-		os.Exit(1)
-	}
+	// The authorizer runs in its own Deployment with an Istio sidecar, which the controller manager can't have because of
+	// the conversion webhook
+	if !introspectionAuthorizerOnly {
+		additionalLabels, err := parseLabels(generatedObjectsLabels)
+		if err != nil {
+			setupLog.Error(err, "parsing labels failed")
+			os.Exit(1)
+		}
 
-	config := controllers.ApiRuleReconcilerConfiguration{
-		OathkeeperSvcAddr:         oathkeeperSvcAddr,
-		OathkeeperSvcPort:         oathkeeperSvcPort,
-		AllowListedDomains:        allowListedDomains,
-		BlockListedServices:       blockListedServices,
-		DomainName:                domainName,
-		CorsAllowOrigins:          corsAllowOrigins,
-		CorsAllowMethods:          corsAllowMethods,
-		CorsAllowHeaders:          corsAllowHeaders,
-		AdditionalLabels:          additionalLabels,
-		ReconciliationPeriod:      reconciliationPeriod,
-		ErrorReconciliationPeriod: errorReconciliationPeriod,
-	}
+		config := controllers.ApiRuleReconcilerConfiguration{
+			OathkeeperSvcAddr:         oathkeeperSvcAddr,
+			OathkeeperSvcPort:         oathkeeperSvcPort,
+			AllowListedDomains:        allowListedDomains,
+			BlockListedServices:       blockListedServices,
+			DomainName:                domainName,
+			CorsAllowOrigins:          corsAllowOrigins,
+			CorsAllowMethods:          corsAllowMethods,
+			CorsAllowHeaders:          corsAllowHeaders,
+			AdditionalLabels:          additionalLabels,
+			ReconciliationPeriod:      reconciliationPeriod,
+			ErrorReconciliationPeriod: errorReconciliationPeriod,
+			IntrospectionProvider:     introspectionProvider,
+		}
 
-	reconciler, err := controllers.NewApiRuleReconciler(mgr, config)
-	if err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "APIRule")
-		os.Exit(1)
+		reconciler, err := controllers.NewApiRuleReconciler(mgr, config)
+		if err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "APIRule")
+			os.Exit(1)
+		}
+		if err = reconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to setup controller", "controller", "APIRule")
+			os.Exit(1)
+		}
+		if enableConversionWebhook {
+			if err = (&gatewayv1beta1.APIRule{}).SetupWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", "APIRule")
+				os.Exit(1)
+			}
+		}
 	}
-	if err = reconciler.SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to setup controller", "controller", "APIRule")
-		os.Exit(1)
+	if introspectionAuthorizerAddr != "" {
+		authorizer := introspection.NewAuthorizer(mgr.GetClient(), mgr.GetAPIReader(), ctrl.Log.WithName("introspection"))
+		if err = mgr.Add(&introspection.Server{Addr: introspectionAuthorizerAddr, Authorizer: authorizer}); err != nil {
+			setupLog.Error(err, "unable to set up token introspection authorizer")
+			os.Exit(1)
+		}
 	}
 	//+kubebuilder:scaffold:builder
 
@@ -34,7 +34,12 @@ func (h *RetryableHttpClient) CallEndpointWithRetries(url string, validator Http
 
 // CallEndpointWithHeadersWithRetries returns error if the status code is not in between bounds of status predicate after retrying deadline is reached
 func (h *RetryableHttpClient) CallEndpointWithHeadersWithRetries(requestHeaders map[string]string, url string, validator HttpResponseAsserter) error {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	return h.CallEndpointWithMethodAndHeadersWithRetries(http.MethodGet, requestHeaders, url, validator)
+}
+
+// CallEndpointWithMethodAndHeadersWithRetries returns error if the status code of the request with the given method is not in between bounds of status predicate after retrying deadline is reached
+func (h *RetryableHttpClient) CallEndpointWithMethodAndHeadersWithRetries(method string, requestHeaders map[string]string, url string, validator HttpResponseAsserter) error {
+	req, err := http.NewRequest(method, url, nil)
 	if err != nil {
 		return err
 	}
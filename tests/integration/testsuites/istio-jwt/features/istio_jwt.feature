@@ -99,6 +99,17 @@ Feature: Exposing endpoints with Istio JWT authorization strategy
     And DiffSvcSameMethods: Calling the "/hello" endpoint with a valid "JWT" token should result in status between 200 and 299
     And DiffSvcSameMethods: Teardown httpbin service
 
+  Scenario: Exposing different services on the same path with different methods
+    Given DiffSvcSamePath: There is a httpbin service
+    And DiffSvcSamePath: There is a workload and service for httpbin and helloworld
+    And DiffSvcSamePath: There is an endpoint secured with JWT on path "/anything" for httpbin service with methods '["GET"]'
+    And DiffSvcSamePath: There is an endpoint secured with JWT on path "/anything" for helloworld service with methods '["POST"]'
+    When DiffSvcSamePath: The APIRule is applied
+    Then DiffSvcSamePath: Calling the "/anything" endpoint with "GET" method and a valid "JWT" token should result in status between 200 and 299
+    # helloworld doesn't serve /anything, so the status shows that the POST request is routed to helloworld instead of httpbin
+    And DiffSvcSamePath: Calling the "/anything" endpoint with "POST" method and a valid "JWT" token should result in status between 404 and 404
+    And DiffSvcSamePath: Teardown httpbin service
+
   Scenario: Exposing a JWT secured endpoint with unavailable issuer and jwks URL
     Given JwtIssuerUnavailable: There is a httpbin service
     Given JwtIssuerUnavailable: There is an endpoint secured with JWT on path "/ip" with invalid issuer and jwks
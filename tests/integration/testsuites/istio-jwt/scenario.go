@@ -10,6 +10,7 @@ import (
 	"github.com/kyma-project/api-gateway/tests/integration/pkg/testcontext"
 	"golang.org/x/oauth2/clientcredentials"
 	"k8s.io/client-go/dynamic"
+	"net/http"
 	"strings"
 )
 
@@ -86,6 +87,10 @@ func (s *scenario) callingTheEndpointWithValidTokenShouldResultInBodyContaining(
 }
 
 func (s *scenario) callingEndpointWithHeadersWithRetries(url string, tokenType string, asserter helpers.HttpResponseAsserter, requestHeaders map[string]string, tokenFrom *tokenFrom) error {
+	return s.callingEndpointWithMethodAndHeadersWithRetries(http.MethodGet, url, tokenType, asserter, requestHeaders, tokenFrom)
+}
+
+func (s *scenario) callingEndpointWithMethodAndHeadersWithRetries(method string, url string, tokenType string, asserter helpers.HttpResponseAsserter, requestHeaders map[string]string, tokenFrom *tokenFrom) error {
 	if requestHeaders == nil {
 		requestHeaders = make(map[string]string)
 	}
@@ -114,7 +119,7 @@ func (s *scenario) callingEndpointWithHeadersWithRetries(url string, tokenType s
 		return fmt.Errorf("unsupported token type: %s", tokenType)
 	}
 
-	return s.httpClient.CallEndpointWithHeadersWithRetries(requestHeaders, url, asserter)
+	return s.httpClient.CallEndpointWithMethodAndHeadersWithRetries(method, requestHeaders, url, asserter)
 }
 
 func (s *scenario) callingTheEndpointWithoutTokenShouldResultInStatusBetween(endpoint string, lower, higher int) error {
@@ -0,0 +1,35 @@
+package istiojwt
+
+import (
+	"fmt"
+	"github.com/cucumber/godog"
+	"github.com/kyma-project/api-gateway/tests/integration/pkg/helpers"
+	"github.com/kyma-project/api-gateway/tests/integration/pkg/testcontext"
+	"strings"
+)
+
+func initDiffServiceSamePath(ctx *godog.ScenarioContext, ts *testsuite) {
+	scenario := ts.createScenario("istio-jwt-diff-svc-same-path.yaml", "istio-diff-service-same-path")
+
+	ctx.Step(`DiffSvcSamePath: There is a httpbin service$`, scenario.thereIsAHttpbinService)
+	ctx.Step(`DiffSvcSamePath: There is a workload and service for httpbin and helloworld$`, scenario.thereAreTwoServices)
+	ctx.Step(`DiffSvcSamePath: There is an endpoint secured with JWT on path "([^"]*)" for (httpbin|helloworld) service with methods '(\[.*\])'$`, scenario.thereIsAJwtSecuredPathForServiceWithMethods)
+	ctx.Step(`DiffSvcSamePath: The APIRule is applied$`, scenario.theAPIRuleIsApplied)
+	ctx.Step(`DiffSvcSamePath: Calling the "([^"]*)" endpoint with "([^"]*)" method and a valid "([^"]*)" token should result in status between (\d+) and (\d+)$`, scenario.callingTheEndpointWithMethodAndValidTokenShouldResultInStatusBetween)
+	ctx.Step(`DiffSvcSamePath: Teardown httpbin service$`, scenario.teardownHttpbinService)
+}
+
+func (s *scenario) thereIsAJwtSecuredPathForServiceWithMethods(path, service, methods string) {
+	s.ManifestTemplate["jwtSecuredPath"] = path
+	s.ManifestTemplate[fmt.Sprintf("%sMethods", service)] = methods
+}
+
+func (s *scenario) callingTheEndpointWithMethodAndValidTokenShouldResultInStatusBetween(endpoint, method, tokenType string, lower, higher int) error {
+	asserter := &helpers.StatusPredicate{LowerStatusBound: lower, UpperStatusBound: higher}
+	tokenFrom := tokenFrom{
+		From:     testcontext.AuthorizationHeaderName,
+		Prefix:   testcontext.AuthorizationHeaderPrefix,
+		AsHeader: true,
+	}
+	return s.callingEndpointWithMethodAndHeadersWithRetries(method, fmt.Sprintf("%s/%s", s.Url, strings.TrimLeft(endpoint, "/")), tokenType, asserter, nil, &tokenFrom)
+}
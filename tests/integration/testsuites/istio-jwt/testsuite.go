@@ -79,6 +79,7 @@ func (t *testsuite) InitScenarios(ctx *godog.ScenarioContext) {
 	initJwtTwoNamespaces(ctx, t)
 	initJwtServiceFallback(ctx, t)
 	initDiffServiceSameMethods(ctx, t)
+	initDiffServiceSamePath(ctx, t)
 	initJwtUnavailableIssuer(ctx, t)
 	initJwtIssuerJwksNotMatch(ctx, t)
 	initMutatorCookie(ctx, t)